@@ -101,8 +101,19 @@ type LinoBlockchain struct {
 
 	// start from previous exported state
 	importRequired bool
+
+	// invariants contributed by modules, checked every invCheckPeriod blocks
+	invariants            *types.InvariantRegistry
+	invCheckPeriod        int64
+	haltOnBrokenInvariant bool
+
+	// migrations of upgrades scheduled by protocol upgrade proposals, keyed by upgrade name
+	upgradeHandlers map[string]UpgradeHandler
 }
 
+// UpgradeHandler - migration executed once at the height of the upgrade with the same name
+type UpgradeHandler func(ctx sdk.Context) sdk.Error
+
 // NewLinoBlockchain - create a Lino Blockchain instance
 func NewLinoBlockchain(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptions ...func(*bam.BaseApp)) *LinoBlockchain {
@@ -131,7 +142,7 @@ func NewLinoBlockchain(
 	lb.globalManager = global.NewGlobalManager(lb.CapKeyGlobalStore, lb.paramHolder)
 	registerEvent(lb.globalManager.WireCodec())
 
-	lb.reputationManager = rep.NewReputationManager(lb.CapKeyReputationV2Store, lb.paramHolder)
+	lb.reputationManager = rep.NewReputationManager(lb.CapKeyReputationV2Store, lb.paramHolder, lb.accountManager)
 	lb.voteManager = vote.NewVoteManager(lb.CapKeyVoteStore, lb.paramHolder)
 	lb.infraManager = infra.NewInfraManager(lb.CapKeyInfraStore, lb.paramHolder)
 	lb.developerManager = developer.NewDeveloperManager(lb.CapKeyDeveloperStore, lb.paramHolder)
@@ -140,6 +151,17 @@ func NewLinoBlockchain(
 	// TODO(yumin): update this when price manager is implemented.
 	lb.postManager = postmn.NewPostManager(lb.CapKeyPostStore, lb.accountManager, &lb.globalManager, lb.developerManager, lb.reputationManager, pricemn.DummyPriceManager{})
 
+	lb.invariants = types.NewInvariantRegistry()
+	lb.accountManager.RegisterInvariants(lb.invariants)
+	lb.voteManager.RegisterInvariants(lb.invariants)
+	lb.valManager.RegisterInvariants(lb.invariants)
+	lb.developerManager.RegisterInvariants(lb.invariants)
+	lb.globalManager.RegisterInvariants(lb.invariants)
+	lb.proposalManager.RegisterInvariants(lb.invariants)
+
+	lb.upgradeHandlers = make(map[string]UpgradeHandler)
+	lb.registerUpgradeHandlers()
+
 	lb.Router().
 		AddRoute(acc.RouterKey, acc.NewHandler(lb.accountManager, &lb.globalManager)).
 		AddRoute(posttypes.RouterKey, post.NewHandler(lb.postManager)).
@@ -236,6 +258,7 @@ func registerEvent(cdc *wire.Codec) {
 	cdc.RegisterConcrete(acc.ReturnCoinEvent{}, "lino/eventReturn", nil)
 	cdc.RegisterConcrete(param.ChangeParamEvent{}, "lino/eventCpe", nil)
 	cdc.RegisterConcrete(proposal.DecideProposalEvent{}, "lino/eventDpe", nil)
+	cdc.RegisterConcrete(proposal.TreasurySpendEvent{}, "lino/eventTreasurySpend", nil)
 }
 
 // SetImportRequired - set whether import is required in initchainer.
@@ -244,6 +267,29 @@ func (lb *LinoBlockchain) SetImportRequired(v bool) {
 	lb.importRequired = v
 }
 
+// SetInvariantCheckPeriod - check invariants every period blocks, zero disables the check.
+// This can be done even after seal().
+func (lb *LinoBlockchain) SetInvariantCheckPeriod(period int64) {
+	lb.invCheckPeriod = period
+}
+
+// SetHaltOnBrokenInvariant - halt instead of logging when invariant is broken.
+// This can be done even after seal().
+func (lb *LinoBlockchain) SetHaltOnBrokenInvariant(v bool) {
+	lb.haltOnBrokenInvariant = v
+}
+
+// SetUpgradeHandler - register migration of upgrade with name.
+// This can be done even after seal().
+func (lb *LinoBlockchain) SetUpgradeHandler(name string, handler UpgradeHandler) {
+	lb.upgradeHandlers[name] = handler
+}
+
+// registerUpgradeHandlers - handlers of upgrades supported by this binary, the chain
+// halts at the height of a scheduled upgrade which is not registered here.
+func (lb *LinoBlockchain) registerUpgradeHandlers() {
+}
+
 // custom logic for lino blockchain initialization
 func (lb *LinoBlockchain) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 	// set init time to zero
@@ -426,6 +472,26 @@ func (lb *LinoBlockchain) toAppInfra(
 
 // init process for a block, execute time events and fire incompetent validators
 func (lb *LinoBlockchain) beginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// one time migration for upgrade1update5, parameters introduced since then are
+	// missing in stored state.
+	if ctx.BlockHeight() == types.BlockchainUpgrade1Update5Height {
+		if err := lb.paramHolder.SetMissingParams(ctx); err != nil {
+			panic(err)
+		}
+	}
+
+	// one time reconciliation for upgrade1update10, coins recycled into inflation pools
+	// before then are counted twice in total supply.
+	if ctx.BlockHeight() == types.BlockchainUpgrade1Update10Height {
+		holdings, _, err := lb.invariants.SupplyBreakdown(ctx)
+		if err != nil {
+			panic(err)
+		}
+		if err := lb.globalManager.ReconcileSupplyOffset(ctx, holdings); err != nil {
+			panic(err)
+		}
+	}
+
 	chainStartTime, err := lb.globalManager.GetChainStartTime(ctx)
 	if err != nil {
 		panic(err)
@@ -449,62 +515,135 @@ func (lb *LinoBlockchain) beginBlocker(ctx sdk.Context, req abci.RequestBeginBlo
 	}
 
 	global.BeginBlocker(ctx, req, &lb.globalManager)
+	// after global begin blocker, which clears the event cache, so that events
+	// registered by the upgrade handler are kept.
+	lb.applyUpgradePlan(ctx)
 	actualPenalty := val.BeginBlocker(ctx, req, lb.valManager)
 
-	// add coins back to inflation pool
-	if err := lb.globalManager.AddToValidatorInflationPool(ctx, actualPenalty); err != nil {
+	// add coins back to inflation pool and community treasury
+	if err := lb.globalManager.DistributePenalty(ctx, actualPenalty); err != nil {
 		panic(err)
 	}
 
 	lb.syncInfoWithVoteManager(ctx)
-	lb.executeTimeEvents(ctx)
-	return abci.ResponseBeginBlock{}
+	tags := lb.executeTimeEvents(ctx)
+	return abci.ResponseBeginBlock{
+		Tags: tags.ToKVPairs(),
+	}
 }
 
-// execute events between last block time and current block time
-func (lb *LinoBlockchain) executeTimeEvents(ctx sdk.Context) {
+// run handler of scheduled upgrade once its height is reached, halt the chain
+// if this binary doesn't have the handler so that operators can switch binary.
+// If the handler fails, its writes are discarded and the upgrade is canceled,
+// a fixed upgrade has to be scheduled by a new protocol upgrade proposal.
+func (lb *LinoBlockchain) applyUpgradePlan(ctx sdk.Context) {
+	if !lb.proposalManager.DoesUpgradePlanExist(ctx) {
+		return
+	}
+	plan, err := lb.proposalManager.GetUpgradePlan(ctx)
+	if err != nil {
+		panic(err)
+	}
+	if ctx.BlockHeight() < plan.Height {
+		return
+	}
+	handler, ok := lb.upgradeHandlers[plan.Name]
+	if !ok {
+		msg := fmt.Sprintf("UPGRADE %q NEEDED at height %d: %s", plan.Name, plan.Height, plan.Link)
+		ctx.Logger().Error(msg)
+		panic(msg)
+	}
+	cachedCtx, write := ctx.CacheContext()
+	snapshot := lb.globalManager.SnapshotEventCache()
+	if err := handler(cachedCtx); err != nil {
+		lb.globalManager.RevertEventCache(snapshot)
+		ctx.Logger().Error(fmt.Sprintf(
+			"failed to apply upgrade %q at height %d, upgrade is canceled: %s",
+			plan.Name, ctx.BlockHeight(), err.Error()))
+		lb.proposalManager.CancelUpgrade(ctx)
+		return
+	}
+	write()
+	if err := lb.proposalManager.MarkUpgradeDone(ctx); err != nil {
+		panic(err)
+	}
+	ctx.Logger().Info(fmt.Sprintf("applied upgrade %q at height %d", plan.Name, ctx.BlockHeight()))
+}
+
+// execute events between last block time and current block time,
+// returns tags of executed events.
+func (lb *LinoBlockchain) executeTimeEvents(ctx sdk.Context) (tags sdk.Tags) {
 	currentTime := ctx.BlockHeader().Time.Unix()
 
 	lastBlockTime, err := lb.globalManager.GetLastBlockTime(ctx)
 	if err != nil {
 		panic(err)
 	}
-	for i := lastBlockTime; i < currentTime; i++ {
-		if timeEvents := lb.globalManager.GetTimeEventListAtTime(ctx, i); timeEvents != nil {
-			lb.executeEvents(ctx, timeEvents.Events)
-			lb.globalManager.RemoveTimeEventList(ctx, i)
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update5Height {
+		for i := lastBlockTime; i < currentTime; i++ {
+			if timeEvents := lb.globalManager.GetTimeEventListAtTime(ctx, i); timeEvents != nil {
+				tags = tags.AppendTags(lb.executeEvents(ctx, i, timeEvents.Events))
+				lb.globalManager.RemoveTimeEventList(ctx, i)
+			}
+		}
+	} else {
+		// events are fetched before execution, as executing events may write to the global store.
+		rows, err := lb.globalManager.GetTimeEventListsInRange(ctx, lastBlockTime, currentTime)
+		if err != nil {
+			panic(err)
+		}
+		for _, row := range rows {
+			tags = tags.AppendTags(lb.executeEvents(ctx, row.UnixTime, row.TimeEventList.Events))
+			lb.globalManager.RemoveTimeEventList(ctx, row.UnixTime)
 		}
 	}
 	if err := lb.globalManager.SetLastBlockTime(ctx, currentTime); err != nil {
 		panic(err)
 	}
+	return tags
 }
 
-// execute events in list based on their type
-func (lb *LinoBlockchain) executeEvents(ctx sdk.Context, eventList []types.Event) sdk.Error {
+// execute events in list based on their type, each event is executed in a cached
+// context, failed events are skipped and recorded without changing any state,
+// events registered to global manager's event cache by a failed event are reverted.
+func (lb *LinoBlockchain) executeEvents(
+	ctx sdk.Context, unixTime int64, eventList []types.Event) (tags sdk.Tags) {
 	for _, event := range eventList {
-		switch e := event.(type) {
-		case postmn.RewardEvent:
-			// TODO(yumin): need to rethink this part.
-			if err := e.Execute(ctx, lb.postManager.(postmn.PostManager)); err != nil {
-				panic(err)
-			}
-		case acc.ReturnCoinEvent:
-			if err := e.Execute(ctx, lb.accountManager); err != nil {
-				panic(err)
-			}
-		case proposal.DecideProposalEvent:
-			if err := e.Execute(
-				ctx, lb.voteManager, lb.valManager, lb.accountManager, lb.proposalManager,
-				lb.postManager, &lb.globalManager); err != nil {
-				panic(err)
-			}
-		case param.ChangeParamEvent:
-			if err := e.Execute(ctx, lb.paramHolder); err != nil {
+		cachedCtx, write := ctx.CacheContext()
+		snapshot := lb.globalManager.SnapshotEventCache()
+		if err := lb.executeEvent(cachedCtx, event); err != nil {
+			lb.globalManager.RevertEventCache(snapshot)
+			ctx.Logger().Error(fmt.Sprintf("failed to execute event %T at %d: %s", event, unixTime, err.Error()))
+			if err := lb.globalManager.RecordFailedEvent(ctx, event, unixTime, err); err != nil {
 				panic(err)
 			}
+			continue
+		}
+		write()
+		if e, ok := event.(types.TaggedEvent); ok {
+			tags = tags.AppendTags(e.Tags())
 		}
 	}
+	return tags
+}
+
+// execute one event based on its type
+func (lb *LinoBlockchain) executeEvent(ctx sdk.Context, event types.Event) sdk.Error {
+	switch e := event.(type) {
+	case postmn.RewardEvent:
+		// TODO(yumin): need to rethink this part.
+		return e.Execute(ctx, lb.postManager.(postmn.PostManager))
+	case acc.ReturnCoinEvent:
+		return e.Execute(ctx, lb.accountManager)
+	case proposal.DecideProposalEvent:
+		return e.Execute(
+			ctx, lb.voteManager, lb.valManager, lb.accountManager, lb.proposalManager,
+			lb.postManager, lb.reputationManager, &lb.globalManager)
+	case param.ChangeParamEvent:
+		return e.Execute(ctx, lb.paramHolder)
+	case proposal.TreasurySpendEvent:
+		return e.Execute(ctx, lb.accountManager, &lb.globalManager)
+	}
 	return nil
 }
 
@@ -514,8 +653,11 @@ func (lb *LinoBlockchain) endBlocker(ctx sdk.Context, req abci.RequestEndBlock)
 	rep.EndBlocker(ctx, req, lb.reputationManager)
 
 	global.EndBlocker(ctx, req, &lb.globalManager)
+	if lb.invCheckPeriod > 0 && ctx.BlockHeight()%lb.invCheckPeriod == 0 {
+		lb.assertInvariants(ctx)
+	}
 	// update validator set.
-	validatorUpdates, err := lb.valManager.GetValidatorUpdates(ctx)
+	validatorUpdates, err := lb.valManager.GetValidatorUpdates(ctx, lb.voteManager)
 	if err != nil {
 		panic(err)
 	}
@@ -524,6 +666,25 @@ func (lb *LinoBlockchain) endBlocker(ctx sdk.Context, req abci.RequestEndBlock)
 	}
 }
 
+// check invariants after all events of the block are committed
+func (lb *LinoBlockchain) assertInvariants(ctx sdk.Context) {
+	start := time.Now()
+	if err := lb.invariants.AssertInvariants(ctx); err != nil {
+		if lb.haltOnBrokenInvariant {
+			panic(err)
+		}
+		ctx.Logger().Error(fmt.Sprintf("height %d: %s", ctx.BlockHeight(), err.Error()))
+		return
+	}
+	ctx.Logger().Info(fmt.Sprintf("invariants checked at height %d in %s", ctx.BlockHeight(), time.Since(start)))
+}
+
+// CheckInvariants - check invariants against latest committed state
+func (lb *LinoBlockchain) CheckInvariants() error {
+	ctx := lb.NewContext(true, abci.Header{Height: lb.LastBlockHeight()})
+	return lb.invariants.AssertInvariants(ctx)
+}
+
 func (lb *LinoBlockchain) increaseMinute(ctx sdk.Context) {
 	pastMinutes, err := lb.globalManager.GetPastMinutes(ctx)
 	if err != nil {
@@ -551,7 +712,10 @@ func (lb *LinoBlockchain) increaseMinute(ctx sdk.Context) {
 // add hourly inflation to content creator reward pool
 func (lb *LinoBlockchain) executeHourlyEvent(ctx sdk.Context) {
 	lb.globalManager.DistributeHourlyInflation(ctx)
-	lb.distributeInflationToValidator(ctx)
+	if err := lb.valManager.DistributeInflationToValidator(
+		ctx, lb.accountManager, &lb.globalManager, lb.voteManager); err != nil {
+		panic(err)
+	}
 }
 
 // execute daily event, record consumption friction and lino power
@@ -571,31 +735,6 @@ func (lb *LinoBlockchain) executeAnnuallyEvent(ctx sdk.Context) {
 	}
 }
 
-// distribute inflation to validators
-// TODO: encaptulate module event inside module
-func (lb *LinoBlockchain) distributeInflationToValidator(ctx sdk.Context) {
-	lst, err := lb.valManager.GetValidatorList(ctx)
-	if err != nil {
-		panic(err)
-	}
-	coin, err := lb.globalManager.GetValidatorHourlyInflation(ctx)
-	if err != nil {
-		panic(err)
-	}
-	// give inflation to each validator evenly
-	for i, validator := range lst.OncallValidators {
-		var ratPerValidator sdk.Dec
-		// XXX(yumin): why in the previous version, it's guarged with
-		// if ctx.BlockHeader().Height > types.LinoBlockchainFirstUpdateHeight {
-		// though only differs in round?
-		ratPerValidator = coin.ToDec().Quo(sdk.NewDec(int64(len(lst.OncallValidators) - i)))
-		coinPerValidator := types.DecToCoin(ratPerValidator)
-		lb.accountManager.AddSavingCoin(
-			ctx, validator, coinPerValidator, "", "", types.ValidatorInflation)
-		coin = coin.Minus(coinPerValidator)
-	}
-}
-
 // distribute inflation to infra provider monthly
 // TODO: encaptulate module event inside module
 func (lb *LinoBlockchain) distributeInflationToInfraProvider(ctx sdk.Context) {
@@ -18,10 +18,12 @@ import (
 
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	acc "github.com/lino-network/lino/x/account"
 	devModel "github.com/lino-network/lino/x/developer/model"
 	globalModel "github.com/lino-network/lino/x/global/model"
 	infraModel "github.com/lino-network/lino/x/infra/model"
 	postmn "github.com/lino-network/lino/x/post/manager"
+	proposalModel "github.com/lino-network/lino/x/proposal/model"
 )
 
 var (
@@ -187,6 +189,8 @@ func TestGenesisFromConfig(t *testing.T) {
 			ContentCreatorAllocation: types.NewDecFromRat(65, 100),
 			DeveloperAllocation:      types.NewDecFromRat(10, 100),
 			ValidatorAllocation:      types.NewDecFromRat(5, 100),
+			TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+			TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 		},
 		param.InfraInternalAllocationParam{
 			StorageAllocation: types.NewDecFromRat(50, 100),
@@ -198,6 +202,7 @@ func TestGenesisFromConfig(t *testing.T) {
 			VoterCoinReturnTimes:           int64(7),
 			DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 			DelegatorCoinReturnTimes:       int64(7),
+			RedelegateIntervalSec:          int64(7 * 24 * 3600),
 		},
 		param.ProposalParam{
 			ContentCensorshipDecideSec:  int64(24 * 7 * 3600),
@@ -214,6 +219,16 @@ func TestGenesisFromConfig(t *testing.T) {
 			ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 			ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 			ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+			TextProposalDecideSec:  int64(7 * 24 * 3600),
+			TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+			ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+			ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+			ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+			ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+			ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+			ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 		},
 		param.DeveloperParam{
 			DeveloperMinDeposit:            types.NewCoinFromInt64(1000000 * types.Decimals),
@@ -231,6 +246,10 @@ func TestGenesisFromConfig(t *testing.T) {
 			PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 			ValidatorListSize:              int64(21),
 			AbsentCommitLimitation:         int64(600), // 10min
+			ValidatorJailDurationSec:       int64(24 * 3600),
+			SignedBlocksWindow:             int64(2400),
+			MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+			MaxValidatorPowerPercent:       int64(10),
 		},
 		param.CoinDayParam{
 			SecondsToRecoverCoinDay: int64(7 * 24 * 3600),
@@ -252,7 +271,14 @@ func TestGenesisFromConfig(t *testing.T) {
 			MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
 		},
 		param.ReputationParam{
-			BestContentIndexN: 10,
+			BestContentIndexN:         10,
+			BestN:                     200,
+			UserMaxN:                  50,
+			RoundDurationSeconds:      25 * 3600,
+			SampleWindowSize:          10,
+			DecayFactor:               10,
+			ReferralClusterCapEnabled: false,
+			ReferralClusterMaxIF:      types.NewMiniDollar(10 * 100000000),
 		},
 	}
 	genesisState.InitGlobalMeta = globalModel.InitParamList{
@@ -312,7 +338,9 @@ func TestDistributeInflationToValidators(t *testing.T) {
 		expectBalanceList[i] = expectBaseBalance
 	}
 	lb.globalManager.DistributeHourlyInflation(ctx)
-	lb.distributeInflationToValidator(ctx)
+	err := lb.valManager.DistributeInflationToValidator(
+		ctx, lb.accountManager, &lb.globalManager, lb.voteManager)
+	assert.Nil(t, err)
 	// simulate app
 	// hourly inflation
 	inflationForValidator :=
@@ -394,7 +422,11 @@ func TestDistributeInflationToValidator(t *testing.T) {
 			t.Errorf("%s: failed to set inflation pool, got err %v", testName, err)
 		}
 
-		lb.distributeInflationToValidator(ctx)
+		err = lb.valManager.DistributeInflationToValidator(
+			ctx, lb.accountManager, &lb.globalManager, lb.voteManager)
+		if err != nil {
+			t.Errorf("%s: failed to distribute inflation to validator, got err %v", testName, err)
+		}
 		inflationPool, err := globalStore.GetInflationPool(ctx)
 		if err != nil {
 			t.Errorf("%s: failed to get inflation pool, got err %v", testName, err)
@@ -856,3 +888,195 @@ func TestGlobalTime(t *testing.T) {
 		assert.Equal(t, cs.expectLastBlockTime, lastBlockTime)
 	}
 }
+
+func TestCheckInvariants(t *testing.T) {
+	lb := newLinoBlockchain(t, 21)
+	assert.Nil(t, lb.CheckInvariants())
+
+	// register fee recycled before supply offset is recorded is counted twice,
+	// supply invariant is not checked until the surplus is reconciled.
+	ctx := lb.BaseApp.NewContext(true, abci.Header{
+		Height: types.BlockchainUpgrade1Update10Height - 1, ChainID: "Lino", Time: time.Unix(60, 0)})
+	res := acc.NewHandler(lb.accountManager, &lb.globalManager)(ctx, acc.NewRegisterMsg(
+		user1, "olduser", "100", secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()))
+	assert.True(t, res.IsOK())
+	assert.Nil(t, lb.invariants.AssertInvariants(ctx))
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update10Height)
+	assert.NotNil(t, lb.invariants.AssertInvariants(ctx))
+	holdings, _, err := lb.invariants.SupplyBreakdown(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, lb.globalManager.ReconcileSupplyOffset(ctx, holdings))
+	assert.Nil(t, lb.invariants.AssertInvariants(ctx))
+
+	// register fee is added to developer inflation pool.
+	res = acc.NewHandler(lb.accountManager, &lb.globalManager)(ctx, acc.NewRegisterMsg(
+		user1, "newuser", "100", secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()))
+	assert.True(t, res.IsOK())
+	assert.Nil(t, lb.invariants.AssertInvariants(ctx))
+
+	// friction of donation is held by global.
+	err = lb.postManager.CreatePost(ctx, types.AccountKey(user1), "post", types.AccountKey(user1), "content", "title")
+	assert.Nil(t, err)
+	err = lb.postManager.LinoDonate(
+		ctx, "validator1", types.NewCoinFromInt64(100*types.Decimals), types.AccountKey(user1), "post", "")
+	assert.Nil(t, err)
+	assert.Nil(t, lb.invariants.AssertInvariants(ctx))
+
+	// penalty is added to validator inflation pool and community treasury.
+	penalty, err := lb.valManager.PunishOncallValidator(
+		ctx, "validator2", types.NewCoinFromInt64(200*types.Decimals), types.PunishDidntVote)
+	assert.Nil(t, err)
+	assert.Nil(t, lb.globalManager.DistributePenalty(ctx, penalty))
+	assert.Nil(t, lb.invariants.AssertInvariants(ctx))
+
+	// coin created out of thin air breaks supply invariant.
+	err = lb.accountManager.AddSavingCoin(
+		ctx, types.AccountKey(user1), types.NewCoinFromInt64(1), "", "", types.TransferIn)
+	assert.Nil(t, err)
+	assert.NotNil(t, lb.invariants.AssertInvariants(ctx))
+
+	// halt on broken invariant.
+	lb.SetHaltOnBrokenInvariant(true)
+	assert.Panics(t, func() { lb.assertInvariants(ctx) })
+}
+
+func TestTimeEventTags(t *testing.T) {
+	lb := newLinoBlockchain(t, 1)
+	header := abci.Header{Height: 2, ChainID: "Lino", Time: time.Unix(60, 0)}
+	lb.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := lb.BaseApp.NewContext(false, header)
+	coin := types.NewCoinFromInt64(1 * types.Decimals)
+	events, err := acc.CreateCoinReturnEvents(
+		ctx, types.AccountKey(user1), 1, 10, coin, types.VoteReturnCoin)
+	assert.Nil(t, err)
+	err = lb.globalManager.RegisterCoinReturnEvent(ctx, events, 1, 10)
+	assert.Nil(t, err)
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+
+	res := lb.BeginBlock(abci.RequestBeginBlock{
+		Header: abci.Header{Height: 3, ChainID: "Lino", Time: time.Unix(120, 0)}})
+	expectTags := sdk.NewTags(
+		types.TagEventType, types.ReturnCoinEventType,
+		types.TagReceiver, user1,
+		types.TagAmount, types.CoinTagValue(coin),
+		types.TagDetailType, types.DetailTypeTagValue(types.VoteReturnCoin),
+	)
+	assert.Equal(t, expectTags.ToKVPairs(), res.Tags)
+}
+
+func TestExecuteEventsRecordsFailedEvent(t *testing.T) {
+	lb := newLinoBlockchain(t, 1)
+	ctx := lb.BaseApp.NewContext(true, abci.Header{Height: 2, ChainID: "Lino", Time: time.Unix(60, 0)})
+	coin := types.NewCoinFromInt64(1 * types.Decimals)
+	failed := acc.ReturnCoinEvent{Username: "nobody", Amount: coin, ReturnType: types.VoteReturnCoin}
+	succeeded := acc.ReturnCoinEvent{
+		Username: types.AccountKey(user1), Amount: coin, ReturnType: types.VoteReturnCoin}
+	saving, err := lb.accountManager.GetSavingFromBank(ctx, types.AccountKey(user1))
+	assert.Nil(t, err)
+
+	tags := lb.executeEvents(ctx, 50, []types.Event{failed, succeeded})
+	assert.Equal(t, succeeded.Tags(), tags)
+
+	failedEvents, err := lb.globalManager.GetFailedEvents(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(failedEvents))
+	assert.Equal(t, int64(1), failedEvents[0].ID)
+	assert.Equal(t, failed, failedEvents[0].Event)
+	assert.Equal(t, int64(50), failedEvents[0].UnixTime)
+	assert.Equal(t, int64(2), failedEvents[0].Height)
+	assert.Equal(t, acc.ErrAccountNotFound("nobody").Error(), failedEvents[0].Error)
+
+	newSaving, err := lb.accountManager.GetSavingFromBank(ctx, types.AccountKey(user1))
+	assert.Nil(t, err)
+	assert.True(t, saving.Plus(coin).IsEqual(newSaving))
+}
+
+func TestApplyUpgradePlan(t *testing.T) {
+	lb := newLinoBlockchain(t, 1)
+	ctx := lb.BaseApp.NewContext(true, abci.Header{Height: 10})
+
+	// nothing happens without scheduled upgrade
+	assert.NotPanics(t, func() { lb.applyUpgradePlan(ctx) })
+
+	plan := &proposalModel.UpgradePlan{Name: "upgrade2", Height: 20, Link: "link", ProposalID: "1"}
+	err := lb.proposalManager.ScheduleUpgrade(ctx, plan)
+	assert.Nil(t, err)
+
+	// halt at upgrade height if handler is not registered
+	assert.NotPanics(t, func() { lb.applyUpgradePlan(ctx) })
+	assert.Panics(t, func() { lb.applyUpgradePlan(ctx.WithBlockHeight(20)) })
+
+	// handler is executed once
+	executed := 0
+	lb.SetUpgradeHandler("upgrade2", func(ctx sdk.Context) sdk.Error {
+		executed++
+		return nil
+	})
+	lb.applyUpgradePlan(ctx.WithBlockHeight(20))
+	lb.applyUpgradePlan(ctx.WithBlockHeight(21))
+	assert.Equal(t, 1, executed)
+	assert.False(t, lb.proposalManager.DoesUpgradePlanExist(ctx))
+	assert.True(t, lb.proposalManager.IsUpgradeDone(ctx, "upgrade2"))
+
+	// only one upgrade is scheduled at a time
+	plan = &proposalModel.UpgradePlan{Name: "upgrade3", Height: 30, Link: "link", ProposalID: "2"}
+	err = lb.proposalManager.ScheduleUpgrade(ctx, plan)
+	assert.Nil(t, err)
+	err = lb.proposalManager.ScheduleUpgrade(
+		ctx, &proposalModel.UpgradePlan{Name: "upgrade4", Height: 40, Link: "link", ProposalID: "3"})
+	assert.NotNil(t, err)
+
+	// failed handler discards its writes and cancels the upgrade
+	lb.SetUpgradeHandler("upgrade3", func(ctx sdk.Context) sdk.Error {
+		if err := lb.globalManager.AddToCommunityTreasury(ctx, types.NewCoinFromInt64(1)); err != nil {
+			return err
+		}
+		return types.NewError(types.CodeTestDummyError, "")
+	})
+	treasury, _ := lb.globalManager.GetCommunityTreasury(ctx)
+	assert.NotPanics(t, func() { lb.applyUpgradePlan(ctx.WithBlockHeight(30)) })
+	newTreasury, _ := lb.globalManager.GetCommunityTreasury(ctx)
+	assert.True(t, treasury.IsEqual(newTreasury))
+	assert.False(t, lb.proposalManager.DoesUpgradePlanExist(ctx))
+	assert.False(t, lb.proposalManager.IsUpgradeDone(ctx, "upgrade3"))
+}
+
+func TestUpgradeHandlerRegistersEvent(t *testing.T) {
+	lb := newLinoBlockchain(t, 1)
+	header := abci.Header{Height: 2, ChainID: "Lino", Time: time.Unix(60, 0)}
+	lb.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := lb.BaseApp.NewContext(false, header)
+	plan := &proposalModel.UpgradePlan{Name: "upgrade2", Height: 3, Link: "link", ProposalID: "1"}
+	err := lb.proposalManager.ScheduleUpgrade(ctx, plan)
+	assert.Nil(t, err)
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+
+	coin := types.NewCoinFromInt64(1 * types.Decimals)
+	lb.SetUpgradeHandler("upgrade2", func(ctx sdk.Context) sdk.Error {
+		events, err := acc.CreateCoinReturnEvents(
+			ctx, types.AccountKey(user1), 1, 10, coin, types.VoteReturnCoin)
+		if err != nil {
+			return err
+		}
+		return lb.globalManager.RegisterCoinReturnEvent(ctx, events, 1, 10)
+	})
+	lb.BeginBlock(abci.RequestBeginBlock{
+		Header: abci.Header{Height: 3, ChainID: "Lino", Time: time.Unix(120, 0)}})
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+
+	// event registered by upgrade handler is executed in later block
+	res := lb.BeginBlock(abci.RequestBeginBlock{
+		Header: abci.Header{Height: 4, ChainID: "Lino", Time: time.Unix(180, 0)}})
+	expectTags := sdk.NewTags(
+		types.TagEventType, types.ReturnCoinEventType,
+		types.TagReceiver, user1,
+		types.TagAmount, types.CoinTagValue(coin),
+		types.TagDetailType, types.DetailTypeTagValue(types.VoteReturnCoin),
+	)
+	assert.Equal(t, expectTags.ToKVPairs(), res.Tags)
+}
@@ -131,6 +131,8 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				ContentCreatorAllocation: types.NewDecFromRat(65, 100),
 				DeveloperAllocation:      types.NewDecFromRat(10, 100),
 				ValidatorAllocation:      types.NewDecFromRat(5, 100),
+				TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+				TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 			},
 			param.InfraInternalAllocationParam{
 				StorageAllocation: types.NewDecFromRat(50, 100),
@@ -142,6 +144,7 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				VoterCoinReturnTimes:           int64(7),
 				DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 				DelegatorCoinReturnTimes:       int64(7),
+				RedelegateIntervalSec:          int64(7 * 24 * 3600),
 			},
 			param.ProposalParam{
 				ContentCensorshipDecideSec:  int64(24 * 7 * 3600),
@@ -158,6 +161,16 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 				ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 				ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+				TextProposalDecideSec:  int64(7 * 24 * 3600),
+				TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+				ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+				ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+				ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+				ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+				ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+				ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 			},
 			param.DeveloperParam{
 				DeveloperMinDeposit:            types.NewCoinFromInt64(1000000 * types.Decimals),
@@ -175,6 +188,10 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 				ValidatorListSize:              int64(21),
 				AbsentCommitLimitation:         int64(600), // 10min
+				ValidatorJailDurationSec:       int64(24 * 3600),
+				SignedBlocksWindow:             int64(2400),
+				MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+				MaxValidatorPowerPercent:       int64(10),
 			},
 			param.CoinDayParam{
 				SecondsToRecoverCoinDay: int64(7 * 24 * 3600),
@@ -196,7 +213,14 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
 			},
 			param.ReputationParam{
-				BestContentIndexN: 10,
+				BestContentIndexN:         10,
+				BestN:                     200,
+				UserMaxN:                  50,
+				RoundDurationSeconds:      25 * 3600,
+				SampleWindowSize:          10,
+				DecayFactor:               10,
+				ReferralClusterCapEnabled: false,
+				ReferralClusterMaxIF:      types.NewMiniDollar(10 * 100000000),
 			},
 		},
 		InitGlobalMeta: globalModel.InitParamList{
@@ -41,10 +41,37 @@ const (
 	FlagUsage    = "usage"
 
 	// Vote
-	FlagVoter      = "voter"
-	FlagProposalID = "proposal-id"
-	FlagResult     = "result"
-	FlagLink       = "link"
+	FlagVoter          = "voter"
+	FlagProposalID     = "proposal-id"
+	FlagResult         = "result"
+	FlagLink           = "link"
+	FlagFromVoter      = "from-voter"
+	FlagToVoter        = "to-voter"
+	FlagCommissionRate = "commission-rate"
+
+	// Proposal
+	FlagCreator       = "creator"
+	FlagReason        = "reason"
+	FlagParamType     = "param-type"
+	FlagParamFile     = "param-file"
+	FlagPermlink      = "permlink"
+	FlagTitle         = "title"
+	FlagUpgradeName   = "upgrade-name"
+	FlagUpgradeHeight = "upgrade-height"
+	FlagVoteOption    = "option"
+	FlagFailedEventID = "failed-event-id"
+	FlagRetry         = "retry"
+	FlagRevoke        = "revoke"
+	FlagGrantFile     = "grant-file"
+
+	// Validator
+	FlagValidatorKeyFile = "validator-key-file"
+	FlagContact          = "contact"
+	FlagIdentity         = "identity"
+
+	// Global
+	FlagEventType = "event-type"
+	FlagDays      = "days"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -16,6 +18,13 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/lino-network/lino/app"
+	"github.com/lino-network/lino/x/reputation/repv2"
+)
+
+const (
+	flagInvCheckPeriod        = "inv-check-period"
+	flagHaltOnBrokenInvariant = "halt-on-broken-invariant"
+	flagTolerance             = "tolerance"
 )
 
 // generate Lino application
@@ -24,6 +33,8 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetPruning(store.NewPruningOptionsFromString(viper.GetString("pruning"))))
 	// after upgrade-1, lino needs to starts
 	app.SetImportRequired(true)
+	app.SetInvariantCheckPeriod(viper.GetInt64(flagInvCheckPeriod))
+	app.SetHaltOnBrokenInvariant(viper.GetBool(flagHaltOnBrokenInvariant))
 	return app
 }
 
@@ -40,6 +51,12 @@ func main() {
 	}
 
 	rootCmd.AddCommand(app.InitCmd(ctx, cdc))
+	rootCmd.AddCommand(checkInvariantsCmd(ctx))
+	rootCmd.AddCommand(migrateReputationCmd())
+	rootCmd.PersistentFlags().Int64(
+		flagInvCheckPeriod, 0, "check invariants every given blocks, 0 disables the check")
+	rootCmd.PersistentFlags().Bool(
+		flagHaltOnBrokenInvariant, false, "halt the node when invariant is broken, log only if false")
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
 
@@ -52,3 +69,49 @@ func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, traceStore io.W
 	lb := app.NewLinoBlockchain(logger, db, traceStore)
 	return lb.ExportAppStateAndValidators()
 }
+
+// check invariants against the application state in node's data dir
+func checkInvariantsCmd(ctx *server.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-invariants",
+		Short: "Check invariants against the latest state in data dir, node must be stopped",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dataDir := filepath.Join(viper.GetString(cli.HomeFlag), "data")
+			db, err := dbm.NewGoLevelDB("application", dataDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			lb := app.NewLinoBlockchain(ctx.Logger, db, nil)
+			if err := lb.CheckInvariants(); err != nil {
+				return err
+			}
+			fmt.Printf("all invariants hold at height %d\n", lb.LastBlockHeight())
+			return nil
+		},
+	}
+}
+
+// convert reputation exported by the v1 reputation system to repv2 format
+func migrateReputationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-reputation <v1-file> <v2-file>",
+		Short: "Convert reputation exported by the v1 reputation system to repv2 format, verified against users' v1 reputation",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tolerance, err := cmd.Flags().GetInt64(flagTolerance)
+			if err != nil {
+				return err
+			}
+			n, err := repv2.MigrateFileFromV1(args[0], args[1], repv2.NewInt(tolerance))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("migrated reputation of %d users to %s\n", n, args[1])
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagTolerance, 0, "max difference of user's reputation allowed, in MiniDollar")
+	return cmd
+}
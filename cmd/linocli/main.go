@@ -15,9 +15,11 @@ import (
 
 	acccmd "github.com/lino-network/lino/x/account/commands"
 	developercmd "github.com/lino-network/lino/x/developer/commands"
+	globalcmd "github.com/lino-network/lino/x/global/commands"
 	infracmd "github.com/lino-network/lino/x/infra/commands"
 	postcmd "github.com/lino-network/lino/x/post/client/cli"
 	proposalcmd "github.com/lino-network/lino/x/proposal/commands"
+	repcmd "github.com/lino-network/lino/x/reputation/commands"
 	validatorcmd "github.com/lino-network/lino/x/validator/commands"
 	delegatecmd "github.com/lino-network/lino/x/vote/commands/delegate"
 	delegationcmd "github.com/lino-network/lino/x/vote/commands/delegate"
@@ -111,6 +113,18 @@ func main() {
 		client.PostCommands(
 			validatorcmd.RevokeTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			validatorcmd.UnjailTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			validatorcmd.RotateKeyTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			validatorcmd.UpdateValidatorTxCmd(cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.PostCommands(
 			delegationcmd.DelegateTxCmd(cdc),
@@ -119,10 +133,18 @@ func main() {
 		client.PostCommands(
 			delegationcmd.WithdrawDelegateTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			delegationcmd.RedelegateTxCmd(cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.GetCommands(
 			delegatecmd.GetDelegationCmd(types.VoteKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			delegatecmd.GetPendingRewardCmd(cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.PostCommands(
@@ -132,6 +154,10 @@ func main() {
 		client.PostCommands(
 			votecmd.WithdrawVoterTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			votecmd.SetCommissionTxCmd(cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.GetCommands(
 			votecmd.GetVoterCmd(types.VoteKVStoreKey, cdc),
@@ -149,11 +175,43 @@ func main() {
 		client.PostCommands(
 			proposalcmd.VoteProposalTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.ChangeParamProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.ContentCensorshipProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.UpgradeProtocolProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.TextProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.TreasurySpendProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.ResolveFailedEventProposalTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			proposalcmd.GrantFreeScoreProposalTxCmd(cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.GetCommands(
 			votecmd.GetVoteCmd(types.VoteKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			votecmd.GetSnapshotPowerCmd(cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.PostCommands(
@@ -231,6 +289,33 @@ func main() {
 		client.GetCommands(
 			validatorcmd.GetValidatorCmd(types.ValidatorKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			validatorcmd.GetUptimeCmd(cdc),
+		)...)
+
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			globalcmd.GetUpcomingEventsCmd(cdc),
+			globalcmd.GetCommunityTreasuryCmd(cdc),
+		)...)
+
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			repcmd.GetRoundCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			repcmd.GetUserInfoCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			repcmd.GetFreeScoreCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			repcmd.GetHistoryCmd(cdc),
+		)...)
 
 	// add proxy, version and key info
 	linocliCmd.AddCommand(
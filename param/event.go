@@ -2,6 +2,8 @@ package param
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lino-network/lino/types"
 )
 
 // ChangeParamEvent - change parameter event
@@ -9,6 +11,21 @@ type ChangeParamEvent struct {
 	Param Parameter `json:"param"`
 }
 
+// EventType - implements types.InspectableEvent
+func (cpe ChangeParamEvent) EventType() string {
+	return types.ChangeParamEventType
+}
+
+// InvolvedAccounts - implements types.InspectableEvent
+func (cpe ChangeParamEvent) InvolvedAccounts() []types.AccountKey {
+	return nil
+}
+
+// Tags - implements types.TaggedEvent
+func (cpe ChangeParamEvent) Tags() sdk.Tags {
+	return sdk.NewTags(types.TagEventType, types.ChangeParamEventType)
+}
+
 // Execute - execute change parameter event
 func (cpe ChangeParamEvent) Execute(ctx sdk.Context, ph ParamHolder) sdk.Error {
 	parameter := cpe.Param
@@ -31,6 +48,8 @@ func (cpe ChangeParamEvent) Execute(ctx sdk.Context, ph ParamHolder) sdk.Error {
 		return ph.setAccountParam(ctx, &parameter)
 	case PostParam:
 		return ph.setPostParam(ctx, &parameter)
+	case ReputationParam:
+		return ph.setReputationParam(ctx, &parameter)
 	default:
 		return ErrInvalidaParameter()
 	}
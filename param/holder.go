@@ -52,6 +52,8 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		ContentCreatorAllocation: types.NewDecFromRat(65, 100),
 		DeveloperAllocation:      types.NewDecFromRat(10, 100),
 		ValidatorAllocation:      types.NewDecFromRat(5, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 	if err := ph.setGlobalAllocationParam(ctx, globalAllocationParam); err != nil {
 		return err
@@ -83,7 +85,67 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		return err
 	}
 
-	validatorParam := &ValidatorParam{
+	validatorParam := defaultValidatorParam()
+	if err := ph.setValidatorParam(ctx, validatorParam); err != nil {
+		return err
+	}
+
+	voteParam := defaultVoteParam()
+	if err := ph.setVoteParam(ctx, voteParam); err != nil {
+		return err
+	}
+
+	proposalParam := defaultProposalParam()
+	if err := ph.setProposalParam(ctx, proposalParam); err != nil {
+		return err
+	}
+
+	coinDayParam := &CoinDayParam{
+		SecondsToRecoverCoinDay: int64(7 * 24 * 3600),
+	}
+	if err := ph.setCoinDayParam(ctx, coinDayParam); err != nil {
+		return err
+	}
+
+	bandwidthParam := &BandwidthParam{
+		SecondsToRecoverBandwidth:   int64(7 * 24 * 3600),
+		CapacityUsagePerTransaction: types.NewCoinFromInt64(1 * types.Decimals),
+		VirtualCoin:                 types.NewCoinFromInt64(1 * types.Decimals),
+	}
+	if err := ph.setBandwidthParam(ctx, bandwidthParam); err != nil {
+		return err
+	}
+
+	accountParam := &AccountParam{
+		MinimumBalance:               types.NewCoinFromInt64(0),
+		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
+		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
+		MaxNumFrozenMoney:            10,
+	}
+	if err := ph.setAccountParam(ctx, accountParam); err != nil {
+		return err
+	}
+
+	reputationParam := &ReputationParam{
+		BestContentIndexN:         10,
+		BestN:                     200,
+		UserMaxN:                  50,
+		RoundDurationSeconds:      25 * 3600,
+		SampleWindowSize:          10,
+		DecayFactor:               10,
+		ReferralClusterCapEnabled: false,
+		ReferralClusterMaxIF:      types.NewMiniDollar(10 * 100000000),
+	}
+	if err := ph.setReputationParam(ctx, reputationParam); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// defaultValidatorParam - default validator parameters
+func defaultValidatorParam() *ValidatorParam {
+	return &ValidatorParam{
 		ValidatorMinWithdraw:           types.NewCoinFromInt64(1 * types.Decimals),
 		ValidatorMinVotingDeposit:      types.NewCoinFromInt64(300000 * types.Decimals),
 		ValidatorMinCommittingDeposit:  types.NewCoinFromInt64(100000 * types.Decimals),
@@ -94,23 +156,28 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 		ValidatorListSize:              int64(21),
 		AbsentCommitLimitation:         int64(600), // 30min
+		ValidatorJailDurationSec:       int64(24 * 3600),
+		SignedBlocksWindow:             int64(2400),
+		MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+		MaxValidatorPowerPercent:       int64(10),
 	}
-	if err := ph.setValidatorParam(ctx, validatorParam); err != nil {
-		return err
-	}
+}
 
-	voteParam := &VoteParam{
+// defaultVoteParam - default vote parameters
+func defaultVoteParam() *VoteParam {
+	return &VoteParam{
 		MinStakeIn:                     types.NewCoinFromInt64(1000 * types.Decimals),
 		VoterCoinReturnIntervalSec:     int64(7 * 24 * 3600),
 		VoterCoinReturnTimes:           int64(7),
 		DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 		DelegatorCoinReturnTimes:       int64(7),
+		RedelegateIntervalSec:          int64(7 * 24 * 3600),
 	}
-	if err := ph.setVoteParam(ctx, voteParam); err != nil {
-		return err
-	}
+}
 
-	proposalParam := &ProposalParam{
+// defaultProposalParam - default proposal parameters
+func defaultProposalParam() *ProposalParam {
+	return &ProposalParam{
 		ContentCensorshipDecideSec:  int64(7 * 24 * 3600),
 		ContentCensorshipPassRatio:  types.NewDecFromRat(50, 100),
 		ContentCensorshipPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
@@ -126,48 +193,99 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+		ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+		ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+		ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+		ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+		ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+		ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 	}
-	if err := ph.setProposalParam(ctx, proposalParam); err != nil {
+}
+
+// SetMissingParams - set parameters introduced after the chain started, which are
+// zero or nil in stored state, to their default values.
+func (ph ParamHolder) SetMissingParams(ctx sdk.Context) error {
+	voteParam, err := ph.GetVoteParam(ctx)
+	if err != nil {
+		return err
+	}
+	setMissingVoteParam(voteParam)
+	if err := ph.setVoteParam(ctx, voteParam); err != nil {
 		return err
 	}
 
-	coinDayParam := &CoinDayParam{
-		SecondsToRecoverCoinDay: int64(7 * 24 * 3600),
+	validatorParam, err := ph.GetValidatorParam(ctx)
+	if err != nil {
+		return err
 	}
-	if err := ph.setCoinDayParam(ctx, coinDayParam); err != nil {
+	setMissingValidatorParam(validatorParam)
+	if err := ph.setValidatorParam(ctx, validatorParam); err != nil {
 		return err
 	}
 
-	bandwidthParam := &BandwidthParam{
-		SecondsToRecoverBandwidth:   int64(7 * 24 * 3600),
-		CapacityUsagePerTransaction: types.NewCoinFromInt64(1 * types.Decimals),
-		VirtualCoin:                 types.NewCoinFromInt64(1 * types.Decimals),
-	}
-	if err := ph.setBandwidthParam(ctx, bandwidthParam); err != nil {
+	proposalParam, err := ph.GetProposalParam(ctx)
+	if err != nil {
 		return err
 	}
+	setMissingProposalParam(proposalParam)
+	return ph.setProposalParam(ctx, proposalParam)
+}
 
-	accountParam := &AccountParam{
-		MinimumBalance:               types.NewCoinFromInt64(0),
-		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
-		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
-		MaxNumFrozenMoney:            10,
+// zero is not a legal value of parameters below, except for quorum.
+func setMissingVoteParam(param *VoteParam) {
+	if param.RedelegateIntervalSec == 0 {
+		param.RedelegateIntervalSec = defaultVoteParam().RedelegateIntervalSec
 	}
-	if err := ph.setAccountParam(ctx, accountParam); err != nil {
-		return err
+}
+
+func setMissingValidatorParam(param *ValidatorParam) {
+	if param.ValidatorJailDurationSec == 0 {
+		param.ValidatorJailDurationSec = defaultValidatorParam().ValidatorJailDurationSec
+	}
+	if param.MaxValidatorPowerPercent == 0 {
+		param.MaxValidatorPowerPercent = defaultValidatorParam().MaxValidatorPowerPercent
 	}
+}
 
-	reputationParam := &ReputationParam{
-		BestContentIndexN: 10,
+func setMissingProposalParam(param *ProposalParam) {
+	defaultParam := defaultProposalParam()
+	if param.TextProposalDecideSec == 0 {
+		param.TextProposalDecideSec = defaultParam.TextProposalDecideSec
 	}
-	if err := ph.setReputationParam(ctx, reputationParam); err != nil {
-		return err
+	if param.TextProposalMinDeposit.IsNil() || param.TextProposalMinDeposit.IsZero() {
+		param.TextProposalMinDeposit = defaultParam.TextProposalMinDeposit
+	}
+	// quorum can be zero, veto threshold of zero vetoes a proposal by a single veto vote.
+	if param.ContentCensorshipQuorum.IsNil() {
+		param.ContentCensorshipQuorum = defaultParam.ContentCensorshipQuorum
+	}
+	if param.ChangeParamQuorum.IsNil() {
+		param.ChangeParamQuorum = defaultParam.ChangeParamQuorum
 	}
+	if param.ProtocolUpgradeQuorum.IsNil() {
+		param.ProtocolUpgradeQuorum = defaultParam.ProtocolUpgradeQuorum
+	}
+	if isDecUnset(param.ContentCensorshipVetoThreshold) {
+		param.ContentCensorshipVetoThreshold = defaultParam.ContentCensorshipVetoThreshold
+	}
+	if isDecUnset(param.ChangeParamVetoThreshold) {
+		param.ChangeParamVetoThreshold = defaultParam.ChangeParamVetoThreshold
+	}
+	if isDecUnset(param.ProtocolUpgradeVetoThreshold) {
+		param.ProtocolUpgradeVetoThreshold = defaultParam.ProtocolUpgradeVetoThreshold
+	}
+}
 
-	return nil
+func isDecUnset(dec sdk.Dec) bool {
+	return dec.IsNil() || dec.IsZero()
 }
 
-// InitParamFromConfig - init all parameters based on pass in args
+// InitParamFromConfig - init all parameters based on pass in args,
+// parameters missing in config are set to their default values.
 func (ph ParamHolder) InitParamFromConfig(
 	ctx sdk.Context,
 	globalParam GlobalAllocationParam,
@@ -197,12 +315,15 @@ func (ph ParamHolder) InitParamFromConfig(
 		return err
 	}
 
+	setMissingValidatorParam(&validatorParam)
 	if err := ph.setValidatorParam(ctx, &validatorParam); err != nil {
 		return err
 	}
+	setMissingVoteParam(&voteParam)
 	if err := ph.setVoteParam(ctx, &voteParam); err != nil {
 		return err
 	}
+	setMissingProposalParam(&proposalParam)
 	if err := ph.setProposalParam(ctx, &proposalParam); err != nil {
 		return err
 	}
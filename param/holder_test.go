@@ -35,6 +35,8 @@ func TestGlobalAllocationParam(t *testing.T) {
 		InfraAllocation:          types.NewDecFromRat(1, 100),
 		DeveloperAllocation:      types.NewDecFromRat(1, 100),
 		ValidatorAllocation:      types.NewDecFromRat(97, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 	err := ph.setGlobalAllocationParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -89,6 +91,10 @@ func TestValidatorParam(t *testing.T) {
 		PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 		ValidatorListSize:              int64(21),
 		AbsentCommitLimitation:         int64(100),
+		ValidatorJailDurationSec:       int64(24 * 3600),
+		SignedBlocksWindow:             int64(2400),
+		MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+		MaxValidatorPowerPercent:       int64(10),
 	}
 	err := ph.setValidatorParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -107,6 +113,7 @@ func TestVoteParam(t *testing.T) {
 		VoterCoinReturnTimes:           int64(7),
 		DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 		DelegatorCoinReturnTimes:       int64(7),
+		RedelegateIntervalSec:          int64(7 * 24 * 3600),
 	}
 	err := ph.setVoteParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -135,6 +142,16 @@ func TestProposalParam(t *testing.T) {
 		ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+		ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+		ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+		ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+		ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+		ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+		ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 	}
 	err := ph.setProposalParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -203,6 +220,8 @@ func TestInitParam(t *testing.T) {
 		ContentCreatorAllocation: types.NewDecFromRat(65, 100),
 		DeveloperAllocation:      types.NewDecFromRat(10, 100),
 		ValidatorAllocation:      types.NewDecFromRat(5, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 
 	infraInternalAllocationParam := InfraInternalAllocationParam{
@@ -227,6 +246,10 @@ func TestInitParam(t *testing.T) {
 		PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 		ValidatorListSize:              int64(21),
 		AbsentCommitLimitation:         int64(600),
+		ValidatorJailDurationSec:       int64(24 * 3600),
+		SignedBlocksWindow:             int64(2400),
+		MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+		MaxValidatorPowerPercent:       int64(10),
 	}
 
 	voteParam := VoteParam{
@@ -235,6 +258,7 @@ func TestInitParam(t *testing.T) {
 		VoterCoinReturnTimes:           int64(7),
 		DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 		DelegatorCoinReturnTimes:       int64(7),
+		RedelegateIntervalSec:          int64(7 * 24 * 3600),
 	}
 	proposalParam := ProposalParam{
 		ContentCensorshipDecideSec:  int64(7 * 24 * 3600),
@@ -252,6 +276,16 @@ func TestInitParam(t *testing.T) {
 		ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+		ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+		ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+		ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+		ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+		ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+		ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 	}
 
 	coinDayParam := CoinDayParam{
@@ -287,6 +321,8 @@ func TestInitParamFromConfig(t *testing.T) {
 		ContentCreatorAllocation: types.NewDecFromRat(65, 100),
 		DeveloperAllocation:      types.NewDecFromRat(10, 100),
 		ValidatorAllocation:      types.NewDecFromRat(5, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 
 	infraInternalAllocationParam := InfraInternalAllocationParam{
@@ -311,6 +347,10 @@ func TestInitParamFromConfig(t *testing.T) {
 		PenaltyByzantine:               types.NewCoinFromInt64(1000000 * types.Decimals),
 		ValidatorListSize:              int64(21),
 		AbsentCommitLimitation:         int64(600),
+		ValidatorJailDurationSec:       int64(24 * 3600),
+		SignedBlocksWindow:             int64(2400),
+		MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+		MaxValidatorPowerPercent:       int64(10),
 	}
 
 	voteParam := VoteParam{
@@ -319,6 +359,7 @@ func TestInitParamFromConfig(t *testing.T) {
 		VoterCoinReturnTimes:           int64(7),
 		DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 		DelegatorCoinReturnTimes:       int64(7),
+		RedelegateIntervalSec:          int64(7 * 24 * 3600),
 	}
 	proposalParam := ProposalParam{
 		ContentCensorshipDecideSec:  int64(7 * 24 * 3600),
@@ -336,6 +377,16 @@ func TestInitParamFromConfig(t *testing.T) {
 		ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+		ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+		ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+		ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+		ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+		ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+		ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 	}
 
 	coinDayParam := CoinDayParam{
@@ -358,7 +409,14 @@ func TestInitParamFromConfig(t *testing.T) {
 		MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
 	}
 	repParam := ReputationParam{
-		BestContentIndexN: 10,
+		BestContentIndexN:         10,
+		BestN:                     200,
+		UserMaxN:                  50,
+		RoundDurationSeconds:      25 * 3600,
+		SampleWindowSize:          10,
+		DecayFactor:               10,
+		ReferralClusterCapEnabled: false,
+		ReferralClusterMaxIF:      types.NewMiniDollar(10 * 100000000),
 	}
 
 	err := ph.InitParamFromConfig(
@@ -429,6 +487,53 @@ func checkStorage(t *testing.T, ctx sdk.Context, ph ParamHolder, expectGlobalAll
 	assert.Equal(t, expectPostParam, *postParam)
 }
 
+func TestSetMissingParams(t *testing.T) {
+	ph := NewParamHolder(TestKVStoreKey)
+	ctx := getContext()
+	err := ph.InitParam(ctx)
+	assert.Nil(t, err)
+
+	voteParam, err := ph.GetVoteParam(ctx)
+	assert.Nil(t, err)
+	voteParam.RedelegateIntervalSec = 0
+	assert.Nil(t, ph.setVoteParam(ctx, voteParam))
+	validatorParam, err := ph.GetValidatorParam(ctx)
+	assert.Nil(t, err)
+	validatorParam.ValidatorJailDurationSec = 0
+	validatorParam.MaxValidatorPowerPercent = 0
+	assert.Nil(t, ph.setValidatorParam(ctx, validatorParam))
+	proposalParam, err := ph.GetProposalParam(ctx)
+	assert.Nil(t, err)
+	proposalParam.TextProposalDecideSec = 0
+	proposalParam.TextProposalMinDeposit = types.NewCoinFromInt64(0)
+	proposalParam.ChangeParamQuorum = sdk.ZeroDec()
+	proposalParam.ChangeParamVetoThreshold = sdk.ZeroDec()
+	assert.Nil(t, ph.setProposalParam(ctx, proposalParam))
+
+	err = ph.SetMissingParams(ctx)
+	assert.Nil(t, err)
+	voteParam, err = ph.GetVoteParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, *defaultVoteParam(), *voteParam)
+	validatorParam, err = ph.GetValidatorParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, *defaultValidatorParam(), *validatorParam)
+	proposalParam, err = ph.GetProposalParam(ctx)
+	assert.Nil(t, err)
+	// zero quorum is legal and kept.
+	assert.True(t, proposalParam.ChangeParamQuorum.IsZero())
+	proposalParam.ChangeParamQuorum = defaultProposalParam().ChangeParamQuorum
+	assert.Equal(t, *defaultProposalParam(), *proposalParam)
+
+	// params decoded from config without new fields.
+	missing := ProposalParam{}
+	setMissingProposalParam(&missing)
+	assert.Equal(t, defaultProposalParam().TextProposalDecideSec, missing.TextProposalDecideSec)
+	assert.Equal(t, defaultProposalParam().TextProposalMinDeposit, missing.TextProposalMinDeposit)
+	assert.Equal(t, defaultProposalParam().ChangeParamQuorum, missing.ChangeParamQuorum)
+	assert.Equal(t, defaultProposalParam().ProtocolUpgradeVetoThreshold, missing.ProtocolUpgradeVetoThreshold)
+}
+
 func TestUpdateGlobalGrowthRate(t *testing.T) {
 	ph := NewParamHolder(TestKVStoreKey)
 	ctx := getContext()
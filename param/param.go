@@ -14,12 +14,17 @@ type Parameter interface{}
 // ContentCreatorAllocation - percentage for all content creator related allocation
 // DeveloperAllocation - percentage of inflation for developers
 // ValidatorAllocation - percentage of inflation for validators
+// TreasuryPenaltyShare - percentage of validator penalties added to community treasury
+// TreasuryFrictionShare - percentage of consumption friction added to community treasury
 type GlobalAllocationParam struct {
 	GlobalGrowthRate         sdk.Dec `json:"global_growth_rate"`
 	InfraAllocation          sdk.Dec `json:"infra_allocation"`
 	ContentCreatorAllocation sdk.Dec `json:"content_creator_allocation"`
 	DeveloperAllocation      sdk.Dec `json:"developer_allocation"`
 	ValidatorAllocation      sdk.Dec `json:"validator_allocation"`
+
+	TreasuryPenaltyShare  sdk.Dec `json:"treasury_penalty_share"`
+	TreasuryFrictionShare sdk.Dec `json:"treasury_friction_share"`
 }
 
 // InfraInternalAllocationParam - infra internal allocation parameters
@@ -36,12 +41,14 @@ type InfraInternalAllocationParam struct {
 // VoterCoinReturnTimes - when withdraw or revoke, the deposit return to voter by return event
 // DelegatorCoinReturnIntervalSec - when withdraw or revoke, the deposit return to delegator by return event
 // DelegatorCoinReturnTimes - when withdraw or revoke, the deposit return to delegator by return event
+// RedelegateIntervalSec - minimum seconds between two redelegations of a delegator
 type VoteParam struct {
 	MinStakeIn                     types.Coin `json:"min_stake_in"`
 	VoterCoinReturnIntervalSec     int64      `json:"voter_coin_return_interval_second"`
 	VoterCoinReturnTimes           int64      `json:"voter_coin_return_times"`
 	DelegatorCoinReturnIntervalSec int64      `json:"delegator_coin_return_interval_second"`
 	DelegatorCoinReturnTimes       int64      `json:"delegator_coin_return_times"`
+	RedelegateIntervalSec          int64      `json:"redelegate_interval_second"`
 }
 
 // ProposalParam - proposal parameters
@@ -58,6 +65,17 @@ type VoteParam struct {
 // ProtocolUpgradeMinDeposit - minimum deposit to propose protocol upgrade proposal
 // ProtocolUpgradePassRatio - upvote and downvote ratio for protocol upgrade proposal
 // ProtocolUpgradePassVotes - minimum voting power required to pass protocol upgrade proposal
+// TextProposalDecideSec - seconds after text proposal created till expired
+// TextProposalMinDeposit - minimum deposit to propose text proposal
+// ContentCensorshipQuorum - minimum ratio of total lino stake voted for content censorship proposal
+// ContentCensorshipVetoThreshold - content censorship proposal is vetoed if veto votes ratio exceeds it
+// ChangeParamQuorum - minimum ratio of total lino stake voted for change param proposal
+// ChangeParamVetoThreshold - change param proposal is vetoed if veto votes ratio exceeds it
+// ProtocolUpgradeQuorum - minimum ratio of total lino stake voted for protocol upgrade proposal
+// ProtocolUpgradeVetoThreshold - protocol upgrade proposal is vetoed if veto votes ratio exceeds it
+// Text proposal has its own decide period and deposit, but is passed with content
+// censorship pass ratio, pass votes, quorum and veto threshold. Resolve failed event,
+// grant free score and treasury spend proposals use all change param parameters.
 type ProposalParam struct {
 	ContentCensorshipDecideSec  int64      `json:"content_censorship_decide_second"`
 	ContentCensorshipMinDeposit types.Coin `json:"content_censorship_min_deposit"`
@@ -72,6 +90,15 @@ type ProposalParam struct {
 	ProtocolUpgradeMinDeposit   types.Coin `json:"protocol_upgrade_min_deposit"`
 	ProtocolUpgradePassRatio    sdk.Dec    `json:"protocol_upgrade_pass_ratio"`
 	ProtocolUpgradePassVotes    types.Coin `json:"protocol_upgrade_pass_votes"`
+	TextProposalDecideSec       int64      `json:"text_proposal_decide_second"`
+	TextProposalMinDeposit      types.Coin `json:"text_proposal_min_deposit"`
+
+	ContentCensorshipQuorum        sdk.Dec `json:"content_censorship_quorum"`
+	ContentCensorshipVetoThreshold sdk.Dec `json:"content_censorship_veto_threshold"`
+	ChangeParamQuorum              sdk.Dec `json:"change_param_quorum"`
+	ChangeParamVetoThreshold       sdk.Dec `json:"change_param_veto_threshold"`
+	ProtocolUpgradeQuorum          sdk.Dec `json:"protocol_upgrade_quorum"`
+	ProtocolUpgradeVetoThreshold   sdk.Dec `json:"protocol_upgrade_veto_threshold"`
 }
 
 // DeveloperParam - developer parameters
@@ -96,7 +123,11 @@ type DeveloperParam struct {
 // PenaltyByzantine - when validator acts as byzantine (double sign, for example),
 // minus PenaltyByzantine amount of Coin from validator deposit
 // ValidatorListSize - size of oncall validator
-// AbsentCommitLimitation - absent block limitation till penalty
+// AbsentCommitLimitation - absent block limitation till penalty, used when signing window is not set
+// ValidatorJailDurationSec - how long a jailed validator has to wait before it can unjail
+// SignedBlocksWindow - number of recent blocks tracked to check validator liveness
+// MinSignedPerWindow - minimum ratio of blocks in signing window a validator has to sign
+// MaxValidatorPowerPercent - power of a validator in tendermint engine is capped at this percent of total power
 type ValidatorParam struct {
 	ValidatorMinWithdraw           types.Coin `json:"validator_min_withdraw"`
 	ValidatorMinVotingDeposit      types.Coin `json:"validator_min_voting_deposit"`
@@ -108,6 +139,10 @@ type ValidatorParam struct {
 	PenaltyByzantine               types.Coin `json:"penalty_byzantine"`
 	ValidatorListSize              int64      `json:"validator_list_size"`
 	AbsentCommitLimitation         int64      `json:"absent_commit_limitation"`
+	ValidatorJailDurationSec       int64      `json:"validator_jail_duration_second"`
+	SignedBlocksWindow             int64      `json:"signed_blocks_window"`
+	MinSignedPerWindow             sdk.Dec    `json:"min_signed_per_window"`
+	MaxValidatorPowerPercent       int64      `json:"max_validator_power_percent"`
 }
 
 // CoinDayParam - coin day parameters
@@ -146,7 +181,23 @@ type PostParam struct {
 	MaxReportReputation       types.Coin `json:"max_report_reputation"`
 }
 
+// ReputationParam - reputation parameters, changes take effect when the next round starts
 // BestContentIndexN - hard cap of how many content can be indexed every round.
+// BestN - number of top posts tracked in a round to select the seed set
+// UserMaxN - max number of posts a user's donations can have impact on in a round
+// RoundDurationSeconds - how many seconds a reputation round lasts
+// SampleWindowSize - how many rounds are used to sample out user's customer score
+// DecayFactor - percentage of penalty on donations outside the seed set
+// ReferralClusterCapEnabled - whether impact factor of accounts sharing a referrer is capped
+// ReferralClusterMaxIF - max impact factor of accounts sharing a referrer on a post in a round
 type ReputationParam struct {
-	BestContentIndexN int `json:"best_content_index_n"`
+	BestContentIndexN    int   `json:"best_content_index_n"`
+	BestN                int   `json:"best_n"`
+	UserMaxN             int   `json:"user_max_n"`
+	RoundDurationSeconds int64 `json:"round_duration_seconds"`
+	SampleWindowSize     int64 `json:"sample_window_size"`
+	DecayFactor          int64 `json:"decay_factor"`
+
+	ReferralClusterCapEnabled bool             `json:"referral_cluster_cap_enabled"`
+	ReferralClusterMaxIF      types.MiniDollar `json:"referral_cluster_max_if"`
 }
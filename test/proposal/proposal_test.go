@@ -60,6 +60,8 @@ func TestForceValidatorVote(t *testing.T) {
 		ContentCreatorAllocation: types.NewDecFromRat(1, 100),
 		DeveloperAllocation:      types.NewDecFromRat(1, 100),
 		ValidatorAllocation:      types.NewDecFromRat(97, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 
 	changeAllocationMsg := proposal.NewChangeGlobalAllocationParamMsg(accountName, desc, "")
@@ -96,6 +96,11 @@ func (coin Coin) IsZero() bool {
 	return coin.Amount.Sign() == 0
 }
 
+// IsNil - returns true if amount is not set, as decoded from a field missing in stored state
+func (coin Coin) IsNil() bool {
+	return coin.Amount == (sdk.Int{})
+}
+
 // IsGT - returns true if the receiver is greater value
 func (coin Coin) IsGT(other Coin) bool {
 	return coin.Amount.GT(other.Amount)
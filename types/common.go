@@ -21,6 +21,9 @@ type ProposalResult int
 // indicates proposal type
 type ProposalType int
 
+// indicates the option of a vote to proposal
+type VoteOption int
+
 // indicates donation type
 type DonationType int
 
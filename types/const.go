@@ -35,11 +35,28 @@ const (
 	ProposalNotPass = ProposalResult(0)
 	ProposalPass    = ProposalResult(1)
 	ProposalRevoked = ProposalResult(2)
+	// ProposalVetoed - veto votes exceed the veto threshold, deposit is burnt
+	ProposalVetoed = ProposalResult(3)
+
+	// Different vote options, option of a vote without option is decided by its result
+	VoteOptionUnspecified = VoteOption(0)
+	VoteOptionYes         = VoteOption(1)
+	VoteOptionNo          = VoteOption(2)
+	VoteOptionAbstain     = VoteOption(3)
+	VoteOptionVeto        = VoteOption(4)
 
 	// Different proposal types
 	ChangeParam       = ProposalType(0)
 	ContentCensorship = ProposalType(1)
 	ProtocolUpgrade   = ProposalType(2)
+	// ResolveFailedEvent - retry or discard a failed time event
+	ResolveFailedEvent = ProposalType(3)
+	// GrantFreeScore - grant or revoke free reputation score
+	GrantFreeScore = ProposalType(4)
+	// TextProposal - non-binding proposal to signal community opinion
+	TextProposal = ProposalType(5)
+	// TreasurySpend - spend coins from community treasury
+	TreasurySpend = ProposalType(6)
 
 	// Different donation types
 	DirectDeposit = DonationType(0)
@@ -60,6 +77,7 @@ const (
 	ProposalReturnCoin   = TransferDetailType(11)
 	GenesisCoin          = TransferDetailType(12)
 	ClaimInterest        = TransferDetailType(13)
+	TreasuryCoin         = TransferDetailType(14)
 
 	// Different possible outcomes
 	TransferOut      = TransferDetailType(20)
@@ -70,6 +88,7 @@ const (
 	DeveloperDeposit = TransferDetailType(25)
 	InfraDeposit     = TransferDetailType(26)
 	ProposalDeposit  = TransferDetailType(27)
+	Redelegate       = TransferDetailType(28)
 
 	// punishment type
 	UnknownPunish      = PunishType(0)
@@ -141,9 +160,40 @@ const (
 	// MaximumLengthOfAppMetadata - maximum length of developer App meta data
 	MaximumLengthOfAppMetadata = 1000
 
+	// MaximumLengthOfValidatorDescription - maximum length of validator description
+	MaximumLengthOfValidatorDescription = 1000
+
+	// MaximumLengthOfValidatorContact - maximum length of validator contact
+	MaximumLengthOfValidatorContact = 100
+
+	// MaximumLengthOfValidatorIdentity - maximum length of validator identity
+	MaximumLengthOfValidatorIdentity = 64
+
 	// MaximumLengthOfProposalReason - maximum length of proposal reason
 	MaximumLengthOfProposalReason = 1000
 
+	// MaximumLengthOfProposalTitle - maximum length of text proposal title
+	MaximumLengthOfProposalTitle = 100
+
+	// MaximumLengthOfProposalDescription - maximum length of text proposal description
+	MaximumLengthOfProposalDescription = 5000
+
+	// MaximumLengthOfUpgradeName - maximum length of protocol upgrade name
+	MaximumLengthOfUpgradeName = 64
+
+	// MaximumNumOfFreeScoreGrants - maximum number of accounts in a free score proposal
+	MaximumNumOfFreeScoreGrants = 100
+
+	// MaximumFreeScoreRecords - maximum number of free score records kept per user
+	MaximumFreeScoreRecords = 100
+
+	// MaximumReputationBestN - maximum number of top posts tracked in a reputation round
+	MaximumReputationBestN = 1000
+
+	// MaximumReputationUserMaxN - maximum number of posts a user's donations can have
+	// impact on in a reputation round
+	MaximumReputationUserMaxN = 200
+
 	// InitAccountWithFullCoinDayMemo - init account with full coin day memo
 	InitAccountWithFullCoinDayMemo = "open account deposit"
 
@@ -180,6 +230,44 @@ const (
 	// BlockchainUpgrade1Update4Height - fix donation bandwidth check.
 	BlockchainUpgrade1Update4Height = 386000
 
+	// BlockchainUpgrade1Update5Height - time events are keyed by big-endian time,
+	// parameters introduced since then are set to their defaults if missing.
+	BlockchainUpgrade1Update5Height = 1200000
+
+	// BlockchainUpgrade1Update6Height - validator power is proportional to its deposit and voting power.
+	BlockchainUpgrade1Update6Height = 1300000
+
+	// BlockchainUpgrade1Update7Height - validator inflation is distributed in proportion to backing stake.
+	BlockchainUpgrade1Update7Height = 1400000
+
+	// BlockchainUpgrade1Update8Height - proposals are tallied with quorum, abstain and veto,
+	// deposit of proposals created since then is returned or burnt when they are decided.
+	BlockchainUpgrade1Update8Height = 1500000
+
+	// BlockchainUpgrade1Update9Height - a share of validator penalties and consumption friction
+	// is added to community treasury.
+	BlockchainUpgrade1Update9Height = 1600000
+
+	// BlockchainUpgrade1Update10Height - coins recycled into inflation pools are recorded as
+	// supply offset, surplus of total supply recycled before is reconciled at this height.
+	BlockchainUpgrade1Update10Height = 1700000
+
+	// BlockchainUpgrade1Update11Height - reputation parameters are read from ReputationParam
+	// and snapshotted when a round starts, instead of fixed in code.
+	BlockchainUpgrade1Update11Height = 1800000
+
+	// BlockchainUpgrade1Update12Height - referrer of accounts registered since then is stored
+	// in AccountInfo.
+	BlockchainUpgrade1Update12Height = 1900000
+
+	// BlockchainUpgrade1Update13Height - voting power of voters is fixed at the creation of
+	// proposals created since then, delegators voting on their own override their voters.
+	BlockchainUpgrade1Update13Height = 2000000
+
+	// BlockchainUpgrade1Update14Height - validators with insufficient deposit after a penalty
+	// are jailed instead of removed, byzantine validators are tombstoned.
+	BlockchainUpgrade1Update14Height = 2100000
+
 	// NoTPSLimitDonationMin - donation >= this value will not cost bandwidth, in coin.
 	NoTPSLimitDonationMin = 100000
 )
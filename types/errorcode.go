@@ -166,6 +166,21 @@ const (
 	CodeUnbalancedAccount              sdk.CodeType = 506
 	CodeValidatorPubKeyAlreadyExist    sdk.CodeType = 507
 	CodeValidatorQueryFailed           sdk.CodeType = 508
+	CodeValidatorJailed                sdk.CodeType = 509
+	CodeValidatorNotJailed             sdk.CodeType = 510
+	CodeJailPeriodNotOver              sdk.CodeType = 511
+	CodeValidatorTombstoned            sdk.CodeType = 512
+	CodeSigningInfoNotFound            sdk.CodeType = 513
+	CodeFailedToMarshalSigningInfo     sdk.CodeType = 514
+	CodeFailedToUnmarshalSigningInfo   sdk.CodeType = 515
+	CodeKeyRotationNotFound            sdk.CodeType = 516
+	CodeFailedToMarshalKeyRotation     sdk.CodeType = 517
+	CodeFailedToUnmarshalKeyRotation   sdk.CodeType = 518
+	CodeInvalidValidatorPubKey         sdk.CodeType = 519
+	CodeInvalidValidatorContact        sdk.CodeType = 520
+	CodeInvalidValidatorIdentity       sdk.CodeType = 521
+	CodeFailedToMarshalRetiredKeys     sdk.CodeType = 522
+	CodeFailedToUnmarshalRetiredKeys   sdk.CodeType = 523
 
 	// Lino global errors reserve 600 ~ 699
 	CodeInfraInflationCoinConversion           sdk.CodeType = 600
@@ -196,23 +211,44 @@ const (
 	CodePastDayIsNegative                      sdk.CodeType = 625
 	CodeFailedToParseEventCacheList            sdk.CodeType = 626
 	CodeGlobalQueryFailed                      sdk.CodeType = 627
+	CodeFailedToParseTimeEventListKey          sdk.CodeType = 628
+	CodeInvalidTimeEventQueryRange             sdk.CodeType = 629
+	CodeFailedEventNotFound                    sdk.CodeType = 630
+	CodeFailedToMarshalFailedEvent             sdk.CodeType = 631
+	CodeFailedToUnmarshalFailedEvent           sdk.CodeType = 632
+	CodeCommunityTreasuryNotEnough             sdk.CodeType = 633
+	CodeFailedToMarshalCommunityTreasury       sdk.CodeType = 634
+	CodeFailedToUnmarshalCommunityTreasury     sdk.CodeType = 635
+	CodeFailedToMarshalSupplyOffset            sdk.CodeType = 636
+	CodeFailedToUnmarshalSupplyOffset          sdk.CodeType = 637
 
 	// Vote errors reserve 700 ~ 799
-	CodeVoterNotFound                  sdk.CodeType = 700
-	CodeVoteNotFound                   sdk.CodeType = 701
-	CodeReferenceListNotFound          sdk.CodeType = 702
-	CodeDelegationNotFound             sdk.CodeType = 703
-	CodeFailedToMarshalVoter           sdk.CodeType = 704
-	CodeFailedToMarshalVote            sdk.CodeType = 705
-	CodeFailedToMarshalDelegation      sdk.CodeType = 706
-	CodeFailedToMarshalReferenceList   sdk.CodeType = 707
-	CodeFailedToUnmarshalVoter         sdk.CodeType = 708
-	CodeFailedToUnmarshalVote          sdk.CodeType = 709
-	CodeFailedToUnmarshalDelegation    sdk.CodeType = 710
-	CodeFailedToUnmarshalReferenceList sdk.CodeType = 711
-	CodeValidatorCannotRevoke          sdk.CodeType = 712
-	CodeVoteAlreadyExist               sdk.CodeType = 713
-	CodeVoteQueryFailed                sdk.CodeType = 714
+	CodeVoterNotFound                   sdk.CodeType = 700
+	CodeVoteNotFound                    sdk.CodeType = 701
+	CodeReferenceListNotFound           sdk.CodeType = 702
+	CodeDelegationNotFound              sdk.CodeType = 703
+	CodeFailedToMarshalVoter            sdk.CodeType = 704
+	CodeFailedToMarshalVote             sdk.CodeType = 705
+	CodeFailedToMarshalDelegation       sdk.CodeType = 706
+	CodeFailedToMarshalReferenceList    sdk.CodeType = 707
+	CodeFailedToUnmarshalVoter          sdk.CodeType = 708
+	CodeFailedToUnmarshalVote           sdk.CodeType = 709
+	CodeFailedToUnmarshalDelegation     sdk.CodeType = 710
+	CodeFailedToUnmarshalReferenceList  sdk.CodeType = 711
+	CodeValidatorCannotRevoke           sdk.CodeType = 712
+	CodeVoteAlreadyExist                sdk.CodeType = 713
+	CodeVoteQueryFailed                 sdk.CodeType = 714
+	CodeRedelegateTooOften              sdk.CodeType = 715
+	CodeInvalidRedelegate               sdk.CodeType = 716
+	CodeInvalidCommissionRate           sdk.CodeType = 717
+	CodeFailedToMarshalDistribution     sdk.CodeType = 718
+	CodeFailedToUnmarshalDistribution   sdk.CodeType = 719
+	CodeFailedToMarshalRewardSnapshot   sdk.CodeType = 720
+	CodeFailedToUnmarshalRewardSnapshot sdk.CodeType = 721
+	CodeSnapshotNotFound                sdk.CodeType = 722
+	CodeFailedToMarshalSnapshot         sdk.CodeType = 723
+	CodeFailedToUnmarshalSnapshot       sdk.CodeType = 724
+	CodeNoDelegatedPower                sdk.CodeType = 725
 
 	// Lino infra errors reserve 800 ~ 899
 	CodeInfraProviderNotFound              sdk.CodeType = 800
@@ -302,9 +338,26 @@ const (
 	CodeIllegalParameter                sdk.CodeType = 1116
 	CodeReasonTooLong                   sdk.CodeType = 1117
 	CodeProposalQueryFailed             sdk.CodeType = 1118
+	CodeInvalidFailedEventID            sdk.CodeType = 1119
+	CodeInvalidFreeScoreGrant           sdk.CodeType = 1120
+	CodeInvalidProposalTitle            sdk.CodeType = 1121
+	CodeProposalDescriptionTooLong      sdk.CodeType = 1122
+	CodeInvalidUpgradeName              sdk.CodeType = 1123
+	CodeInvalidUpgradeHeight            sdk.CodeType = 1124
+	CodeUpgradePlanNotFound             sdk.CodeType = 1125
+	CodeFailedToMarshalUpgradePlan      sdk.CodeType = 1126
+	CodeFailedToUnmarshalUpgradePlan    sdk.CodeType = 1127
+	CodeInvalidVoteOption               sdk.CodeType = 1128
+	CodeFailedToMarshalProposalTally    sdk.CodeType = 1129
+	CodeFailedToUnmarshalProposalTally  sdk.CodeType = 1130
+	CodeProposalDepositNotFound         sdk.CodeType = 1131
+	CodeFailedToMarshalDeposit          sdk.CodeType = 1132
+	CodeFailedToUnmarshalDeposit        sdk.CodeType = 1133
+	CodeUpgradePlanExist                sdk.CodeType = 1134
 
 	// reputation errors reserve 1200 ~ 1299
-	CodeReputationQueryFailed sdk.CodeType = 1200
+	CodeReputationQueryFailed   sdk.CodeType = 1200
+	CodeReputationRoundNotFound sdk.CodeType = 1201
 
 	// testing dummy error 100000
 	CodeTestDummyError sdk.CodeType = 100000
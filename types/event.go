@@ -1,8 +1,37 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 // Event - event executed in app.go
 type Event interface{}
 
+// Different time event types, used when inspecting pending events
+const (
+	RewardEventType         = "reward"
+	ReturnCoinEventType     = "return_coin"
+	DecideProposalEventType = "decide_proposal"
+	ChangeParamEventType    = "change_param"
+	TreasurySpendEventType  = "treasury_spend"
+)
+
+// InspectableEvent - event that can be filtered by type and involved accounts
+type InspectableEvent interface {
+	EventType() string
+	InvolvedAccounts() []AccountKey
+}
+
+// TaggedEvent - event emitting tags when it is executed in BeginBlock
+type TaggedEvent interface {
+	Tags() sdk.Tags
+}
+
+// PendingCoinEvent - event holding coins until it is executed
+type PendingCoinEvent interface {
+	PendingCoin() Coin
+}
+
 // Minute -> TimeEventList
 type TimeEventList struct {
 	Events []Event `json:"events"`
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Invariant - check a property of the state, returns error describing the violation
+type Invariant func(ctx sdk.Context) error
+
+// SupplyHolding - amount of LINO held by a module, counted in total supply
+type SupplyHolding func(ctx sdk.Context) (Coin, sdk.Error)
+
+type invariantRoute struct {
+	name      string
+	invariant Invariant
+}
+
+type supplyHoldingRoute struct {
+	name    string
+	holding SupplyHolding
+}
+
+// InvariantRegistry - invariants and supply holdings registered by modules,
+// checked in registration order.
+type InvariantRegistry struct {
+	invariants []invariantRoute
+	holdings   []supplyHoldingRoute
+}
+
+// NewInvariantRegistry - return an empty invariant registry
+func NewInvariantRegistry() *InvariantRegistry {
+	return &InvariantRegistry{}
+}
+
+// RegisterInvariant - register an invariant under module/name
+func (ir *InvariantRegistry) RegisterInvariant(module, name string, invariant Invariant) {
+	ir.invariants = append(ir.invariants, invariantRoute{
+		name:      module + "/" + name,
+		invariant: invariant,
+	})
+}
+
+// RegisterSupplyHolding - register coins held by module, all holdings
+// sum up to the total supply.
+func (ir *InvariantRegistry) RegisterSupplyHolding(module, name string, holding SupplyHolding) {
+	ir.holdings = append(ir.holdings, supplyHoldingRoute{
+		name:    module + "/" + name,
+		holding: holding,
+	})
+}
+
+// SupplyBreakdown - sum of all supply holdings and amount held by each of them
+func (ir *InvariantRegistry) SupplyBreakdown(ctx sdk.Context) (Coin, map[string]Coin, sdk.Error) {
+	total := NewCoinFromInt64(0)
+	breakdown := make(map[string]Coin)
+	for _, route := range ir.holdings {
+		coin, err := route.holding(ctx)
+		if err != nil {
+			return total, nil, err
+		}
+		breakdown[route.name] = coin
+		total = total.Plus(coin)
+	}
+	return total, breakdown, nil
+}
+
+// SupplyInvariant - invariant that all supply holdings sum up to the total supply
+func (ir *InvariantRegistry) SupplyInvariant(totalSupply SupplyHolding) Invariant {
+	return func(ctx sdk.Context) error {
+		supply, err := totalSupply(ctx)
+		if err != nil {
+			return err
+		}
+		total, breakdown, err := ir.SupplyBreakdown(ctx)
+		if err != nil {
+			return err
+		}
+		if total.IsEqual(supply) {
+			return nil
+		}
+		details := []string{}
+		for _, route := range ir.holdings {
+			details = append(details, fmt.Sprintf("%s: %s", route.name, breakdown[route.name]))
+		}
+		return fmt.Errorf(
+			"total supply %s, sum of holdings %s (%s)", supply, total, strings.Join(details, ", "))
+	}
+}
+
+// AssertInvariants - check all invariants, returns error listing every broken invariant
+func (ir *InvariantRegistry) AssertInvariants(ctx sdk.Context) error {
+	broken := []string{}
+	for _, route := range ir.invariants {
+		if err := route.invariant(ctx); err != nil {
+			broken = append(broken, fmt.Sprintf("%s: %s", route.name, err.Error()))
+		}
+	}
+	if len(broken) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invariants broken: %s", strings.Join(broken, "; "))
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Tag keys of handler results and executed time events, indexed by tendermint.
+const (
+	TagSender     = "sender"
+	TagReceiver   = "receiver"
+	TagUsername   = "username"
+	TagAuthor     = "author"
+	TagPermlink   = "permlink"
+	TagApp        = "app"
+	TagAmount     = "amount"
+	TagDetailType = "detail_type"
+	TagProposalID = "proposal_id"
+	TagEventType  = "event_type"
+)
+
+// CoinTagValue - tag value of coin, amount in minimum unit
+func CoinTagValue(coin Coin) string {
+	return coin.Amount.String()
+}
+
+// DetailTypeTagValue - tag value of transfer detail type
+func DetailTypeTagValue(detailType TransferDetailType) string {
+	return strconv.Itoa(int(detailType))
+}
+
+// UsernameTags - tags of message only involves one user
+func UsernameTags(username AccountKey) sdk.Tags {
+	return sdk.NewTags(TagUsername, string(username))
+}
+
+// CoinTags - tags of coin moved in or out of user's saving
+func CoinTags(username AccountKey, coin Coin, detailType TransferDetailType) sdk.Tags {
+	return sdk.NewTags(
+		TagUsername, string(username),
+		TagAmount, CoinTagValue(coin),
+		TagDetailType, DetailTypeTagValue(detailType),
+	)
+}
+
+// TransferTags - tags of coin transferred from sender to receiver
+func TransferTags(
+	sender, receiver AccountKey, coin Coin, detailType TransferDetailType) sdk.Tags {
+	return sdk.NewTags(
+		TagSender, string(sender),
+		TagReceiver, string(receiver),
+		TagAmount, CoinTagValue(coin),
+		TagDetailType, DetailTypeTagValue(detailType),
+	)
+}
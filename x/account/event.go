@@ -13,6 +13,31 @@ type ReturnCoinEvent struct {
 	ReturnType types.TransferDetailType `json:"return_type"`
 }
 
+// EventType - implements types.InspectableEvent
+func (event ReturnCoinEvent) EventType() string {
+	return types.ReturnCoinEventType
+}
+
+// InvolvedAccounts - implements types.InspectableEvent
+func (event ReturnCoinEvent) InvolvedAccounts() []types.AccountKey {
+	return []types.AccountKey{event.Username}
+}
+
+// Tags - implements types.TaggedEvent
+func (event ReturnCoinEvent) Tags() sdk.Tags {
+	return sdk.NewTags(
+		types.TagEventType, types.ReturnCoinEventType,
+		types.TagReceiver, string(event.Username),
+		types.TagAmount, types.CoinTagValue(event.Amount),
+		types.TagDetailType, types.DetailTypeTagValue(event.ReturnType),
+	)
+}
+
+// PendingCoin - implements types.PendingCoinEvent
+func (event ReturnCoinEvent) PendingCoin() types.Coin {
+	return event.Amount
+}
+
 // Execute - execute coin return events
 func (event ReturnCoinEvent) Execute(ctx sdk.Context, am AccountManager) sdk.Error {
 	if !am.DoesAccountExist(ctx, event.Username) {
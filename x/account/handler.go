@@ -52,7 +52,7 @@ func handleTransferMsg(ctx sdk.Context, am AccountManager, msg TransferMsg) sdk.
 		ctx, msg.Receiver, coin, msg.Sender, msg.Memo, types.TransferIn); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.TransferTags(msg.Sender, msg.Receiver, coin, types.TransferOut)}
 }
 
 func handleRecoverMsg(ctx sdk.Context, am AccountManager, msg RecoverMsg) sdk.Result {
@@ -65,7 +65,7 @@ func handleRecoverMsg(ctx sdk.Context, am AccountManager, msg RecoverMsg) sdk.Re
 		msg.NewAppPubKey); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
 }
 
 // Handle RegisterMsg
@@ -98,7 +98,7 @@ func handleRegisterMsg(ctx sdk.Context, am AccountManager, gm *global.GlobalMana
 		msg.NewAppPubKey, coin.Minus(accParams.RegisterFee)); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.TransferTags(msg.Referrer, msg.NewUser, coin, types.TransferOut)}
 }
 
 // Handle RegisterMsg
@@ -109,5 +109,5 @@ func handleUpdateAccountMsg(ctx sdk.Context, am AccountManager, msg UpdateAccoun
 	if err := am.UpdateJSONMeta(ctx, msg.Username, msg.JSONMeta); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
 }
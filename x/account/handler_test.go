@@ -56,6 +56,9 @@ func TestTransferNormal(t *testing.T) {
 		if result.IsOK() != tc.wantOK {
 			t.Errorf("%s diff result, got %v, want %v", tc.testName, result.IsOK(), tc.wantOK)
 		}
+		if tc.wantOK {
+			assert.Equal(t, types.TransferTags(tc.msg.Sender, tc.msg.Receiver, c200, types.TransferOut), result.Tags)
+		}
 
 		senderSaving, _ := am.GetSavingFromBank(ctx, tc.msg.Sender)
 		receiverSaving, _ := am.GetSavingFromBank(ctx, tc.msg.Receiver)
@@ -150,8 +153,9 @@ func TestHandleAccountRecover(t *testing.T) {
 	for testName, tc := range testCases {
 		msg := NewRecoverMsg(tc.user, tc.newResetKey, tc.newTransactionKey, tc.newAppKey)
 		result := handler(ctx, msg)
-		if !assert.Equal(t, sdk.Result{}, result) {
-			t.Errorf("%s: diff result, got %v, want %v", testName, result, sdk.Result{})
+		expectResult := sdk.Result{Tags: types.UsernameTags(types.AccountKey(tc.user))}
+		if !assert.Equal(t, expectResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", testName, result, expectResult)
 		}
 
 		accInfo := model.AccountInfo{
@@ -199,7 +203,9 @@ func TestHandleRegister(t *testing.T) {
 				secp256k1.GenPrivKey().PubKey(),
 				secp256k1.GenPrivKey().PubKey(),
 			),
-			expectResult:            sdk.Result{},
+			expectResult: sdk.Result{
+				Tags: types.TransferTags("referrer", "user1", types.NewCoinFromInt64(1*types.Decimals), types.TransferOut),
+			},
 			expectReferrerSaving:    c100,
 			expectNewAccountSaving:  c0,
 			expectNewAccountCoinDay: c0,
@@ -251,7 +257,9 @@ func TestHandleRegister(t *testing.T) {
 				secp256k1.GenPrivKey().PubKey(),
 				secp256k1.GenPrivKey().PubKey(),
 			),
-			expectResult:            sdk.Result{},
+			expectResult: sdk.Result{
+				Tags: types.TransferTags("referrer", "user3", types.NewCoinFromInt64(150000), types.TransferOut),
+			},
 			expectReferrerSaving:    types.NewCoinFromInt64(9750000),
 			expectNewAccountSaving:  types.NewCoinFromInt64(50000),
 			expectNewAccountCoinDay: types.NewCoinFromInt64(50000),
@@ -264,7 +272,9 @@ func TestHandleRegister(t *testing.T) {
 				secp256k1.GenPrivKey().PubKey(),
 				secp256k1.GenPrivKey().PubKey(),
 			),
-			expectResult:            sdk.Result{},
+			expectResult: sdk.Result{
+				Tags: types.TransferTags("referrer", "user4", types.NewCoinFromInt64(250000), types.TransferOut),
+			},
 			expectReferrerSaving:    types.NewCoinFromInt64(95 * types.Decimals),
 			expectNewAccountSaving:  types.NewCoinFromInt64(150000),
 			expectNewAccountCoinDay: types.NewCoinFromInt64(1 * types.Decimals),
@@ -355,7 +365,7 @@ func TestHandleUpdateAccountMsg(t *testing.T) {
 		{
 			testName:         "normal update",
 			updateAccountMsg: NewUpdateAccountMsg("accKey", "{'link':'https://lino.network'}"),
-			expectResult:     sdk.Result{},
+			expectResult:     sdk.Result{Tags: types.UsernameTags("accKey")},
 		},
 		{
 			testName:         "invalid username",
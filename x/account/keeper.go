@@ -16,6 +16,8 @@ type AccountKeeper interface {
 		resetKey, transactionKey, appKey crypto.PubKey, registerDeposit types.Coin) sdk.Error
 	GetCoinDay(
 		ctx sdk.Context, username types.AccountKey) (types.Coin, sdk.Error)
+	GetReferrer(
+		ctx sdk.Context, username types.AccountKey) (types.AccountKey, sdk.Error)
 	AddSavingCoin(
 		ctx sdk.Context, username types.AccountKey, coin types.Coin, from types.AccountKey, memo string,
 		detailType types.TransferDetailType) (err sdk.Error)
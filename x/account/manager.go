@@ -63,6 +63,9 @@ func (accManager AccountManager) CreateAccount(
 		TransactionKey: transactionKey,
 		AppKey:         appKey,
 	}
+	if ctx.BlockHeader().Height >= types.BlockchainUpgrade1Update12Height {
+		accountInfo.Referrer = referrer
+	}
 	if err := accManager.storage.SetInfo(ctx, username, accountInfo); err != nil {
 		return err
 	}
@@ -380,6 +383,16 @@ func (accManager AccountManager) GetAppKey(
 	return accountInfo.AppKey, nil
 }
 
+// GetReferrer - get the account who registered the user
+func (accManager AccountManager) GetReferrer(
+	ctx sdk.Context, username types.AccountKey) (types.AccountKey, sdk.Error) {
+	accountInfo, err := accManager.storage.GetInfo(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	return accountInfo.Referrer, nil
+}
+
 // GetSavingFromBank - get user balance
 func (accManager AccountManager) GetSavingFromBank(
 	ctx sdk.Context, username types.AccountKey) (types.Coin, sdk.Error) {
@@ -846,6 +859,22 @@ func (accManager AccountManager) IterateAccounts(ctx sdk.Context, process func(m
 	accManager.storage.IterateAccounts(ctx, process)
 }
 
+// GetTotalSaving - get sum of saving of all accounts
+func (accManager AccountManager) GetTotalSaving(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	accManager.storage.IterateAccounts(ctx, func(info model.AccountInfo, bank model.AccountBank) bool {
+		total = total.Plus(bank.Saving)
+		return false
+	})
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by accounts. Frozen money is returned by
+// coin return events, which are counted by global manager.
+func (accManager AccountManager) RegisterInvariants(ir *types.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "saving", accManager.GetTotalSaving)
+}
+
 func min(a, b int64) int64 {
 	if a < b {
 		return a
@@ -1896,6 +1896,39 @@ func TestLastPostAt(t *testing.T) {
 		assert.Equal(t, lastPostAt, tc.lastPostAt)
 	}
 }
+
+func TestGetReferrer(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		height         int64
+		expectReferrer types.AccountKey
+	}{
+		{
+			testName:       "referrer is not stored before upgrade",
+			height:         types.BlockchainUpgrade1Update12Height - 1,
+			expectReferrer: "",
+		},
+		{
+			testName:       "referrer is stored since upgrade",
+			height:         types.BlockchainUpgrade1Update12Height,
+			expectReferrer: accountReferrer,
+		},
+	}
+	for _, tc := range testCases {
+		ctx, am, _ := setupTest(t, tc.height)
+		user1 := types.AccountKey("user1")
+
+		createTestAccount(ctx, am, string(user1))
+
+		referrer, err := am.GetReferrer(ctx, user1)
+		assert.Nil(t, err, "%s", tc.testName)
+		assert.Equal(t, tc.expectReferrer, referrer, "%s", tc.testName)
+
+		_, err = am.GetReferrer(ctx, types.AccountKey("nonexist"))
+		assert.NotNil(t, err, "%s", tc.testName)
+	}
+}
+
 func TestAddFrozenMoney(t *testing.T) {
 	ctx, am, _ := setupTest(t, 1)
 	user1 := types.AccountKey("user1")
@@ -97,6 +97,29 @@ func (_m *AccountKeeper) GetCoinDay(ctx types.Context, username linotypes.Accoun
 	return r0, r1
 }
 
+// GetReferrer provides a mock function with given fields: ctx, username
+func (_m *AccountKeeper) GetReferrer(ctx types.Context, username linotypes.AccountKey) (linotypes.AccountKey, types.Error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 linotypes.AccountKey
+	if rf, ok := ret.Get(0).(func(types.Context, linotypes.AccountKey) linotypes.AccountKey); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(linotypes.AccountKey)
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context, linotypes.AccountKey) types.Error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // MinusSavingCoin provides a mock function with given fields: ctx, username, coin, to, memo, detailType
 func (_m *AccountKeeper) MinusSavingCoin(ctx types.Context, username linotypes.AccountKey, coin linotypes.Coin, to linotypes.AccountKey, memo string, detailType linotypes.TransferDetailType) types.Error {
 	ret := _m.Called(ctx, username, coin, to, memo, detailType)
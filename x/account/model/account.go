@@ -16,6 +16,7 @@ type AccountInfo struct {
 	ResetKey       crypto.PubKey    `json:"reset_key"`
 	TransactionKey crypto.PubKey    `json:"transaction_key"`
 	AppKey         crypto.PubKey    `json:"app_key"`
+	Referrer       types.AccountKey `json:"referrer"`
 }
 
 // AccountBank - user balance
@@ -38,6 +38,7 @@ func TestAccountInfo(t *testing.T) {
 		ResetKey:       secp256k1.GenPrivKey().PubKey(),
 		TransactionKey: secp256k1.GenPrivKey().PubKey(),
 		AppKey:         secp256k1.GenPrivKey().PubKey(),
+		Referrer:       types.AccountKey("referrer"),
 	}
 	err := as.SetInfo(ctx, types.AccountKey("test"), &accInfo)
 	assert.Nil(t, err)
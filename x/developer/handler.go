@@ -58,7 +58,8 @@ func handleDeveloperRegisterMsg(
 		ctx, msg.Username, deposit, msg.Website, msg.Description, msg.AppMetaData); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, deposit, types.DeveloperDeposit).
+		AppendTag(types.TagApp, string(msg.Username))}
 }
 
 func handleDeveloperUpdateMsg(
@@ -75,7 +76,7 @@ func handleDeveloperUpdateMsg(
 		ctx, msg.Username, msg.Website, msg.Description, msg.AppMetaData); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username).AppendTag(types.TagApp, string(msg.Username))}
 }
 
 func handleDeveloperRevokeMsg(
@@ -103,7 +104,8 @@ func handleDeveloperRevokeMsg(
 		ctx, msg.Username, gm, am, param.DeveloperCoinReturnTimes, param.DeveloperCoinReturnIntervalSec, coin); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.DeveloperReturnCoin).
+		AppendTag(types.TagApp, string(msg.Username))}
 }
 
 func handleGrantPermissionMsg(
@@ -146,7 +148,7 @@ func handleGrantPermissionMsg(
 	default:
 		return ErrInvalidGrantPermission().Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username).AppendTag(types.TagApp, string(msg.AuthorizedApp))}
 }
 
 func handleRevokePermissionMsg(
@@ -158,7 +160,7 @@ func handleRevokePermissionMsg(
 	if err := am.RevokePermission(ctx, msg.Username, msg.RevokeFrom, msg.Permission); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username).AppendTag(types.TagApp, string(msg.RevokeFrom))}
 }
 
 func handlePreAuthorizationMsg(
@@ -179,7 +181,8 @@ func handlePreAuthorizationMsg(
 		ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, types.PreAuthorizationPermission, amount); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, amount, types.TransferOut).
+		AppendTag(types.TagApp, string(msg.AuthorizedApp))}
 }
 
 func returnCoinTo(
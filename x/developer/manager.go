@@ -219,6 +219,22 @@ func (dm DeveloperManager) WithdrawAll(
 	return developer.Deposit, nil
 }
 
+// GetTotalDeposit - get sum of deposit of all developers
+func (dm DeveloperManager) GetTotalDeposit(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	if err := dm.storage.IterateDevelopers(ctx, func(developer *model.Developer) {
+		total = total.Plus(developer.Deposit)
+	}); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by developers
+func (dm DeveloperManager) RegisterInvariants(ir *types.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "deposit", dm.GetTotalDeposit)
+}
+
 // Export state of storage
 func (dm DeveloperManager) Export(ctx sdk.Context) *model.DeveloperTables {
 	return dm.storage.Export(ctx)
@@ -57,6 +57,21 @@ func (ds DeveloperStorage) GetDeveloper(
 	return provider, nil
 }
 
+// IterateDevelopers - call fn on every developer
+func (ds DeveloperStorage) IterateDevelopers(ctx sdk.Context, fn func(developer *Developer)) sdk.Error {
+	store := ctx.KVStore(ds.key)
+	itr := sdk.KVStorePrefixIterator(store, developerSubstore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		developer := new(Developer)
+		if err := ds.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), developer); err != nil {
+			return ErrFailedToUnmarshalDeveloper(err)
+		}
+		fn(developer)
+	}
+	return nil
+}
+
 // SetDeveloper - set developer to KVStore
 func (ds DeveloperStorage) SetDeveloper(
 	ctx sdk.Context, accKey types.AccountKey, developer *Developer) sdk.Error {
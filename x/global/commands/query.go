@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/global"
+	"github.com/lino-network/lino/x/global/model"
+)
+
+// GetUpcomingEventsCmd returns upcoming coin returns and rewards of a user
+func GetUpcomingEventsCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upcoming-events <username>",
+		Short: "Query upcoming coin returns and rewards of a user",
+		RunE:  getUpcomingEventsCmd(cdc),
+	}
+	cmd.Flags().String(client.FlagEventType, global.AllEventTypes,
+		fmt.Sprintf("event type, one of %s, %s or %s",
+			types.ReturnCoinEventType, types.RewardEventType, global.AllEventTypes))
+	cmd.Flags().Int64(client.FlagDays, 30, "number of days to look ahead")
+	return cmd
+}
+
+func getUpcomingEventsCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a username")
+		}
+		days := viper.GetInt64(client.FlagDays)
+		if days <= 0 {
+			return errors.New("days must be positive")
+		}
+		startTime := time.Now().Unix()
+		endTime := startTime + days*24*3600
+
+		res, err := ctx.QueryCustom(
+			global.QuerierRoute, global.QueryUpcomingTimeEvents,
+			strconv.FormatInt(startTime, 10), strconv.FormatInt(endTime, 10),
+			viper.GetString(client.FlagEventType), args[0])
+		if err != nil {
+			return err
+		}
+		rows := []model.GlobalTimeEventTimeRow{}
+		if err := cdc.UnmarshalJSON(res, &rows); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			output, err := wire.MarshalJSONIndent(cdc, row.TimeEventList.Events)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s (%d):\n%s\n", time.Unix(row.UnixTime, 0).UTC().Format(time.RFC3339), row.UnixTime, string(output))
+		}
+		return nil
+	}
+}
+
+// GetCommunityTreasuryCmd returns coins in community treasury
+func GetCommunityTreasuryCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "community-treasury",
+		Short: "Query coins in community treasury",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := client.NewCoreContextFromViper()
+			res, err := ctx.QueryCustom(global.QuerierRoute, global.QueryCommunityTreasury)
+			if err != nil {
+				return err
+			}
+			treasury := new(model.CommunityTreasury)
+			if err := cdc.UnmarshalJSON(res, treasury); err != nil {
+				return err
+			}
+			output, err := wire.MarshalJSONIndent(cdc, treasury)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
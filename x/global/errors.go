@@ -26,3 +26,13 @@ func ErrParseEventCacheList() sdk.Error {
 func ErrQueryFailed() sdk.Error {
 	return types.NewError(types.CodeGlobalQueryFailed, fmt.Sprintf("query global store failed"))
 }
+
+// ErrInvalidTimeEventQueryRange - error when time event query range is invalid or too large
+func ErrInvalidTimeEventQueryRange(startTime, endTime int64) sdk.Error {
+	return types.NewError(types.CodeInvalidTimeEventQueryRange, fmt.Sprintf("invalid time event query range [%v, %v)", startTime, endTime))
+}
+
+// ErrCommunityTreasuryNotEnough - error if community treasury can't afford the spend
+func ErrCommunityTreasuryNotEnough(balance, spend types.Coin) sdk.Error {
+	return types.NewError(types.CodeCommunityTreasuryNotEnough, fmt.Sprintf("community treasury %v is not enough for %v", balance, spend))
+}
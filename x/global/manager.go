@@ -76,6 +76,278 @@ func (gm *GlobalManager) GetTimeEventListAtTime(ctx sdk.Context, unixTime int64)
 	return eventList
 }
 
+// GetTimeEventListsInRange - get time event lists in [startTime, endTime) ordered by time
+func (gm *GlobalManager) GetTimeEventListsInRange(
+	ctx sdk.Context, startTime, endTime int64) ([]model.GlobalTimeEventTimeRow, sdk.Error) {
+	return gm.storage.GetTimeEventListsInRange(ctx, startTime, endTime)
+}
+
+// GetUpcomingTimeEvents - get time events in [startTime, endTime) of given event type
+// and involving given account, empty event type or username matches all events.
+func (gm *GlobalManager) GetUpcomingTimeEvents(
+	ctx sdk.Context, startTime, endTime int64, eventType string,
+	username types.AccountKey) ([]model.GlobalTimeEventTimeRow, sdk.Error) {
+	rows, err := gm.storage.GetTimeEventListsInRange(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	rst := []model.GlobalTimeEventTimeRow{}
+	for _, row := range rows {
+		events := []types.Event{}
+		for _, event := range row.TimeEventList.Events {
+			if matchTimeEvent(event, eventType, username) {
+				events = append(events, event)
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		rst = append(rst, model.GlobalTimeEventTimeRow{
+			UnixTime:      row.UnixTime,
+			TimeEventList: types.TimeEventList{Events: events},
+		})
+	}
+	return rst, nil
+}
+
+func matchTimeEvent(event types.Event, eventType string, username types.AccountKey) bool {
+	if eventType == "" && username == "" {
+		return true
+	}
+	e, ok := event.(types.InspectableEvent)
+	if !ok {
+		return false
+	}
+	if eventType != "" && e.EventType() != eventType {
+		return false
+	}
+	if username != "" && types.FindAccountInList(username, e.InvolvedAccounts()) == -1 {
+		return false
+	}
+	return true
+}
+
+// MigrateTimeEventLists - rekey all legacy time event lists by big-endian time
+func (gm *GlobalManager) MigrateTimeEventLists(ctx sdk.Context) sdk.Error {
+	n, err := gm.storage.MigrateLegacyTimeEventLists(ctx)
+	if err != nil {
+		return err
+	}
+	ctx.Logger().Info("time event lists migrated", "count", n)
+	return nil
+}
+
+// RecordFailedEvent - record a time event that failed to execute at current block
+func (gm *GlobalManager) RecordFailedEvent(
+	ctx sdk.Context, event types.Event, unixTime int64, execErr sdk.Error) sdk.Error {
+	id, err := gm.storage.GetNextFailedEventID(ctx)
+	if err != nil {
+		return err
+	}
+	failedEvent := &model.FailedEvent{
+		ID:       id,
+		Event:    event,
+		UnixTime: unixTime,
+		Height:   ctx.BlockHeight(),
+		Error:    execErr.Error(),
+	}
+	if err := gm.storage.SetFailedEvent(ctx, failedEvent); err != nil {
+		return err
+	}
+	return gm.storage.SetNextFailedEventID(ctx, id+1)
+}
+
+// DoesFailedEventExist - check if failed event exists
+func (gm *GlobalManager) DoesFailedEventExist(ctx sdk.Context, id int64) bool {
+	return gm.storage.DoesFailedEventExist(ctx, id)
+}
+
+// GetFailedEvent - get failed event by id
+func (gm *GlobalManager) GetFailedEvent(ctx sdk.Context, id int64) (*model.FailedEvent, sdk.Error) {
+	return gm.storage.GetFailedEvent(ctx, id)
+}
+
+// GetFailedEvents - get all failed events ordered by id
+func (gm *GlobalManager) GetFailedEvents(ctx sdk.Context) ([]model.FailedEvent, sdk.Error) {
+	return gm.storage.GetFailedEvents(ctx)
+}
+
+// RetryFailedEvent - register failed event at current time, it will be executed in next block
+func (gm *GlobalManager) RetryFailedEvent(ctx sdk.Context, id int64) sdk.Error {
+	failedEvent, err := gm.storage.GetFailedEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := gm.registerEventAtTime(ctx, ctx.BlockHeader().Time.Unix(), failedEvent.Event); err != nil {
+		return err
+	}
+	gm.storage.RemoveFailedEvent(ctx, id)
+	return nil
+}
+
+// DiscardFailedEvent - remove failed event without executing it
+func (gm *GlobalManager) DiscardFailedEvent(ctx sdk.Context, id int64) sdk.Error {
+	if !gm.storage.DoesFailedEventExist(ctx, id) {
+		return model.ErrFailedEventNotFound(id)
+	}
+	gm.storage.RemoveFailedEvent(ctx, id)
+	return nil
+}
+
+// GetTotalLinoCoin - get total lino coin in circulation
+func (gm *GlobalManager) GetTotalLinoCoin(ctx sdk.Context) (types.Coin, sdk.Error) {
+	globalMeta, err := gm.storage.GetGlobalMeta(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return globalMeta.TotalLinoCoin, nil
+}
+
+// GetTotalSupply - get total lino coin in circulation and coins in inflation pools
+// and consumption reward pool, which are added to circulation when distributed,
+// minus supply offset counted twice in them.
+func (gm *GlobalManager) GetTotalSupply(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total, err := gm.getGrossSupply(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	offset, err := gm.GetSupplyOffset(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return total.Minus(offset), nil
+}
+
+// GetSupplyOffset - get coins counted twice in total lino coin and inflation pools
+func (gm *GlobalManager) GetSupplyOffset(ctx sdk.Context) (types.Coin, sdk.Error) {
+	offset, err := gm.storage.GetSupplyOffset(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return offset.Amount, nil
+}
+
+// ReconcileSupplyOffset - set supply offset to the surplus of total supply over holdings,
+// which is the amount recycled into inflation pools before it is recorded.
+func (gm *GlobalManager) ReconcileSupplyOffset(ctx sdk.Context, holdings types.Coin) sdk.Error {
+	total, err := gm.getGrossSupply(ctx)
+	if err != nil {
+		return err
+	}
+	return gm.storage.SetSupplyOffset(ctx, &model.SupplyOffset{Amount: total.Minus(holdings)})
+}
+
+// getGrossSupply - get total lino coin and coins in inflation pools and consumption reward pool
+func (gm *GlobalManager) getGrossSupply(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total, err := gm.GetTotalLinoCoin(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	for _, pool := range []types.SupplyHolding{
+		gm.GetInfraInflationPool, gm.GetValidatorInflationPool,
+		gm.GetDeveloperInflationPool, gm.GetConsumptionRewardPool} {
+		coin, err := pool(ctx)
+		if err != nil {
+			return types.NewCoinFromInt64(0), err
+		}
+		total = total.Plus(coin)
+	}
+	return total, nil
+}
+
+// GetInfraInflationPool - get coins in infra inflation pool
+func (gm *GlobalManager) GetInfraInflationPool(ctx sdk.Context) (types.Coin, sdk.Error) {
+	pool, err := gm.storage.GetInflationPool(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return pool.InfraInflationPool, nil
+}
+
+// GetValidatorInflationPool - get coins in validator inflation pool
+func (gm *GlobalManager) GetValidatorInflationPool(ctx sdk.Context) (types.Coin, sdk.Error) {
+	pool, err := gm.storage.GetInflationPool(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return pool.ValidatorInflationPool, nil
+}
+
+// GetDeveloperInflationPool - get coins in developer inflation pool
+func (gm *GlobalManager) GetDeveloperInflationPool(ctx sdk.Context) (types.Coin, sdk.Error) {
+	pool, err := gm.storage.GetInflationPool(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return pool.DeveloperInflationPool, nil
+}
+
+// GetConsumptionRewardPool - get coins in consumption reward pool
+func (gm *GlobalManager) GetConsumptionRewardPool(ctx sdk.Context) (types.Coin, sdk.Error) {
+	consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return consumptionMeta.ConsumptionRewardPool, nil
+}
+
+// GetPendingCoin - get sum of coins held by pending and failed time events
+func (gm *GlobalManager) GetPendingCoin(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	addPending := func(event types.Event) {
+		if e, ok := event.(types.PendingCoinEvent); ok {
+			total = total.Plus(e.PendingCoin())
+		}
+	}
+	if err := gm.storage.IterateTimeEventLists(ctx, func(lst *types.TimeEventList) {
+		for _, event := range lst.Events {
+			addPending(event)
+		}
+	}); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	failedEvents, err := gm.storage.GetFailedEvents(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	for _, failedEvent := range failedEvents {
+		addPending(failedEvent.Event)
+	}
+	return total, nil
+}
+
+// GetUnclaimedFriction - get sum of consumption friction not yet claimed as interest
+func (gm *GlobalManager) GetUnclaimedFriction(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	if err := gm.storage.IterateLinoStakeStats(ctx, func(stat *model.LinoStakeStat) {
+		total = total.Plus(stat.UnclaimedFriction)
+	}); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by global and the supply invariant.
+// Coins in inflation pools and consumption reward pool are part of total supply.
+// Supply offset is recorded since BlockchainUpgrade1Update10Height, the supply
+// invariant is not checked before it.
+func (gm *GlobalManager) RegisterInvariants(ir *types.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "pending_coin", gm.GetPendingCoin)
+	ir.RegisterSupplyHolding(ModuleName, "unclaimed_friction", gm.GetUnclaimedFriction)
+	ir.RegisterSupplyHolding(ModuleName, "community_treasury", gm.GetCommunityTreasury)
+	ir.RegisterSupplyHolding(ModuleName, "infra_inflation_pool", gm.GetInfraInflationPool)
+	ir.RegisterSupplyHolding(ModuleName, "validator_inflation_pool", gm.GetValidatorInflationPool)
+	ir.RegisterSupplyHolding(ModuleName, "developer_inflation_pool", gm.GetDeveloperInflationPool)
+	ir.RegisterSupplyHolding(ModuleName, "consumption_reward_pool", gm.GetConsumptionRewardPool)
+	supplyInvariant := ir.SupplyInvariant(gm.GetTotalSupply)
+	ir.RegisterInvariant(ModuleName, "supply", func(ctx sdk.Context) error {
+		if ctx.BlockHeight() < types.BlockchainUpgrade1Update10Height {
+			return nil
+		}
+		return supplyInvariant(ctx)
+	})
+}
+
 // GetLastBlockTime - get last block time from KVStore
 func (gm *GlobalManager) GetLastBlockTime(ctx sdk.Context) (int64, sdk.Error) {
 	globalTime, err := gm.storage.GetGlobalTime(ctx)
@@ -175,6 +447,14 @@ func (gm *GlobalManager) AddFrictionAndRegisterContentRewardEvent(
 	if err != nil {
 		return err
 	}
+	allocation, err := gm.paramHolder.GetGlobalAllocationParam(ctx)
+	if err != nil {
+		return err
+	}
+	friction, err = gm.takeTreasuryShare(ctx, friction, allocation.TreasuryFrictionShare)
+	if err != nil {
+		return err
+	}
 	consumptionMeta.ConsumptionWindow = types.NewMiniDollarFromInt(consumptionMeta.ConsumptionWindow.Add(evaluate.Int))
 	linoStakeStat.TotalConsumptionFriction = linoStakeStat.TotalConsumptionFriction.Plus(friction)
 	linoStakeStat.UnclaimedFriction = linoStakeStat.UnclaimedFriction.Plus(friction)
@@ -227,6 +507,19 @@ func (gm *GlobalManager) MinusLinoStakeFromStat(ctx sdk.Context, linoStake types
 	return nil
 }
 
+// GetTotalLinoStake - get total lino stake at current day
+func (gm *GlobalManager) GetTotalLinoStake(ctx sdk.Context) (types.Coin, sdk.Error) {
+	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	linoStakeStat, err := gm.storage.GetLinoStakeStat(ctx, pastDay)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return linoStakeStat.TotalLinoStake, nil
+}
+
 // GetInterestSince - get interest from unix time till now (exclusive)
 func (gm *GlobalManager) GetInterestSince(ctx sdk.Context, unixTime int64, linoStake types.Coin) (types.Coin, sdk.Error) {
 	startDay, err := gm.GetPastDay(ctx, unixTime)
@@ -436,7 +729,7 @@ func (gm *GlobalManager) AddToDeveloperInflationPool(ctx sdk.Context, coin types
 	if err := gm.storage.SetInflationPool(ctx, inflationPool); err != nil {
 		return err
 	}
-	return nil
+	return gm.recordRecycledCoin(ctx, coin)
 }
 
 // AddToValidatorInflationPool - add validator inflation to pool
@@ -449,7 +742,83 @@ func (gm *GlobalManager) AddToValidatorInflationPool(ctx sdk.Context, coin types
 	if err := gm.storage.SetInflationPool(ctx, pool); err != nil {
 		return err
 	}
-	return nil
+	return gm.recordRecycledCoin(ctx, coin)
+}
+
+// recordRecycledCoin - since BlockchainUpgrade1Update10Height, coin added back to inflation
+// pool is added to supply offset, as it stays in total lino coin and is added again when
+// pool is distributed.
+func (gm *GlobalManager) recordRecycledCoin(ctx sdk.Context, coin types.Coin) sdk.Error {
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update10Height {
+		return nil
+	}
+	offset, err := gm.storage.GetSupplyOffset(ctx)
+	if err != nil {
+		return err
+	}
+	offset.Amount = offset.Amount.Plus(coin)
+	return gm.storage.SetSupplyOffset(ctx, offset)
+}
+
+// DistributePenalty - add validator penalty to community treasury by its share
+// since BlockchainUpgrade1Update9Height, the rest goes to validator inflation pool.
+func (gm *GlobalManager) DistributePenalty(ctx sdk.Context, penalty types.Coin) sdk.Error {
+	allocation, err := gm.paramHolder.GetGlobalAllocationParam(ctx)
+	if err != nil {
+		return err
+	}
+	rest, err := gm.takeTreasuryShare(ctx, penalty, allocation.TreasuryPenaltyShare)
+	if err != nil {
+		return err
+	}
+	return gm.AddToValidatorInflationPool(ctx, rest)
+}
+
+// takeTreasuryShare - add share of coin to community treasury, return the rest.
+// The share is not set in params stored before the treasury is introduced.
+func (gm *GlobalManager) takeTreasuryShare(
+	ctx sdk.Context, coin types.Coin, share sdk.Dec) (types.Coin, sdk.Error) {
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update9Height || share.IsNil() {
+		return coin, nil
+	}
+	treasuryCoin := types.DecToCoin(coin.ToDec().Mul(share))
+	if err := gm.AddToCommunityTreasury(ctx, treasuryCoin); err != nil {
+		return coin, err
+	}
+	return coin.Minus(treasuryCoin), nil
+}
+
+// GetCommunityTreasury - get coins in community treasury
+func (gm *GlobalManager) GetCommunityTreasury(ctx sdk.Context) (types.Coin, sdk.Error) {
+	treasury, err := gm.storage.GetCommunityTreasury(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return treasury.Balance, nil
+}
+
+// AddToCommunityTreasury - add coin to community treasury
+func (gm *GlobalManager) AddToCommunityTreasury(ctx sdk.Context, coin types.Coin) sdk.Error {
+	treasury, err := gm.storage.GetCommunityTreasury(ctx)
+	if err != nil {
+		return err
+	}
+	treasury.Balance = treasury.Balance.Plus(coin)
+	return gm.storage.SetCommunityTreasury(ctx, treasury)
+}
+
+// SpendFromCommunityTreasury - take coin from community treasury, the caller
+// is responsible for adding it to the receiver.
+func (gm *GlobalManager) SpendFromCommunityTreasury(ctx sdk.Context, coin types.Coin) sdk.Error {
+	treasury, err := gm.storage.GetCommunityTreasury(ctx)
+	if err != nil {
+		return err
+	}
+	if !treasury.Balance.IsGTE(coin) {
+		return ErrCommunityTreasuryNotEnough(treasury.Balance, coin)
+	}
+	treasury.Balance = treasury.Balance.Minus(coin)
+	return gm.storage.SetCommunityTreasury(ctx, treasury)
 }
 
 // GetValidatorHourlyInflation - get validator hourly inflation
@@ -518,6 +887,20 @@ func (gm *GlobalManager) addTotalLinoCoin(ctx sdk.Context, newCoin types.Coin) s
 	return nil
 }
 
+// BurnCoin - remove coin from circulation, the coin must already be taken from its holder
+func (gm *GlobalManager) BurnCoin(ctx sdk.Context, coin types.Coin) sdk.Error {
+	globalMeta, err := gm.storage.GetGlobalMeta(ctx)
+	if err != nil {
+		return err
+	}
+	globalMeta.TotalLinoCoin = globalMeta.TotalLinoCoin.Minus(coin)
+
+	if err := gm.storage.SetGlobalMeta(ctx, globalMeta); err != nil {
+		return err
+	}
+	return nil
+}
+
 // UpdateTPS - update current tps based on current block information
 func (gm *GlobalManager) UpdateTPS(ctx sdk.Context) sdk.Error {
 	tps, err := gm.storage.GetTPS(ctx)
@@ -573,6 +956,24 @@ func (gm *GlobalManager) CommitEventCache(ctx sdk.Context) sdk.Error {
 	return nil
 }
 
+// SnapshotEventCache - number of events at each time in event cache, used to
+// revert events registered by a failed time event.
+func (gm *GlobalManager) SnapshotEventCache() []int {
+	snapshot := make([]int, len(gm.deliverTxEventCacheList))
+	for i, eventCache := range gm.deliverTxEventCacheList {
+		snapshot[i] = len(eventCache.EventList)
+	}
+	return snapshot
+}
+
+// RevertEventCache - remove events registered to event cache after the snapshot
+func (gm *GlobalManager) RevertEventCache(snapshot []int) {
+	gm.deliverTxEventCacheList = gm.deliverTxEventCacheList[:len(snapshot)]
+	for i, size := range snapshot {
+		gm.deliverTxEventCacheList[i].EventList = gm.deliverTxEventCacheList[i].EventList[:size]
+	}
+}
+
 // ClearEventCache - clear event cache
 // clear event cache will only be committed at the beginblocker
 func (gm *GlobalManager) ClearEventCache(ctx sdk.Context) sdk.Error {
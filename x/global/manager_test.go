@@ -1,6 +1,7 @@
 package global
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
@@ -22,6 +23,17 @@ const (
 
 type testEvent struct{}
 
+type testAccountEvent struct {
+	Type     string           `json:"type"`
+	Username types.AccountKey `json:"username"`
+}
+
+func (e testAccountEvent) EventType() string { return e.Type }
+
+func (e testAccountEvent) InvolvedAccounts() []types.AccountKey {
+	return []types.AccountKey{e.Username}
+}
+
 // Construct some global addrs and txs for tests.
 var (
 	TestGlobalKVStoreKey = sdk.NewKVStoreKey("global")
@@ -414,6 +426,299 @@ func TestTimeEventList(t *testing.T) {
 	}
 }
 
+func TestGetTimeEventListsInRange(t *testing.T) {
+	ctx, gm := setupTest(t)
+	baseTime := int64(1500000000)
+	ctx = ctx.WithBlockHeader(abci.Header{
+		ChainID: "Lino", Height: types.BlockchainUpgrade1Update5Height, Time: time.Unix(baseTime, 0)})
+	for _, eventTime := range []int64{baseTime + 3600, baseTime, baseTime + 1, baseTime + 256, baseTime + 3600} {
+		err := gm.registerEventAtTime(ctx, eventTime, testEvent{})
+		assert.Nil(t, err)
+	}
+	err := gm.CommitEventCache(ctx)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName    string
+		startTime   int64
+		endTime     int64
+		expectTimes []int64
+		expectSizes []int
+	}{
+		{
+			testName:    "empty range",
+			startTime:   baseTime,
+			endTime:     baseTime,
+			expectTimes: []int64{},
+			expectSizes: []int{},
+		},
+		{
+			testName:    "end time is exclusive",
+			startTime:   baseTime,
+			endTime:     baseTime + 256,
+			expectTimes: []int64{baseTime, baseTime + 1},
+			expectSizes: []int{1, 1},
+		},
+		{
+			testName:    "ordered by time across byte boundary",
+			startTime:   baseTime + 1,
+			endTime:     baseTime + 3601,
+			expectTimes: []int64{baseTime + 1, baseTime + 256, baseTime + 3600},
+			expectSizes: []int{1, 1, 2},
+		},
+		{
+			testName:    "no events in range",
+			startTime:   baseTime + 3601,
+			endTime:     baseTime + 24*3600,
+			expectTimes: []int64{},
+			expectSizes: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		rows, err := gm.GetTimeEventListsInRange(ctx, tc.startTime, tc.endTime)
+		if err != nil {
+			t.Errorf("%s: failed to get time event lists, got err %v", tc.testName, err)
+		}
+		if len(rows) != len(tc.expectTimes) {
+			t.Errorf("%s: diff number of lists, got %v, want %v", tc.testName, len(rows), len(tc.expectTimes))
+			continue
+		}
+		for i, row := range rows {
+			assert.Equal(t, tc.expectTimes[i], row.UnixTime, tc.testName)
+			assert.Equal(t, tc.expectSizes[i], len(row.TimeEventList.Events), tc.testName)
+		}
+	}
+}
+
+func TestGetUpcomingTimeEvents(t *testing.T) {
+	ctx, gm := setupTest(t)
+	gm.WireCodec().RegisterConcrete(testAccountEvent{}, "testAccount", nil)
+	baseTime := ctx.BlockHeader().Time.Unix()
+	aliceReturn := testAccountEvent{Type: types.ReturnCoinEventType, Username: "alice"}
+	aliceReward := testAccountEvent{Type: types.RewardEventType, Username: "alice"}
+	bobReturn := testAccountEvent{Type: types.ReturnCoinEventType, Username: "bob"}
+	gm.registerEventAtTime(ctx, baseTime+10, aliceReturn)
+	gm.registerEventAtTime(ctx, baseTime+10, bobReturn)
+	gm.registerEventAtTime(ctx, baseTime+20, aliceReward)
+	gm.registerEventAtTime(ctx, baseTime+20, testEvent{})
+	gm.registerEventAtTime(ctx, baseTime+30, bobReturn)
+	err := gm.CommitEventCache(ctx)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName     string
+		eventType    string
+		username     types.AccountKey
+		expectEvents []model.GlobalTimeEventTimeRow
+	}{
+		{
+			testName:  "all events",
+			eventType: "",
+			username:  "",
+			expectEvents: []model.GlobalTimeEventTimeRow{
+				{UnixTime: baseTime + 10, TimeEventList: types.TimeEventList{Events: []types.Event{aliceReturn, bobReturn}}},
+				{UnixTime: baseTime + 20, TimeEventList: types.TimeEventList{Events: []types.Event{aliceReward, testEvent{}}}},
+				{UnixTime: baseTime + 30, TimeEventList: types.TimeEventList{Events: []types.Event{bobReturn}}},
+			},
+		},
+		{
+			testName:  "events of alice",
+			eventType: "",
+			username:  "alice",
+			expectEvents: []model.GlobalTimeEventTimeRow{
+				{UnixTime: baseTime + 10, TimeEventList: types.TimeEventList{Events: []types.Event{aliceReturn}}},
+				{UnixTime: baseTime + 20, TimeEventList: types.TimeEventList{Events: []types.Event{aliceReward}}},
+			},
+		},
+		{
+			testName:  "return coin events",
+			eventType: types.ReturnCoinEventType,
+			username:  "",
+			expectEvents: []model.GlobalTimeEventTimeRow{
+				{UnixTime: baseTime + 10, TimeEventList: types.TimeEventList{Events: []types.Event{aliceReturn, bobReturn}}},
+				{UnixTime: baseTime + 30, TimeEventList: types.TimeEventList{Events: []types.Event{bobReturn}}},
+			},
+		},
+		{
+			testName:     "no reward events of bob",
+			eventType:    types.RewardEventType,
+			username:     "bob",
+			expectEvents: []model.GlobalTimeEventTimeRow{},
+		},
+	}
+	for _, tc := range testCases {
+		events, err := gm.GetUpcomingTimeEvents(ctx, baseTime, baseTime+100, tc.eventType, tc.username)
+		if err != nil {
+			t.Errorf("%s: failed to get upcoming time events, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.expectEvents, events) {
+			t.Errorf("%s: diff events, got %v, want %v", tc.testName, events, tc.expectEvents)
+		}
+	}
+}
+
+func TestQueryUpcomingTimeEventsRange(t *testing.T) {
+	ctx, gm := setupTest(t)
+	testCases := []struct {
+		testName  string
+		height    int64
+		endTime   int64
+		expectErr sdk.Error
+	}{
+		{
+			testName:  "legacy query within six hours",
+			height:    types.BlockchainUpgrade1Update5Height - 1,
+			endTime:   maxLegacyTimeEventQueryRangeSec,
+			expectErr: nil,
+		},
+		{
+			testName:  "legacy query exceeds six hours",
+			height:    types.BlockchainUpgrade1Update5Height - 1,
+			endTime:   maxLegacyTimeEventQueryRangeSec + 1,
+			expectErr: ErrInvalidTimeEventQueryRange(0, maxLegacyTimeEventQueryRangeSec+1),
+		},
+		{
+			testName:  "indexed query within one year",
+			height:    types.BlockchainUpgrade1Update5Height,
+			endTime:   maxTimeEventQueryRangeSec,
+			expectErr: nil,
+		},
+		{
+			testName:  "indexed query exceeds one year",
+			height:    types.BlockchainUpgrade1Update5Height,
+			endTime:   maxTimeEventQueryRangeSec + 1,
+			expectErr: ErrInvalidTimeEventQueryRange(0, maxTimeEventQueryRangeSec+1),
+		},
+	}
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeight(tc.height)
+		_, err := queryUpcomingTimeEvents(
+			ctx, []string{"0", strconv.FormatInt(tc.endTime, 10)}, abci.RequestQuery{}, gm)
+		assert.Equal(t, tc.expectErr, err, tc.testName)
+	}
+}
+
+func TestMigrateTimeEventLists(t *testing.T) {
+	ctx, gm := setupTest(t)
+	baseTime := ctx.BlockHeader().Time.Unix()
+	// registered before upgrade, stored by legacy keys.
+	for i := int64(0); i < 10; i++ {
+		err := gm.registerEventAtTime(ctx, baseTime+i*100, testEvent{})
+		assert.Nil(t, err)
+	}
+	err := gm.CommitEventCache(ctx)
+	assert.Nil(t, err)
+
+	upgradeCtx := ctx.WithBlockHeight(types.BlockchainUpgrade1Update5Height)
+	rows, err := gm.GetTimeEventListsInRange(upgradeCtx, baseTime, baseTime+1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(rows))
+
+	err = gm.MigrateTimeEventLists(upgradeCtx)
+	assert.Nil(t, err)
+	rows, err = gm.GetTimeEventListsInRange(upgradeCtx, baseTime, baseTime+1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(rows))
+	for i, row := range rows {
+		assert.Equal(t, baseTime+int64(i)*100, row.UnixTime)
+		assert.Equal(t, &row.TimeEventList, gm.GetTimeEventListAtTime(upgradeCtx, row.UnixTime))
+		assert.Nil(t, gm.GetTimeEventListAtTime(ctx, row.UnixTime))
+	}
+}
+
+func TestFailedEvents(t *testing.T) {
+	ctx, gm := setupTest(t)
+	baseTime := ctx.BlockHeader().Time.Unix()
+
+	err := gm.RecordFailedEvent(ctx, testEvent{}, baseTime, ErrQueryFailed())
+	assert.Nil(t, err)
+	err = gm.RecordFailedEvent(ctx, testEvent{}, baseTime+1, ErrQueryFailed())
+	assert.Nil(t, err)
+
+	failedEvents, err := gm.GetFailedEvents(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(failedEvents))
+	assert.Equal(t, int64(1), failedEvents[0].ID)
+	assert.Equal(t, baseTime, failedEvents[0].UnixTime)
+	assert.Equal(t, int64(2), failedEvents[1].ID)
+
+	// retry reschedules the event at current block time.
+	err = gm.RetryFailedEvent(ctx, 1)
+	assert.Nil(t, err)
+	assert.False(t, gm.DoesFailedEventExist(ctx, 1))
+	err = gm.CommitEventCache(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TimeEventList{Events: []types.Event{testEvent{}}},
+		gm.GetTimeEventListAtTime(ctx, baseTime))
+
+	err = gm.DiscardFailedEvent(ctx, 2)
+	assert.Nil(t, err)
+	assert.False(t, gm.DoesFailedEventExist(ctx, 2))
+	err = gm.DiscardFailedEvent(ctx, 2)
+	assert.Equal(t, model.ErrFailedEventNotFound(2), err)
+	err = gm.RetryFailedEvent(ctx, 2)
+	assert.Equal(t, model.ErrFailedEventNotFound(2), err)
+
+	// id keeps increasing after events are resolved.
+	err = gm.RecordFailedEvent(ctx, testEvent{}, baseTime, ErrQueryFailed())
+	assert.Nil(t, err)
+	assert.True(t, gm.DoesFailedEventExist(ctx, 3))
+}
+
+func TestRevertEventCache(t *testing.T) {
+	ctx, gm := setupTest(t)
+	gm.WireCodec().RegisterConcrete(testAccountEvent{}, "testAccount", nil)
+	baseTime := ctx.BlockHeader().Time.Unix()
+	kept := testAccountEvent{Type: types.ReturnCoinEventType, Username: "alice"}
+	reverted := testAccountEvent{Type: types.ReturnCoinEventType, Username: "bob"}
+
+	assert.Nil(t, gm.registerEventAtTime(ctx, baseTime+10, kept))
+	snapshot := gm.SnapshotEventCache()
+	// events appended to existing time and registered at new time are both reverted.
+	assert.Nil(t, gm.registerEventAtTime(ctx, baseTime+10, reverted))
+	assert.Nil(t, gm.registerEventAtTime(ctx, baseTime+20, reverted))
+	gm.RevertEventCache(snapshot)
+	assert.Nil(t, gm.registerEventAtTime(ctx, baseTime+30, kept))
+
+	err := gm.CommitEventCache(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TimeEventList{Events: []types.Event{kept}},
+		gm.GetTimeEventListAtTime(ctx, baseTime+10))
+	assert.Nil(t, gm.GetTimeEventListAtTime(ctx, baseTime+20))
+	assert.Equal(t, &types.TimeEventList{Events: []types.Event{kept}},
+		gm.GetTimeEventListAtTime(ctx, baseTime+30))
+}
+
+// BenchmarkTimeEventsAfterOneDayHalt - fetch time events after one day without blocks.
+func BenchmarkTimeEventsAfterOneDayHalt(b *testing.B) {
+	ctx := getContext()
+	holder := param.NewParamHolder(TestParamKVStoreKey)
+	holder.InitParam(ctx)
+	gm := NewGlobalManager(TestGlobalKVStoreKey, holder)
+	cdc := gm.WireCodec()
+	cdc.RegisterInterface((*types.Event)(nil), nil)
+	cdc.RegisterConcrete(testEvent{}, "test", nil)
+	gm.InitGlobalManager(ctx, totalLino)
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update5Height)
+	for i := int64(0); i < 24; i++ {
+		gm.registerEventAtTime(ctx, i*3600, testEvent{})
+	}
+	gm.CommitEventCache(ctx)
+
+	b.Run("per second", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := int64(0); i < 24*3600; i++ {
+				gm.GetTimeEventListAtTime(ctx, i)
+			}
+		}
+	})
+	b.Run("range scan", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			gm.GetTimeEventListsInRange(ctx, 0, 24*3600)
+		}
+	})
+}
+
 func TestRegisterCoinReturnEvent(t *testing.T) {
 	ctx, gm := setupTest(t)
 	baseTime := ctx.BlockHeader().Time.Unix()
@@ -898,25 +1203,41 @@ func TestAddToValidatorInflationPool(t *testing.T) {
 	}
 	err := gm.storage.SetInflationPool(ctx, inflationPool)
 	assert.Nil(t, err)
+	total, err := gm.GetTotalLinoCoin(ctx)
+	assert.Nil(t, err)
 
 	testCases := []struct {
-		testName string
-		coin     types.Coin
-		expect   types.Coin
+		testName     string
+		height       int64
+		coin         types.Coin
+		expect       types.Coin
+		expectOffset types.Coin
 	}{
 		{
-			testName: "add 100 inflation",
-			coin:     types.NewCoinFromInt64(100),
-			expect:   types.NewCoinFromInt64(100),
+			testName:     "add 100 inflation",
+			height:       1,
+			coin:         types.NewCoinFromInt64(100),
+			expect:       types.NewCoinFromInt64(100),
+			expectOffset: types.NewCoinFromInt64(0),
 		},
 		{
-			testName: "add 1 more inflation",
-			coin:     types.NewCoinFromInt64(1),
-			expect:   types.NewCoinFromInt64(101),
+			testName:     "add 1 more inflation",
+			height:       types.BlockchainUpgrade1Update10Height - 1,
+			coin:         types.NewCoinFromInt64(1),
+			expect:       types.NewCoinFromInt64(101),
+			expectOffset: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:     "coin added to pool is recorded as supply offset since upgrade",
+			height:       types.BlockchainUpgrade1Update10Height,
+			coin:         types.NewCoinFromInt64(10),
+			expect:       types.NewCoinFromInt64(111),
+			expectOffset: types.NewCoinFromInt64(10),
 		},
 	}
 
 	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeight(tc.height)
 		err := gm.AddToValidatorInflationPool(ctx, tc.coin)
 		if err != nil {
 			t.Errorf("%s: failed to add validator inflation pool, got err %v", tc.testName, err)
@@ -929,9 +1250,140 @@ func TestAddToValidatorInflationPool(t *testing.T) {
 			t.Errorf("%s: diff validator inflation pool, got %v, want %v", tc.testName,
 				pool.ValidatorInflationPool, tc.expect)
 		}
+		totalLino, err := gm.GetTotalLinoCoin(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get total lino coin, got err %v", tc.testName, err)
+		}
+		if !totalLino.IsEqual(total) {
+			t.Errorf("%s: diff total lino coin, got %v, want %v", tc.testName,
+				totalLino, total)
+		}
+		offset, err := gm.GetSupplyOffset(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get supply offset, got err %v", tc.testName, err)
+		}
+		if !offset.IsEqual(tc.expectOffset) {
+			t.Errorf("%s: diff supply offset, got %v, want %v", tc.testName,
+				offset, tc.expectOffset)
+		}
+	}
+}
+
+func TestBurnCoin(t *testing.T) {
+	ctx, gm := setupTest(t)
+	total, err := gm.GetTotalLinoCoin(ctx)
+	assert.Nil(t, err)
+
+	err = gm.BurnCoin(ctx, types.NewCoinFromInt64(100))
+	assert.Nil(t, err)
+	burnt, err := gm.GetTotalLinoCoin(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, total.Minus(types.NewCoinFromInt64(100)), burnt)
+}
+
+func TestDistributePenalty(t *testing.T) {
+	ctx, gm := setupTest(t)
+	err := gm.storage.SetInflationPool(ctx, &model.InflationPool{
+		ValidatorInflationPool: types.NewCoinFromInt64(0),
+	})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName       string
+		height         int64
+		penalty        types.Coin
+		expectPool     types.Coin
+		expectTreasury types.Coin
+	}{
+		{
+			testName:       "all penalty goes to inflation pool before upgrade",
+			height:         types.BlockchainUpgrade1Update9Height - 1,
+			penalty:        types.NewCoinFromInt64(100),
+			expectPool:     types.NewCoinFromInt64(100),
+			expectTreasury: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:       "half of penalty goes to treasury since upgrade",
+			height:         types.BlockchainUpgrade1Update9Height,
+			penalty:        types.NewCoinFromInt64(100),
+			expectPool:     types.NewCoinFromInt64(150),
+			expectTreasury: types.NewCoinFromInt64(50),
+		},
+		{
+			testName:       "treasury share is rounded",
+			height:         types.BlockchainUpgrade1Update9Height,
+			penalty:        types.NewCoinFromInt64(3),
+			expectPool:     types.NewCoinFromInt64(151),
+			expectTreasury: types.NewCoinFromInt64(52),
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeight(tc.height)
+		err := gm.DistributePenalty(ctx, tc.penalty)
+		if err != nil {
+			t.Errorf("%s: failed to distribute penalty, got err %v", tc.testName, err)
+		}
+		pool, err := gm.storage.GetInflationPool(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get inflation pool, got err %v", tc.testName, err)
+		}
+		if !pool.ValidatorInflationPool.IsEqual(tc.expectPool) {
+			t.Errorf("%s: diff validator inflation pool, got %v, want %v", tc.testName,
+				pool.ValidatorInflationPool, tc.expectPool)
+		}
+		treasury, err := gm.GetCommunityTreasury(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get community treasury, got err %v", tc.testName, err)
+		}
+		if !treasury.IsEqual(tc.expectTreasury) {
+			t.Errorf("%s: diff community treasury, got %v, want %v", tc.testName,
+				treasury, tc.expectTreasury)
+		}
 	}
 }
 
+func TestAddFrictionToCommunityTreasury(t *testing.T) {
+	ctx, gm := setupTest(t)
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update9Height)
+	err := gm.SetChainStartTime(ctx, ctx.BlockHeader().Time.Unix())
+	assert.Nil(t, err)
+
+	err = gm.AddFrictionAndRegisterContentRewardEvent(
+		ctx, testEvent{}, types.NewCoinFromInt64(100), types.NewMiniDollar(1))
+	assert.Nil(t, err)
+
+	treasury, err := gm.GetCommunityTreasury(ctx)
+	assert.Nil(t, err)
+	assert.True(t, treasury.IsEqual(types.NewCoinFromInt64(5)))
+	linoStakeStat, err := gm.storage.GetLinoStakeStat(ctx, 0)
+	assert.Nil(t, err)
+	assert.True(t, linoStakeStat.TotalConsumptionFriction.IsEqual(types.NewCoinFromInt64(95)))
+	assert.True(t, linoStakeStat.UnclaimedFriction.IsEqual(types.NewCoinFromInt64(95)))
+}
+
+func TestSpendFromCommunityTreasury(t *testing.T) {
+	ctx, gm := setupTest(t)
+	err := gm.AddToCommunityTreasury(ctx, types.NewCoinFromInt64(100))
+	assert.Nil(t, err)
+
+	err = gm.SpendFromCommunityTreasury(ctx, types.NewCoinFromInt64(101))
+	assert.Equal(t, ErrCommunityTreasuryNotEnough(
+		types.NewCoinFromInt64(100), types.NewCoinFromInt64(101)).Code(), err.Code())
+
+	err = gm.SpendFromCommunityTreasury(ctx, types.NewCoinFromInt64(40))
+	assert.Nil(t, err)
+	treasury, err := gm.GetCommunityTreasury(ctx)
+	assert.Nil(t, err)
+	assert.True(t, treasury.IsEqual(types.NewCoinFromInt64(60)))
+
+	err = gm.SpendFromCommunityTreasury(ctx, types.NewCoinFromInt64(60))
+	assert.Nil(t, err)
+	treasury, err = gm.GetCommunityTreasury(ctx)
+	assert.Nil(t, err)
+	assert.True(t, treasury.IsZero())
+}
+
 func TestChainStartTime(t *testing.T) {
 	ctx, gm := setupTest(t)
 
@@ -1091,6 +1543,20 @@ func TestAddLinoStakeToStat(t *testing.T) {
 	}
 }
 
+func TestGetTotalLinoStake(t *testing.T) {
+	ctx, gm := setupTest(t)
+
+	stake, err := gm.GetTotalLinoStake(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(0), stake)
+
+	err = gm.AddLinoStakeToStat(ctx, types.NewCoinFromInt64(10*types.Decimals))
+	assert.Nil(t, err)
+	stake, err = gm.GetTotalLinoStake(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(10*types.Decimals), stake)
+}
+
 func TestMinusLinoStakeFromStat(t *testing.T) {
 	ctx, gm := setupTest(t)
 
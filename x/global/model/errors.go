@@ -101,3 +101,43 @@ func ErrFailedToUnmarshalTPS(err error) sdk.Error {
 func ErrFailedToUnmarshalTime(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalTime, fmt.Sprintf("failed to unmarshal time: %s", err.Error()))
 }
+
+// ErrFailedToParseTimeEventListKey - error if parse time event list key failed
+func ErrFailedToParseTimeEventListKey(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToParseTimeEventListKey, fmt.Sprintf("failed to parse time event list key: %s", err.Error()))
+}
+
+// ErrFailedEventNotFound - error if failed event is not found in KVStore
+func ErrFailedEventNotFound(id int64) sdk.Error {
+	return types.NewError(types.CodeFailedEventNotFound, fmt.Sprintf("failed event %d not found", id))
+}
+
+// ErrFailedToMarshalFailedEvent - error if marshal failed event failed
+func ErrFailedToMarshalFailedEvent(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalFailedEvent, fmt.Sprintf("failed to marshal failed event: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalFailedEvent - error if unmarshal failed event failed
+func ErrFailedToUnmarshalFailedEvent(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalFailedEvent, fmt.Sprintf("failed to unmarshal failed event: %s", err.Error()))
+}
+
+// ErrFailedToMarshalCommunityTreasury - error if marshal community treasury failed
+func ErrFailedToMarshalCommunityTreasury(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalCommunityTreasury, fmt.Sprintf("failed to marshal community treasury: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalCommunityTreasury - error if unmarshal community treasury failed
+func ErrFailedToUnmarshalCommunityTreasury(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalCommunityTreasury, fmt.Sprintf("failed to unmarshal community treasury: %s", err.Error()))
+}
+
+// ErrFailedToMarshalSupplyOffset - error if marshal supply offset failed
+func ErrFailedToMarshalSupplyOffset(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalSupplyOffset, fmt.Sprintf("failed to marshal supply offset: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalSupplyOffset - error if unmarshal supply offset failed
+func ErrFailedToUnmarshalSupplyOffset(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalSupplyOffset, fmt.Sprintf("failed to unmarshal supply offset: %s", err.Error()))
+}
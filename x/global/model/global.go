@@ -42,6 +42,19 @@ func (t *TPS) ToIR() TPSIR {
 	}
 }
 
+// CommunityTreasury - coins funded by validator penalties and consumption friction,
+// spent by treasury spend proposals.
+type CommunityTreasury struct {
+	Balance types.Coin `json:"balance"`
+}
+
+// SupplyOffset - coins counted twice in total supply. Register fee and validator penalties
+// recycled into inflation pools stay in total lino coin, and are added again when pools
+// are distributed.
+type SupplyOffset struct {
+	Amount types.Coin `json:"amount"`
+}
+
 // InflationPool, determined by GlobalAllocation
 // InfraInflationPool inflation pool for infra
 // TotalContentCreatorInflationPool total inflation pool for content creator this year
@@ -83,6 +96,22 @@ type InitParamList struct {
 	ConsumptionFreezingPeriodSec int64   `json:"consumption_freezing_period_second"`
 }
 
+// FailedEvent - time event that failed to execute and was skipped
+// UnixTime is the time the event was scheduled at
+// Height is the block height the event failed at
+type FailedEvent struct {
+	ID       int64       `json:"id"`
+	Event    types.Event `json:"event"`
+	UnixTime int64       `json:"unix_time"`
+	Height   int64       `json:"height"`
+	Error    string      `json:"error"`
+}
+
+// NextFailedEventID - id of next failed event
+type NextFailedEventID struct {
+	NextFailedEventID int64 `json:"next_failed_event_id"`
+}
+
 type EventCache struct {
 	UnixTime  int64         `json:"unix_time"`
 	EventList []types.Event `json:"event_list"`
@@ -29,11 +29,17 @@ type GlobalMiscIR struct {
 	ConsumptionMeta ConsumptionMetaIR `json:"consumption_meta"`
 	TPS             TPSIR             `json:"tps"`
 	Time            GlobalTime        `json:"time"`
+	// since community treasury.
+	CommunityTreasury *CommunityTreasury `json:"community_treasury,omitempty"`
+	// since supply offset.
+	SupplyOffset *SupplyOffset `json:"supply_offset,omitempty"`
 }
 
 // GlobalTablesIR - GlobalMisc changed.
 type GlobalTablesIR struct {
 	GlobalTimeEventLists []GlobalTimeEventTimeRow `json:"global_time_event_lists"`
 	GlobalStakeStats     []GlobalStakeStatDayRow  `json:"global_stake_stats"`
-	GlobalMisc           GlobalMiscIR             `json:"global_misc"`
+	// since failed events are recorded.
+	GlobalFailedEvents []GlobalFailedEventRow `json:"global_failed_events"`
+	GlobalMisc         GlobalMiscIR           `json:"global_misc"`
 }
@@ -16,13 +16,21 @@ type GlobalStakeStatDayRow struct {
 	StakeStat LinoStakeStat `json:"stake_stat"`
 }
 
+// GlobalFailedEventRow - failed events, pk: ID
+type GlobalFailedEventRow struct {
+	ID          int64       `json:"id"`
+	FailedEvent FailedEvent `json:"failed_event"`
+}
+
 // GlobalMisc - a bunch of global variables with no pk, pk: none
 type GlobalMisc struct {
-	Meta            GlobalMeta      `json:"meta"`
-	InflationPool   InflationPool   `json:"inflation_pool"`
-	ConsumptionMeta ConsumptionMeta `json:"consumption_meta"`
-	TPS             TPS             `json:"tps"`
-	Time            GlobalTime      `json:"time"`
+	Meta              GlobalMeta        `json:"meta"`
+	InflationPool     InflationPool     `json:"inflation_pool"`
+	ConsumptionMeta   ConsumptionMeta   `json:"consumption_meta"`
+	TPS               TPS               `json:"tps"`
+	Time              GlobalTime        `json:"time"`
+	CommunityTreasury CommunityTreasury `json:"community_treasury"`
+	SupplyOffset      SupplyOffset      `json:"supply_offset"`
 }
 
 // ToIR -
@@ -33,6 +41,12 @@ func (g GlobalMisc) ToIR() GlobalMiscIR {
 		ConsumptionMeta: g.ConsumptionMeta.ToIR(),
 		TPS:             g.TPS.ToIR(),
 		Time:            g.Time,
+		CommunityTreasury: &CommunityTreasury{
+			Balance: g.CommunityTreasury.Balance,
+		},
+		SupplyOffset: &SupplyOffset{
+			Amount: g.SupplyOffset.Amount,
+		},
 	}
 }
 
@@ -40,6 +54,7 @@ func (g GlobalMisc) ToIR() GlobalMiscIR {
 type GlobalTables struct {
 	GlobalTimeEventLists []GlobalTimeEventTimeRow `json:"global_time_event_lists"`
 	GlobalStakeStats     []GlobalStakeStatDayRow  `json:"global_stake_stats"`
+	GlobalFailedEvents   []GlobalFailedEventRow   `json:"global_failed_events"`
 	GlobalMisc           GlobalMisc               `json:"global_misc"`
 }
 
@@ -48,6 +63,7 @@ func (g GlobalTables) ToIR() GlobalTablesIR {
 	return GlobalTablesIR{
 		GlobalTimeEventLists: g.GlobalTimeEventLists,
 		GlobalStakeStats:     g.GlobalStakeStats,
+		GlobalFailedEvents:   g.GlobalFailedEvents,
 		GlobalMisc:           g.GlobalMisc.ToIR(),
 	}
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/binary"
 	"strconv"
 
 	wire "github.com/cosmos/cosmos-sdk/codec"
@@ -18,6 +19,11 @@ var (
 	tpsSubStore             = []byte{0x04} // SubStore for tps
 	timeSubStore            = []byte{0x05} // SubStore for time
 	linoStakeStatSubStore   = []byte{0x06} // SubStore for lino power statistic
+	timeEventIndexSubStore  = []byte{0x07} // SubStore for time event list keyed by big-endian time
+	failedEventSubStore     = []byte{0x08} // SubStore for failed events
+	nextFailedEventIDStore  = []byte{0x09} // SubStore for next failed event id
+	communityTreasuryStore  = []byte{0x0a} // SubStore for community treasury
+	supplyOffsetStore       = []byte{0x0b} // SubStore for supply offset
 )
 
 // GlobalStorage - global storage
@@ -41,6 +47,7 @@ func NewGlobalStorage(key sdk.StoreKey) GlobalStorage {
 	cdc.RegisterConcrete(param.BandwidthParam{}, "param/bandwidth", nil)
 	cdc.RegisterConcrete(param.AccountParam{}, "param/account", nil)
 	cdc.RegisterConcrete(param.PostParam{}, "param/post", nil)
+	cdc.RegisterConcrete(param.ReputationParam{}, "param/reputation", nil)
 
 	wire.RegisterCrypto(cdc)
 	return GlobalStorage{
@@ -117,7 +124,7 @@ func (gs GlobalStorage) InitGlobalState(
 // GetTimeEventList - get time event list at given unix time
 func (gs GlobalStorage) GetTimeEventList(ctx sdk.Context, unixTime int64) (*types.TimeEventList, sdk.Error) {
 	store := ctx.KVStore(gs.key)
-	listByte := store.Get(GetTimeEventListKey(unixTime))
+	listByte := store.Get(getTimeEventListKeyAtHeight(ctx.BlockHeight(), unixTime))
 	// event doesn't exist
 	if listByte == nil {
 		return nil, nil
@@ -136,14 +143,226 @@ func (gs GlobalStorage) SetTimeEventList(ctx sdk.Context, unixTime int64, lst *t
 	if err != nil {
 		return ErrFailedToMarshalTimeEventList(err)
 	}
-	store.Set(GetTimeEventListKey(unixTime), listByte)
+	store.Set(getTimeEventListKeyAtHeight(ctx.BlockHeight(), unixTime), listByte)
 	return nil
 }
 
 // RemoveTimeEventList - remove time event list at given unix time
 func (gs GlobalStorage) RemoveTimeEventList(ctx sdk.Context, unixTime int64) sdk.Error {
 	store := ctx.KVStore(gs.key)
-	store.Delete(GetTimeEventListKey(unixTime))
+	store.Delete(getTimeEventListKeyAtHeight(ctx.BlockHeight(), unixTime))
+	return nil
+}
+
+// GetTimeEventListsInRange - get all time event lists in [startTime, endTime),
+// ordered by time. Before time events are indexed by big-endian time, every second
+// in the range is looked up.
+func (gs GlobalStorage) GetTimeEventListsInRange(
+	ctx sdk.Context, startTime, endTime int64) ([]GlobalTimeEventTimeRow, sdk.Error) {
+	rst := []GlobalTimeEventTimeRow{}
+	if startTime >= endTime {
+		return rst, nil
+	}
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update5Height {
+		for i := startTime; i < endTime; i++ {
+			lst, err := gs.GetTimeEventList(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			if lst != nil {
+				rst = append(rst, GlobalTimeEventTimeRow{UnixTime: i, TimeEventList: *lst})
+			}
+		}
+		return rst, nil
+	}
+	store := ctx.KVStore(gs.key)
+	itr := store.Iterator(GetTimeEventListKey(startTime), GetTimeEventListKey(endTime))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		lst := new(types.TimeEventList)
+		if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), lst); err != nil {
+			return nil, ErrFailedToUnmarshalTimeEventList(err)
+		}
+		rst = append(rst, GlobalTimeEventTimeRow{
+			UnixTime:      getUnixTimeFromTimeEventListKey(itr.Key()),
+			TimeEventList: *lst,
+		})
+	}
+	return rst, nil
+}
+
+// MigrateLegacyTimeEventLists - move all time event lists keyed by decimal string
+// to keys of big-endian time, returns the number of lists migrated.
+func (gs GlobalStorage) MigrateLegacyTimeEventLists(ctx sdk.Context) (int, sdk.Error) {
+	store := ctx.KVStore(gs.key)
+	keys := [][]byte{}
+	values := [][]byte{}
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, timeEventListSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			keys = append(keys, itr.Key())
+			values = append(values, itr.Value())
+		}
+	}()
+	for i, k := range keys {
+		unixTime, err := strconv.ParseInt(string(k[1:]), 10, 64)
+		if err != nil {
+			return 0, ErrFailedToParseTimeEventListKey(err)
+		}
+		store.Set(GetTimeEventListKey(unixTime), values[i])
+		store.Delete(k)
+	}
+	return len(keys), nil
+}
+
+// IterateTimeEventLists - call fn on every time event list, both legacy and indexed keys.
+func (gs GlobalStorage) IterateTimeEventLists(ctx sdk.Context, fn func(lst *types.TimeEventList)) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	for _, prefix := range [][]byte{timeEventListSubStore, timeEventIndexSubStore} {
+		if err := func() sdk.Error {
+			itr := sdk.KVStorePrefixIterator(store, prefix)
+			defer itr.Close()
+			for ; itr.Valid(); itr.Next() {
+				lst := new(types.TimeEventList)
+				if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), lst); err != nil {
+					return ErrFailedToUnmarshalTimeEventList(err)
+				}
+				fn(lst)
+			}
+			return nil
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFailedEvent - get failed event by id
+func (gs GlobalStorage) GetFailedEvent(ctx sdk.Context, id int64) (*FailedEvent, sdk.Error) {
+	store := ctx.KVStore(gs.key)
+	bz := store.Get(GetFailedEventKey(id))
+	if bz == nil {
+		return nil, ErrFailedEventNotFound(id)
+	}
+	failedEvent := new(FailedEvent)
+	if err := gs.cdc.UnmarshalBinaryLengthPrefixed(bz, failedEvent); err != nil {
+		return nil, ErrFailedToUnmarshalFailedEvent(err)
+	}
+	return failedEvent, nil
+}
+
+// SetFailedEvent - set failed event, keyed by its id
+func (gs GlobalStorage) SetFailedEvent(ctx sdk.Context, failedEvent *FailedEvent) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	bz, err := gs.cdc.MarshalBinaryLengthPrefixed(*failedEvent)
+	if err != nil {
+		return ErrFailedToMarshalFailedEvent(err)
+	}
+	store.Set(GetFailedEventKey(failedEvent.ID), bz)
+	return nil
+}
+
+// DoesFailedEventExist - check if failed event exists
+func (gs GlobalStorage) DoesFailedEventExist(ctx sdk.Context, id int64) bool {
+	store := ctx.KVStore(gs.key)
+	return store.Has(GetFailedEventKey(id))
+}
+
+// RemoveFailedEvent - remove failed event by id
+func (gs GlobalStorage) RemoveFailedEvent(ctx sdk.Context, id int64) {
+	store := ctx.KVStore(gs.key)
+	store.Delete(GetFailedEventKey(id))
+}
+
+// GetFailedEvents - get all failed events ordered by id
+func (gs GlobalStorage) GetFailedEvents(ctx sdk.Context) ([]FailedEvent, sdk.Error) {
+	rst := []FailedEvent{}
+	store := ctx.KVStore(gs.key)
+	itr := sdk.KVStorePrefixIterator(store, failedEventSubStore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		failedEvent := new(FailedEvent)
+		if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), failedEvent); err != nil {
+			return nil, ErrFailedToUnmarshalFailedEvent(err)
+		}
+		rst = append(rst, *failedEvent)
+	}
+	return rst, nil
+}
+
+// GetNextFailedEventID - get id for the next failed event, starts from 1
+func (gs GlobalStorage) GetNextFailedEventID(ctx sdk.Context) (int64, sdk.Error) {
+	store := ctx.KVStore(gs.key)
+	bz := store.Get(GetNextFailedEventIDKey())
+	if bz == nil {
+		return 1, nil
+	}
+	nextID := new(NextFailedEventID)
+	if err := gs.cdc.UnmarshalBinaryLengthPrefixed(bz, nextID); err != nil {
+		return 0, ErrFailedToUnmarshalFailedEvent(err)
+	}
+	return nextID.NextFailedEventID, nil
+}
+
+// SetNextFailedEventID - set id for the next failed event
+func (gs GlobalStorage) SetNextFailedEventID(ctx sdk.Context, id int64) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	bz, err := gs.cdc.MarshalBinaryLengthPrefixed(NextFailedEventID{NextFailedEventID: id})
+	if err != nil {
+		return ErrFailedToMarshalFailedEvent(err)
+	}
+	store.Set(GetNextFailedEventIDKey(), bz)
+	return nil
+}
+
+// GetCommunityTreasury - get community treasury, empty treasury if not set
+func (gs GlobalStorage) GetCommunityTreasury(ctx sdk.Context) (*CommunityTreasury, sdk.Error) {
+	store := ctx.KVStore(gs.key)
+	bz := store.Get(GetCommunityTreasuryKey())
+	if bz == nil {
+		return &CommunityTreasury{Balance: types.NewCoinFromInt64(0)}, nil
+	}
+	treasury := new(CommunityTreasury)
+	if err := gs.cdc.UnmarshalBinaryLengthPrefixed(bz, treasury); err != nil {
+		return nil, ErrFailedToUnmarshalCommunityTreasury(err)
+	}
+	return treasury, nil
+}
+
+// SetCommunityTreasury - set community treasury to KVStore
+func (gs GlobalStorage) SetCommunityTreasury(ctx sdk.Context, treasury *CommunityTreasury) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	bz, err := gs.cdc.MarshalBinaryLengthPrefixed(*treasury)
+	if err != nil {
+		return ErrFailedToMarshalCommunityTreasury(err)
+	}
+	store.Set(GetCommunityTreasuryKey(), bz)
+	return nil
+}
+
+// GetSupplyOffset - get supply offset, zero offset if not set
+func (gs GlobalStorage) GetSupplyOffset(ctx sdk.Context) (*SupplyOffset, sdk.Error) {
+	store := ctx.KVStore(gs.key)
+	bz := store.Get(GetSupplyOffsetKey())
+	if bz == nil {
+		return &SupplyOffset{Amount: types.NewCoinFromInt64(0)}, nil
+	}
+	offset := new(SupplyOffset)
+	if err := gs.cdc.UnmarshalBinaryLengthPrefixed(bz, offset); err != nil {
+		return nil, ErrFailedToUnmarshalSupplyOffset(err)
+	}
+	return offset, nil
+}
+
+// SetSupplyOffset - set supply offset to KVStore
+func (gs GlobalStorage) SetSupplyOffset(ctx sdk.Context, offset *SupplyOffset) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	bz, err := gs.cdc.MarshalBinaryLengthPrefixed(*offset)
+	if err != nil {
+		return ErrFailedToMarshalSupplyOffset(err)
+	}
+	store.Set(GetSupplyOffsetKey(), bz)
 	return nil
 }
 
@@ -172,6 +391,21 @@ func (gs GlobalStorage) GetLinoStakeStat(ctx sdk.Context, day int64) (*LinoStake
 	return linoStakeStat, nil
 }
 
+// IterateLinoStakeStats - call fn on lino stake statistic of every day
+func (gs GlobalStorage) IterateLinoStakeStats(ctx sdk.Context, fn func(stat *LinoStakeStat)) sdk.Error {
+	store := ctx.KVStore(gs.key)
+	itr := sdk.KVStorePrefixIterator(store, linoStakeStatSubStore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		stat := new(LinoStakeStat)
+		if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), stat); err != nil {
+			return ErrFailedToUnmarshalLinoStakeStatistic(err)
+		}
+		fn(stat)
+	}
+	return nil
+}
+
 // GetGlobalMeta - get global meta from KVStore
 func (gs GlobalStorage) GetGlobalMeta(ctx sdk.Context) (*GlobalMeta, sdk.Error) {
 	store := ctx.KVStore(gs.key)
@@ -301,7 +535,7 @@ func (gs GlobalStorage) SetGlobalTime(ctx sdk.Context, globalTime *GlobalTime) s
 func (gs GlobalStorage) Export(ctx sdk.Context) *GlobalTables {
 	tables := &GlobalTables{}
 	store := ctx.KVStore(gs.key)
-	// export table.TimeEventLists
+	// export table.TimeEventLists, both legacy and indexed keys.
 	func() {
 		itr := sdk.KVStorePrefixIterator(store, timeEventListSubStore)
 		defer itr.Close()
@@ -312,8 +546,8 @@ func (gs GlobalStorage) Export(ctx sdk.Context) *GlobalTables {
 			if err != nil {
 				panic("failed to parse int: " + err.Error())
 			}
-			eventlist, err := gs.GetTimeEventList(ctx, unixTime)
-			if err != nil {
+			eventlist := new(types.TimeEventList)
+			if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), eventlist); err != nil {
 				panic("failed to read eventlist: " + err.Error())
 			}
 			row := GlobalTimeEventTimeRow{
@@ -323,6 +557,21 @@ func (gs GlobalStorage) Export(ctx sdk.Context) *GlobalTables {
 			tables.GlobalTimeEventLists = append(tables.GlobalTimeEventLists, row)
 		}
 	}()
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, timeEventIndexSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			eventlist := new(types.TimeEventList)
+			if err := gs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), eventlist); err != nil {
+				panic("failed to read eventlist: " + err.Error())
+			}
+			row := GlobalTimeEventTimeRow{
+				UnixTime:      getUnixTimeFromTimeEventListKey(itr.Key()),
+				TimeEventList: *eventlist,
+			}
+			tables.GlobalTimeEventLists = append(tables.GlobalTimeEventLists, row)
+		}
+	}()
 	// export tables.StakeStats
 	func() {
 		itr := sdk.KVStorePrefixIterator(store, linoStakeStatSubStore)
@@ -345,6 +594,17 @@ func (gs GlobalStorage) Export(ctx sdk.Context) *GlobalTables {
 			tables.GlobalStakeStats = append(tables.GlobalStakeStats, row)
 		}
 	}()
+	// export tables.FailedEvents
+	failedEvents, err := gs.GetFailedEvents(ctx)
+	if err != nil {
+		panic("failed to read failed events: " + err.Error())
+	}
+	for _, v := range failedEvents {
+		tables.GlobalFailedEvents = append(tables.GlobalFailedEvents, GlobalFailedEventRow{
+			ID:          v.ID,
+			FailedEvent: v,
+		})
+	}
 	// global miscs
 	meta, err := gs.GetGlobalMeta(ctx)
 	if err != nil {
@@ -366,12 +626,22 @@ func (gs GlobalStorage) Export(ctx sdk.Context) *GlobalTables {
 	if err != nil {
 		panic("failed to get global time")
 	}
+	treasury, err := gs.GetCommunityTreasury(ctx)
+	if err != nil {
+		panic("failed to get community treasury")
+	}
+	offset, err := gs.GetSupplyOffset(ctx)
+	if err != nil {
+		panic("failed to get supply offset")
+	}
 	misc := GlobalMisc{
-		Meta:            *meta,
-		InflationPool:   *pool,
-		ConsumptionMeta: *consumptionMeta,
-		TPS:             *tps,
-		Time:            *time,
+		Meta:              *meta,
+		InflationPool:     *pool,
+		ConsumptionMeta:   *consumptionMeta,
+		TPS:               *tps,
+		Time:              *time,
+		CommunityTreasury: *treasury,
+		SupplyOffset:      *offset,
 	}
 	tables.GlobalMisc = misc
 	return tables
@@ -394,6 +664,16 @@ func (gs GlobalStorage) Import(ctx sdk.Context, tb *GlobalTablesIR) {
 		err := gs.SetLinoStakeStat(ctx, v.Day, &v.StakeStat)
 		check(err)
 	}
+	// import table.GlobalFailedEvents
+	nextFailedEventID := int64(1)
+	for _, v := range tb.GlobalFailedEvents {
+		err := gs.SetFailedEvent(ctx, &v.FailedEvent)
+		check(err)
+		if v.ID >= nextFailedEventID {
+			nextFailedEventID = v.ID + 1
+		}
+	}
+	check(gs.SetNextFailedEventID(ctx, nextFailedEventID))
 	// import table.Misc
 	misc := tb.GlobalMisc
 
@@ -403,6 +683,18 @@ func (gs GlobalStorage) Import(ctx sdk.Context, tb *GlobalTablesIR) {
 	err = gs.SetGlobalTime(ctx, &misc.Time)
 	check(err)
 
+	// community treasury is absent in states exported before it's introduced.
+	if misc.CommunityTreasury != nil {
+		err = gs.SetCommunityTreasury(ctx, misc.CommunityTreasury)
+		check(err)
+	}
+
+	// supply offset is absent in states exported before it's introduced.
+	if misc.SupplyOffset != nil {
+		err = gs.SetSupplyOffset(ctx, misc.SupplyOffset)
+		check(err)
+	}
+
 	// type diff in IR
 	var cwindow types.MiniDollar
 	if misc.ConsumptionMeta.IsConsumptionWindowDollarUnit {
@@ -431,11 +723,53 @@ func GetLinoStakeStatKey(day int64) []byte {
 	return append(linoStakeStatSubStore, strconv.FormatInt(day, 10)...)
 }
 
-// GetTimeEventListKey - get time event list from KVStore
+// GetTimeEventListKey - "time event index substore" + "big-endian unix time"
+// unix time is never negative, so byte order is the same as time order.
 func GetTimeEventListKey(unixTime int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(unixTime))
+	return append(timeEventIndexSubStore, bz...)
+}
+
+// GetLegacyTimeEventListKey - "time event list substore" + "decimal unix time",
+// used before BlockchainUpgrade1Update5Height.
+func GetLegacyTimeEventListKey(unixTime int64) []byte {
 	return append(timeEventListSubStore, strconv.FormatInt(unixTime, 10)...)
 }
 
+func getTimeEventListKeyAtHeight(height int64, unixTime int64) []byte {
+	if height < types.BlockchainUpgrade1Update5Height {
+		return GetLegacyTimeEventListKey(unixTime)
+	}
+	return GetTimeEventListKey(unixTime)
+}
+
+func getUnixTimeFromTimeEventListKey(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(timeEventIndexSubStore):]))
+}
+
+// GetFailedEventKey - "failed event substore" + "big-endian id"
+func GetFailedEventKey(id int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id))
+	return append(failedEventSubStore, bz...)
+}
+
+// GetNextFailedEventIDKey - "next failed event id substore"
+func GetNextFailedEventIDKey() []byte {
+	return nextFailedEventIDStore
+}
+
+// GetCommunityTreasuryKey - "community treasury substore"
+func GetCommunityTreasuryKey() []byte {
+	return communityTreasuryStore
+}
+
+// GetSupplyOffsetKey - "supply offset substore"
+func GetSupplyOffsetKey() []byte {
+	return supplyOffsetStore
+}
+
 // GetGlobalMetaKey - "global meta substore"
 func GetGlobalMetaKey() []byte {
 	return globalMetaSubStore
@@ -74,3 +74,37 @@ func TestGlobalStorageGenesis(t *testing.T) {
 	}
 	checkGlobalStorage(t, ctx, gm, globalMeta, consumptionMeta, inflationPool)
 }
+
+func TestCommunityTreasury(t *testing.T) {
+	gm := NewGlobalStorage(TestGlobalKVStoreKey)
+	ctx := getContext()
+
+	treasury, err := gm.GetCommunityTreasury(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, CommunityTreasury{Balance: types.NewCoinFromInt64(0)}, *treasury)
+
+	treasury.Balance = types.NewCoinFromInt64(100 * types.Decimals)
+	err = gm.SetCommunityTreasury(ctx, treasury)
+	assert.Nil(t, err)
+
+	treasury, err = gm.GetCommunityTreasury(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, CommunityTreasury{Balance: types.NewCoinFromInt64(100 * types.Decimals)}, *treasury)
+}
+
+func TestSupplyOffset(t *testing.T) {
+	gm := NewGlobalStorage(TestGlobalKVStoreKey)
+	ctx := getContext()
+
+	offset, err := gm.GetSupplyOffset(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, SupplyOffset{Amount: types.NewCoinFromInt64(0)}, *offset)
+
+	offset.Amount = types.NewCoinFromInt64(100 * types.Decimals)
+	err = gm.SetSupplyOffset(ctx, offset)
+	assert.Nil(t, err)
+
+	offset, err = gm.GetSupplyOffset(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, SupplyOffset{Amount: types.NewCoinFromInt64(100 * types.Decimals)}, *offset)
+}
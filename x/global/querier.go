@@ -23,6 +23,20 @@ const (
 	QueryTPS             = "tps"
 	QueryLinoStakeStat   = "linoStakeStat"
 	QueryGlobalTime      = "globalTime"
+
+	QueryUpcomingTimeEvents = "upcomingTimeEvents"
+	QueryFailedEvents       = "failedEvents"
+	QueryFailedEvent        = "failedEvent"
+	QueryCommunityTreasury  = "communityTreasury"
+
+	// AllEventTypes - matches all event types in upcoming time events query
+	AllEventTypes = "all"
+
+	// maxTimeEventQueryRangeSec - upcoming time events query can cover at most one year
+	maxTimeEventQueryRangeSec = 3600 * 24 * 366
+	// maxLegacyTimeEventQueryRangeSec - before time events are indexed, every second in the
+	// range is looked up, so the query can cover at most six hours.
+	maxLegacyTimeEventQueryRangeSec = 3600 * 6
 )
 
 // creates a querier for global REST endpoints
@@ -43,6 +57,14 @@ func NewQuerier(gm GlobalManager) sdk.Querier {
 			return queryGlobalTime(ctx, cdc, path[1:], req, gm)
 		case QueryLinoStakeStat:
 			return queryLinoStakeStat(ctx, cdc, path[1:], req, gm)
+		case QueryUpcomingTimeEvents:
+			return queryUpcomingTimeEvents(ctx, path[1:], req, gm)
+		case QueryFailedEvents:
+			return queryFailedEvents(ctx, path[1:], req, gm)
+		case QueryFailedEvent:
+			return queryFailedEvent(ctx, path[1:], req, gm)
+		case QueryCommunityTreasury:
+			return queryCommunityTreasury(ctx, cdc, path[1:], req, gm)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown global query endpoint")
 		}
@@ -80,6 +102,18 @@ func queryGlobalMeta(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.R
 	return res, nil
 }
 
+func queryCommunityTreasury(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, gm GlobalManager) ([]byte, sdk.Error) {
+	treasury, err := gm.storage.GetCommunityTreasury(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(treasury)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
 func queryInflationPool(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, gm GlobalManager) ([]byte, sdk.Error) {
 	inflationPool, err := gm.storage.GetInflationPool(ctx)
 	if err != nil {
@@ -146,3 +180,74 @@ func queryLinoStakeStat(ctx sdk.Context, cdc *wire.Codec, path []string, req abc
 	}
 	return res, nil
 }
+
+// queryUpcomingTimeEvents - path: startTime/endTime[/eventType[/username]]
+func queryUpcomingTimeEvents(ctx sdk.Context, path []string, req abci.RequestQuery, gm GlobalManager) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 2); err != nil {
+		return nil, err
+	}
+	startTime, convertErr := strconv.ParseInt(path[0], 10, 64)
+	if convertErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	endTime, convertErr := strconv.ParseInt(path[1], 10, 64)
+	if convertErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	maxRange := int64(maxTimeEventQueryRangeSec)
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update5Height {
+		maxRange = maxLegacyTimeEventQueryRangeSec
+	}
+	if startTime < 0 || endTime < startTime || endTime-startTime > maxRange {
+		return nil, ErrInvalidTimeEventQueryRange(startTime, endTime)
+	}
+	eventType := ""
+	if len(path) > 2 && path[2] != AllEventTypes {
+		eventType = path[2]
+	}
+	username := types.AccountKey("")
+	if len(path) > 3 {
+		username = types.AccountKey(path[3])
+	}
+	events, err := gm.GetUpcomingTimeEvents(ctx, startTime, endTime, eventType, username)
+	if err != nil {
+		return nil, err
+	}
+	// events are interfaces, only registered in global manager's codec.
+	res, marshalErr := gm.WireCodec().MarshalJSON(events)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryFailedEvents(ctx sdk.Context, path []string, req abci.RequestQuery, gm GlobalManager) ([]byte, sdk.Error) {
+	failedEvents, err := gm.GetFailedEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := gm.WireCodec().MarshalJSON(failedEvents)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryFailedEvent(ctx sdk.Context, path []string, req abci.RequestQuery, gm GlobalManager) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	id, convertErr := strconv.ParseInt(path[0], 10, 64)
+	if convertErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	failedEvent, err := gm.GetFailedEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := gm.WireCodec().MarshalJSON(failedEvent)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
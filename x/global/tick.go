@@ -16,6 +16,12 @@ func BeginBlocker(
 	if err := gm.ClearEventCache(ctx); err != nil {
 		panic(err)
 	}
+	// one time migration for upgrade1update5, must happen before time events are executed.
+	if ctx.BlockHeight() == types.BlockchainUpgrade1Update5Height {
+		if err := gm.MigrateTimeEventLists(ctx); err != nil {
+			panic(err)
+		}
+	}
 	return
 }
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/lino-network/lino/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -28,5 +30,5 @@ func handleProviderReportMsg(ctx sdk.Context, im InfraManager, msg ProviderRepor
 	if err := im.ReportUsage(ctx, msg.Username, msg.Usage); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
 }
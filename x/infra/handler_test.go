@@ -24,7 +24,7 @@ func TestReportBasic(t *testing.T) {
 
 	msg2 := NewProviderReportMsg("user1", usage)
 	res2 := handler(ctx, msg2)
-	assert.Equal(t, sdk.Result{}, res2)
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, res2)
 
 	provider, _ := im.storage.GetInfraProvider(ctx, user1)
 	assert.Equal(t, usage, provider.Usage)
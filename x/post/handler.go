@@ -43,7 +43,7 @@ func handleCreatePostMsg(ctx sdk.Context, msg CreatePostMsg, pm PostKeeper) sdk.
 	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: postTags(msg.Author, msg.PostID, msg.CreatedBy)}
 }
 
 func handleUpdatePostMsg(ctx sdk.Context, msg UpdatePostMsg, pm PostKeeper) sdk.Result {
@@ -51,7 +51,7 @@ func handleUpdatePostMsg(ctx sdk.Context, msg UpdatePostMsg, pm PostKeeper) sdk.
 	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: postTags(msg.Author, msg.PostID, "")}
 }
 
 func handleDeletePostMsg(ctx sdk.Context, msg DeletePostMsg, pm PostKeeper) sdk.Result {
@@ -59,7 +59,7 @@ func handleDeletePostMsg(ctx sdk.Context, msg DeletePostMsg, pm PostKeeper) sdk.
 	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: postTags(msg.Author, msg.PostID, "")}
 }
 
 // Handle DonateMsg
@@ -72,7 +72,8 @@ func handleDonateMsg(ctx sdk.Context, msg DonateMsg, pm PostKeeper) sdk.Result {
 	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: postTags(msg.Author, msg.PostID, msg.FromApp).AppendTags(
+		linotypes.TransferTags(msg.Username, msg.Author, amount, linotypes.DonationOut))}
 }
 
 func handleIDADonateMsg(ctx sdk.Context, msg IDADonateMsg, pm PostKeeper) sdk.Result {
@@ -85,5 +86,18 @@ func handleIDADonateMsg(ctx sdk.Context, msg IDADonateMsg, pm PostKeeper) sdk.Re
 	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: postTags(msg.Author, msg.PostID, msg.App).AppendTags(
+		sdk.NewTags(linotypes.TagSender, string(msg.Username), linotypes.TagReceiver, string(msg.Author)))}
+}
+
+// tags of post, app is omitted if empty
+func postTags(author linotypes.AccountKey, postID string, app linotypes.AccountKey) sdk.Tags {
+	tags := sdk.NewTags(
+		linotypes.TagAuthor, string(author),
+		linotypes.TagPermlink, string(linotypes.GetPermlink(author, postID)),
+	)
+	if app != "" {
+		tags = tags.AppendTag(linotypes.TagApp, string(app))
+	}
+	return tags
 }
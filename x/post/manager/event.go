@@ -18,6 +18,30 @@ type RewardEvent struct {
 	FromApp    linotypes.AccountKey `json:"from_app"`
 }
 
+// EventType - implements linotypes.InspectableEvent
+func (event RewardEvent) EventType() string {
+	return linotypes.RewardEventType
+}
+
+// InvolvedAccounts - implements linotypes.InspectableEvent
+func (event RewardEvent) InvolvedAccounts() []linotypes.AccountKey {
+	return []linotypes.AccountKey{event.PostAuthor, event.Consumer}
+}
+
+// Tags - implements linotypes.TaggedEvent
+func (event RewardEvent) Tags() sdk.Tags {
+	tags := sdk.NewTags(
+		linotypes.TagEventType, linotypes.RewardEventType,
+		linotypes.TagSender, string(event.Consumer),
+		linotypes.TagAuthor, string(event.PostAuthor),
+		linotypes.TagPermlink, string(linotypes.GetPermlink(event.PostAuthor, event.PostID)),
+	)
+	if event.FromApp != "" {
+		tags = tags.AppendTag(linotypes.TagApp, string(event.FromApp))
+	}
+	return tags
+}
+
 // Execute - execute reward event after 7 days
 func (event RewardEvent) Execute(ctx sdk.Context, pm PostManager) sdk.Error {
 	// check if post is deleted, Note that if post is deleted, it's ok to just
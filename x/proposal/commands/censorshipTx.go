@@ -0,0 +1,50 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ContentCensorshipProposalTxCmd will create a content censorship proposal tx and sign it with the given key
+func ContentCensorshipProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-censorship",
+		Short: "propose to delete a post by its permlink",
+		RunE:  sendContentCensorshipProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().String(client.FlagPermlink, "", "permlink of the target post")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendContentCensorshipProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		permlink := types.Permlink(viper.GetString(client.FlagPermlink))
+		reason := viper.GetString(client.FlagReason)
+
+		// create the message
+		msg := proposal.NewDeletePostContentMsg(creator, permlink, reason)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package vote
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/param"
+	"github.com/lino-network/lino/x/proposal"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChangeParamProposalTxCmd will create a change param proposal tx and sign it with the given key
+func ChangeParamProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-change-param",
+		Short: "propose to change parameters to the ones in a JSON file",
+		RunE:  sendChangeParamProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().String(client.FlagParamType, "",
+		"global-allocation, infra-allocation, vote, proposal, developer, "+
+			"validator, bandwidth, account, post or reputation")
+	cmd.Flags().String(client.FlagParamFile, "", "JSON file of the new parameter")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendChangeParamProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		reason := viper.GetString(client.FlagReason)
+
+		bz, err := ioutil.ReadFile(viper.GetString(client.FlagParamFile))
+		if err != nil {
+			return err
+		}
+
+		// create the message
+		msg, err := newChangeParamMsg(
+			cdc, creator, viper.GetString(client.FlagParamType), bz, reason)
+		if err != nil {
+			return err
+		}
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
+
+// newChangeParamMsg - decode parameter of @p paramType from JSON and wrap it into msg
+func newChangeParamMsg(
+	cdc *wire.Codec, creator, paramType string, bz []byte, reason string) (sdk.Msg, error) {
+	switch paramType {
+	case "global-allocation":
+		var p param.GlobalAllocationParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeGlobalAllocationParamMsg(creator, p, reason), nil
+	case "infra-allocation":
+		var p param.InfraInternalAllocationParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeInfraInternalAllocationParamMsg(creator, p, reason), nil
+	case "vote":
+		var p param.VoteParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeVoteParamMsg(creator, p, reason), nil
+	case "proposal":
+		var p param.ProposalParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeProposalParamMsg(creator, p, reason), nil
+	case "developer":
+		var p param.DeveloperParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeDeveloperParamMsg(creator, p, reason), nil
+	case "validator":
+		var p param.ValidatorParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeValidatorParamMsg(creator, p, reason), nil
+	case "bandwidth":
+		var p param.BandwidthParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeBandwidthParamMsg(creator, p, reason), nil
+	case "account":
+		var p param.AccountParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeAccountParamMsg(creator, p, reason), nil
+	case "post":
+		var p param.PostParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangePostParamMsg(creator, p, reason), nil
+	case "reputation":
+		var p param.ReputationParam
+		if err := cdc.UnmarshalJSON(bz, &p); err != nil {
+			return nil, err
+		}
+		return proposal.NewChangeReputationParamMsg(creator, p, reason), nil
+	default:
+		return nil, errors.Errorf("unknown param type: %s", paramType)
+	}
+}
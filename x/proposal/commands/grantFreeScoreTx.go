@@ -0,0 +1,62 @@
+package vote
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/proposal"
+	"github.com/lino-network/lino/x/proposal/model"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GrantFreeScoreProposalTxCmd will create a grant free score proposal tx and sign it with the given key
+func GrantFreeScoreProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-grant-free-score",
+		Short: "propose to grant or revoke free reputation score of accounts in a JSON file",
+		RunE:  sendGrantFreeScoreProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().String(client.FlagGrantFile, "",
+		"JSON file of the grants, e.g. [{\"username\":\"alice\",\"score\":\"100\"}]")
+	cmd.Flags().Bool(client.FlagRevoke, false, "revoke the free score instead of granting it")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendGrantFreeScoreProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		revoke := viper.GetBool(client.FlagRevoke)
+		reason := viper.GetString(client.FlagReason)
+
+		bz, err := ioutil.ReadFile(viper.GetString(client.FlagGrantFile))
+		if err != nil {
+			return err
+		}
+		var grants []model.FreeScoreGrant
+		if err := cdc.UnmarshalJSON(bz, &grants); err != nil {
+			return err
+		}
+
+		// create the message
+		msg := proposal.NewGrantFreeScoreMsg(creator, grants, revoke, reason)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
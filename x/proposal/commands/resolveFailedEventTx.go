@@ -0,0 +1,51 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/proposal"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ResolveFailedEventProposalTxCmd will create a resolve failed event proposal tx and sign it with the given key
+func ResolveFailedEventProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-resolve-failed-event",
+		Short: "propose to retry or discard a failed event",
+		RunE:  sendResolveFailedEventProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().Int64(client.FlagFailedEventID, 0, "id of the failed event")
+	cmd.Flags().Bool(client.FlagRetry, false, "retry the failed event instead of discarding it")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendResolveFailedEventProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		failedEventID := viper.GetInt64(client.FlagFailedEventID)
+		retry := viper.GetBool(client.FlagRetry)
+		reason := viper.GetString(client.FlagReason)
+
+		// create the message
+		msg := proposal.NewResolveFailedEventMsg(creator, failedEventID, retry, reason)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
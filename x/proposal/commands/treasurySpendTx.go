@@ -0,0 +1,52 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TreasurySpendProposalTxCmd will create a treasury spend proposal tx and sign it with the given key
+func TreasurySpendProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-treasury-spend",
+		Short: "create a proposal to spend coins from community treasury",
+		RunE:  sendTreasurySpendProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().String(client.FlagReceiver, "", "recipient of the coins")
+	cmd.Flags().String(client.FlagAmount, "", "amount of LNO to spend")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendTreasurySpendProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		recipient := viper.GetString(client.FlagReceiver)
+		amount := types.LNO(viper.GetString(client.FlagAmount))
+		reason := viper.GetString(client.FlagReason)
+
+		// create the message
+		msg := proposal.NewTreasurySpendMsg(creator, recipient, amount, reason)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
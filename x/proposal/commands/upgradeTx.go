@@ -0,0 +1,53 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/proposal"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UpgradeProtocolProposalTxCmd will create a protocol upgrade proposal tx and sign it with the given key
+func UpgradeProtocolProposalTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-upgrade",
+		Short: "propose to upgrade protocol, upgrade with name is scheduled at upgrade height",
+		RunE:  sendUpgradeProtocolProposalTx(cdc),
+	}
+	cmd.Flags().String(client.FlagCreator, "", "creator of the proposal")
+	cmd.Flags().String(client.FlagLink, "", "link to the upgrade")
+	cmd.Flags().String(client.FlagUpgradeName, "", "name of upgrade handler in new binary")
+	cmd.Flags().Int64(client.FlagUpgradeHeight, 0, "block height to halt for the upgrade")
+	cmd.Flags().String(client.FlagReason, "", "reason of the proposal")
+	return cmd
+}
+
+func sendUpgradeProtocolProposalTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		creator := viper.GetString(client.FlagCreator)
+		link := viper.GetString(client.FlagLink)
+		name := viper.GetString(client.FlagUpgradeName)
+		height := viper.GetInt64(client.FlagUpgradeHeight)
+		reason := viper.GetString(client.FlagReason)
+
+		// create the message
+		msg := proposal.NewUpgradeProtocolMsg(creator, link, name, height, reason)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
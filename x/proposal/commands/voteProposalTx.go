@@ -2,11 +2,13 @@ package vote
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/proposal"
 
 	wire "github.com/cosmos/cosmos-sdk/codec"
@@ -14,6 +16,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+var voteOptions = map[string]types.VoteOption{
+	"yes":     types.VoteOptionYes,
+	"no":      types.VoteOptionNo,
+	"abstain": types.VoteOptionAbstain,
+	"veto":    types.VoteOptionVeto,
+}
+
 // VoteProposalTxCmd will create a voteProposal tx and sign it with the given key
 func VoteProposalTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -24,6 +33,7 @@ func VoteProposalTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagVoter, "", "voter for the proposal")
 	cmd.Flags().Int64(client.FlagProposalID, -1, "proposal id")
 	cmd.Flags().Bool(client.FlagResult, true, "vote result")
+	cmd.Flags().String(client.FlagVoteOption, "", "vote option: yes, no, abstain or veto, overrides result")
 	return cmd
 }
 
@@ -36,6 +46,13 @@ func sendVoteProposalTx(cdc *wire.Codec) client.CommandTxCallback {
 
 		// create the message
 		msg := proposal.NewVoteProposalMsg(voter, id, result)
+		if option := viper.GetString(client.FlagVoteOption); option != "" {
+			voteOption, ok := voteOptions[strings.ToLower(option)]
+			if !ok {
+				return fmt.Errorf("unknown vote option: %s", option)
+			}
+			msg = proposal.NewVoteProposalOptionMsg(voter, id, voteOption)
+		}
 
 		// build and sign the transaction, then broadcast to Tendermint
 		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
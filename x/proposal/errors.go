@@ -76,3 +76,48 @@ func ErrIllegalParameter() sdk.Error {
 func ErrQueryFailed() sdk.Error {
 	return types.NewError(types.CodeProposalQueryFailed, fmt.Sprintf("query proposal store failed"))
 }
+
+// ErrInvalidFailedEventID - error if failed event id is invalid
+func ErrInvalidFailedEventID() sdk.Error {
+	return types.NewError(types.CodeInvalidFailedEventID, fmt.Sprintf("invalid failed event id"))
+}
+
+// ErrFailedEventNotFound - error if failed event is not found
+func ErrFailedEventNotFound() sdk.Error {
+	return types.NewError(types.CodeFailedEventNotFound, fmt.Sprintf("failed event is not found"))
+}
+
+// ErrInvalidFreeScoreGrant - error if free score grant list is invalid
+func ErrInvalidFreeScoreGrant() sdk.Error {
+	return types.NewError(types.CodeInvalidFreeScoreGrant, fmt.Sprintf("invalid free score grant"))
+}
+
+// ErrInvalidProposalTitle - error if text proposal title is empty or too long
+func ErrInvalidProposalTitle() sdk.Error {
+	return types.NewError(types.CodeInvalidProposalTitle, fmt.Sprintf("invalid proposal title"))
+}
+
+// ErrProposalDescriptionTooLong - error if text proposal description is too long
+func ErrProposalDescriptionTooLong() sdk.Error {
+	return types.NewError(types.CodeProposalDescriptionTooLong, fmt.Sprintf("proposal description is too long"))
+}
+
+// ErrInvalidUpgradeName - error if protocol upgrade name is invalid
+func ErrInvalidUpgradeName() sdk.Error {
+	return types.NewError(types.CodeInvalidUpgradeName, fmt.Sprintf("invalid upgrade name"))
+}
+
+// ErrInvalidVoteOption - error if vote option is invalid
+func ErrInvalidVoteOption() sdk.Error {
+	return types.NewError(types.CodeInvalidVoteOption, fmt.Sprintf("invalid vote option"))
+}
+
+// ErrInvalidUpgradeHeight - error if protocol upgrade height is invalid
+func ErrInvalidUpgradeHeight() sdk.Error {
+	return types.NewError(types.CodeInvalidUpgradeHeight, fmt.Sprintf("invalid upgrade height"))
+}
+
+// ErrUpgradePlanExist - error if another upgrade is already scheduled
+func ErrUpgradePlanExist(name string) sdk.Error {
+	return types.NewError(types.CodeUpgradePlanExist, fmt.Sprintf("upgrade %s is already scheduled", name))
+}
@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	types "github.com/lino-network/lino/types"
 	acc "github.com/lino-network/lino/x/account"
+	rep "github.com/lino-network/lino/x/reputation"
 	val "github.com/lino-network/lino/x/validator"
 )
 
@@ -17,11 +18,29 @@ type DecideProposalEvent struct {
 	ProposalID   types.ProposalKey  `json:"proposal_id"`
 }
 
+// EventType - implements types.InspectableEvent
+func (dpe DecideProposalEvent) EventType() string {
+	return types.DecideProposalEventType
+}
+
+// InvolvedAccounts - implements types.InspectableEvent
+func (dpe DecideProposalEvent) InvolvedAccounts() []types.AccountKey {
+	return nil
+}
+
+// Tags - implements types.TaggedEvent
+func (dpe DecideProposalEvent) Tags() sdk.Tags {
+	return sdk.NewTags(
+		types.TagEventType, types.DecideProposalEventType,
+		types.TagProposalID, string(dpe.ProposalID),
+	)
+}
+
 // Execute - execute proposal event, check vote and update status
 func (dpe DecideProposalEvent) Execute(
 	ctx sdk.Context, voteManager vote.VoteManager, valManager val.ValidatorManager,
 	am acc.AccountManager, proposalManager ProposalManager, postManager post.PostKeeper,
-	gm *global.GlobalManager) sdk.Error {
+	rm rep.ReputationKeeper, gm *global.GlobalManager) sdk.Error {
 	// check it is ongoing proposal
 	if !proposalManager.IsOngoingProposal(ctx, dpe.ProposalID) {
 		return ErrOngoingProposalNotFound()
@@ -46,19 +65,35 @@ func (dpe DecideProposalEvent) Execute(
 		return err
 	}
 
-	// add coins back to inflation pool
-	if err := gm.AddToValidatorInflationPool(ctx, actualPenalty); err != nil {
+	// add coins back to inflation pool and community treasury
+	if err := gm.DistributePenalty(ctx, actualPenalty); err != nil {
 		return err
 	}
 
+	// quorum is relative to total stake since BlockchainUpgrade1Update8Height
+	totalStake := types.NewCoinFromInt64(0)
+	if ctx.BlockHeight() >= types.BlockchainUpgrade1Update8Height {
+		totalStake, err = gm.GetTotalLinoStake(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// update the ongoing and past proposal list
 	proposalRes, err := proposalManager.UpdateProposalPassStatus(
-		ctx, dpe.ProposalType, dpe.ProposalID)
+		ctx, dpe.ProposalType, dpe.ProposalID, totalStake)
 	if err != nil {
 		return err
 	}
-	// majority disagree this proposal
-	if proposalRes == types.ProposalNotPass {
+	// votes are tallied, snapshot of voting power is no longer needed
+	if err := voteManager.EndVotingPowerSnapshot(ctx, dpe.ProposalID); err != nil {
+		return err
+	}
+	if err := dpe.SettleDeposit(ctx, proposalRes, am, proposalManager, gm); err != nil {
+		return err
+	}
+	// majority disagree this proposal, or it is vetoed
+	if proposalRes != types.ProposalPass {
 		return nil
 	}
 
@@ -76,10 +111,42 @@ func (dpe DecideProposalEvent) Execute(
 		if err := dpe.ExecuteProtocolUpgrade(ctx, dpe.ProposalID, proposalManager); err != nil {
 			return err
 		}
+	case types.ResolveFailedEvent:
+		if err := dpe.ExecuteResolveFailedEvent(ctx, dpe.ProposalID, proposalManager, gm); err != nil {
+			return err
+		}
+	case types.GrantFreeScore:
+		if err := dpe.ExecuteGrantFreeScore(ctx, dpe.ProposalID, proposalManager, rm); err != nil {
+			return err
+		}
+	case types.TreasurySpend:
+		if err := dpe.ExecuteTreasurySpend(ctx, dpe.ProposalID, proposalManager, am, gm); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// SettleDeposit - return deposit held by proposal module to creator, or burn it
+// if the proposal is vetoed. Deposits of proposals created before
+// BlockchainUpgrade1Update8Height are returned by coin return events.
+func (dpe DecideProposalEvent) SettleDeposit(
+	ctx sdk.Context, proposalRes types.ProposalResult, am acc.AccountManager,
+	proposalManager ProposalManager, gm *global.GlobalManager) sdk.Error {
+	if !proposalManager.DoesProposalDepositExist(ctx, dpe.ProposalID) {
+		return nil
+	}
+	deposit, err := proposalManager.ReleaseProposalDeposit(ctx, dpe.ProposalID)
+	if err != nil {
+		return err
+	}
+	if proposalRes == types.ProposalVetoed {
+		return gm.BurnCoin(ctx, deposit.Amount)
+	}
+	return am.AddSavingCoin(
+		ctx, deposit.Creator, deposit.Amount, "", string(dpe.ProposalID), types.ProposalReturnCoin)
+}
+
 // ExecuteChangeParam - reigster parameter change event
 func (dpe DecideProposalEvent) ExecuteChangeParam(
 	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
@@ -113,8 +180,118 @@ func (dpe DecideProposalEvent) ExecuteContentCensorship(
 	return nil
 }
 
-// ExecuteProtocolUpgrade - since execute protocol upgrade engage code change, the process need to be done manually
+// ExecuteProtocolUpgrade - schedule the upgrade plan of proposal, chain halts at the
+// height of the plan unless the binary has a handler of the upgrade. Proposal without
+// name, with a height already passed or with an applied name is not scheduled. If
+// another upgrade is pending, the decide event fails and is recorded as failed event,
+// which can be retried by a resolve failed event proposal after the pending upgrade.
 func (dpe DecideProposalEvent) ExecuteProtocolUpgrade(
 	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager) sdk.Error {
+	plan, err := proposalManager.GetUpgradePlanOfProposal(ctx, curID)
+	if err != nil {
+		return err
+	}
+	if plan == nil || plan.Height <= ctx.BlockHeight() ||
+		proposalManager.IsUpgradeDone(ctx, plan.Name) {
+		return nil
+	}
+	return proposalManager.ScheduleUpgrade(ctx, plan)
+}
+
+// ExecuteResolveFailedEvent - retry or discard the failed event, a failed event
+// resolved by another proposal is ignored.
+func (dpe DecideProposalEvent) ExecuteResolveFailedEvent(
+	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
+	gm *global.GlobalManager) sdk.Error {
+	failedEventID, retry, err := proposalManager.GetFailedEventResolution(ctx, curID)
+	if err != nil {
+		return err
+	}
+	if !gm.DoesFailedEventExist(ctx, failedEventID) {
+		return nil
+	}
+	if retry {
+		return gm.RetryFailedEvent(ctx, failedEventID)
+	}
+	return gm.DiscardFailedEvent(ctx, failedEventID)
+}
+
+// ExecuteGrantFreeScore - grant or revoke free reputation score of accounts
+func (dpe DecideProposalEvent) ExecuteGrantFreeScore(
+	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
+	rm rep.ReputationKeeper) sdk.Error {
+	grants, revoke, err := proposalManager.GetFreeScoreGrants(ctx, curID)
+	if err != nil {
+		return err
+	}
+	for _, grant := range grants {
+		score := grant.Score
+		if revoke {
+			score = types.NewMiniDollarFromInt(score.Neg())
+		}
+		if err := rm.GrantFreeScore(ctx, grant.Username, score, curID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecuteTreasurySpend - transfer coins from community treasury to recipient. If
+// recipient no longer exists or community treasury is not enough, the spend is
+// recorded as failed event, which can be retried or discarded by a resolve failed
+// event proposal.
+func (dpe DecideProposalEvent) ExecuteTreasurySpend(
+	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
+	am acc.AccountManager, gm *global.GlobalManager) sdk.Error {
+	recipient, amount, err := proposalManager.GetTreasurySpend(ctx, curID)
+	if err != nil {
+		return err
+	}
+	event := TreasurySpendEvent{ProposalID: curID, Recipient: recipient, Amount: amount}
+	if err := event.Execute(ctx, am, gm); err != nil {
+		return gm.RecordFailedEvent(ctx, event, ctx.BlockHeader().Time.Unix(), err)
+	}
 	return nil
 }
+
+// TreasurySpendEvent - transfer coins from community treasury to recipient of
+// a passed treasury spend proposal
+type TreasurySpendEvent struct {
+	ProposalID types.ProposalKey `json:"proposal_id"`
+	Recipient  types.AccountKey  `json:"recipient"`
+	Amount     types.Coin        `json:"amount"`
+}
+
+// EventType - implements types.InspectableEvent
+func (tse TreasurySpendEvent) EventType() string {
+	return types.TreasurySpendEventType
+}
+
+// InvolvedAccounts - implements types.InspectableEvent
+func (tse TreasurySpendEvent) InvolvedAccounts() []types.AccountKey {
+	return []types.AccountKey{tse.Recipient}
+}
+
+// Tags - implements types.TaggedEvent
+func (tse TreasurySpendEvent) Tags() sdk.Tags {
+	return sdk.NewTags(
+		types.TagEventType, types.TreasurySpendEventType,
+		types.TagProposalID, string(tse.ProposalID),
+		types.TagReceiver, string(tse.Recipient),
+		types.TagAmount, types.CoinTagValue(tse.Amount),
+	)
+}
+
+// Execute - spend coins from community treasury, no state is changed if recipient
+// doesn't exist or community treasury is not enough.
+func (tse TreasurySpendEvent) Execute(
+	ctx sdk.Context, am acc.AccountManager, gm *global.GlobalManager) sdk.Error {
+	if !am.DoesAccountExist(ctx, tse.Recipient) {
+		return ErrAccountNotFound()
+	}
+	if err := gm.SpendFromCommunityTreasury(ctx, tse.Amount); err != nil {
+		return err
+	}
+	return am.AddSavingCoin(
+		ctx, tse.Recipient, tse.Amount, "", string(tse.ProposalID), types.TreasuryCoin)
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	acc "github.com/lino-network/lino/x/account"
 	"github.com/lino-network/lino/x/proposal/model"
+	repmocks "github.com/lino-network/lino/x/reputation/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -182,7 +184,7 @@ func TestDecideProposal(t *testing.T) {
 
 	for _, cs := range cases {
 		if cs.decideProposal {
-			err := cs.event.Execute(ctx, voteManager, valManager, am, pm, postManager, &gm)
+			err := cs.event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
 			assert.Nil(t, err)
 			proposal, _ := pm.storage.GetExpiredProposal(ctx, cs.proposalID)
 			proposalInfo := proposal.GetProposalInfo()
@@ -192,9 +194,13 @@ func TestDecideProposal(t *testing.T) {
 			assert.Equal(t, cs.expectDisagreeVotes, proposalInfo.DisagreeVotes)
 
 		} else {
-			voteManager.AddVote(ctx, cs.proposalID, cs.voter, cs.voterRes)
+			option := types.VoteOptionNo
+			if cs.voterRes {
+				option = types.VoteOptionYes
+			}
+			voteManager.AddVote(ctx, cs.proposalID, cs.voter, option)
 
-			err := pm.UpdateProposalVotingStatus(ctx, cs.proposalID, cs.voter, cs.voterRes, cs.votingPower)
+			err := pm.UpdateProposalVotingStatus(ctx, cs.proposalID, cs.voter, option, cs.votingPower, nil)
 			assert.Nil(t, err)
 		}
 
@@ -217,3 +223,313 @@ func TestDecideProposal(t *testing.T) {
 		assert.Equal(t, expectExpiredProposalList, expiredList)
 	}
 }
+
+func TestDecideResolveFailedEventProposal(t *testing.T) {
+	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, 0)
+	voteManager.InitGenesis(ctx)
+	valManager.InitGenesis(ctx)
+	pm.InitGenesis(ctx)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	passVotes := proposalParam.ChangeParamPassVotes.Plus(types.NewCoinFromInt64(1))
+	zero := types.NewCoinFromInt64(0)
+	curTime := ctx.BlockHeader().Time.Unix()
+
+	events := []acc.ReturnCoinEvent{}
+	for i := int64(1); i <= 3; i++ {
+		event := acc.ReturnCoinEvent{Username: "user1", Amount: types.NewCoinFromInt64(i)}
+		assert.Nil(t, gm.RecordFailedEvent(ctx, event, curTime-i, ErrAccountNotFound()))
+		events = append(events, event)
+	}
+
+	retryID, _ := pm.AddProposal(
+		ctx, "c1", pm.CreateResolveFailedEventProposal(ctx, 1, true, ""), 10)
+	resolvedID, _ := pm.AddProposal(
+		ctx, "c2", pm.CreateResolveFailedEventProposal(ctx, 1, false, ""), 10)
+	discardID, _ := pm.AddProposal(
+		ctx, "c3", pm.CreateResolveFailedEventProposal(ctx, 2, false, ""), 10)
+	notPassID, _ := pm.AddProposal(
+		ctx, "c4", pm.CreateResolveFailedEventProposal(ctx, 3, false, ""), 10)
+	for _, id := range []types.ProposalKey{retryID, resolvedID, discardID} {
+		assert.Nil(t, addProposalInfo(ctx, pm, id, passVotes, zero))
+	}
+	assert.Nil(t, addProposalInfo(ctx, pm, notPassID, zero, passVotes))
+
+	testCases := []struct {
+		testName         string
+		proposalID       types.ProposalKey
+		wantFailedEvents []int64
+	}{
+		{
+			testName:         "retry failed event",
+			proposalID:       retryID,
+			wantFailedEvents: []int64{2, 3},
+		},
+		{
+			testName:         "failed event resolved by another proposal is ignored",
+			proposalID:       resolvedID,
+			wantFailedEvents: []int64{2, 3},
+		},
+		{
+			testName:         "discard failed event",
+			proposalID:       discardID,
+			wantFailedEvents: []int64{3},
+		},
+		{
+			testName:         "failed event is kept if proposal doesn't pass",
+			proposalID:       notPassID,
+			wantFailedEvents: []int64{3},
+		},
+	}
+	for _, tc := range testCases {
+		event := DecideProposalEvent{ProposalType: types.ResolveFailedEvent, ProposalID: tc.proposalID}
+		err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+		assert.Nil(t, err, tc.testName)
+		failedEvents, err := gm.GetFailedEvents(ctx)
+		assert.Nil(t, err, tc.testName)
+		ids := []int64{}
+		for _, failedEvent := range failedEvents {
+			ids = append(ids, failedEvent.ID)
+		}
+		assert.Equal(t, tc.wantFailedEvents, ids, tc.testName)
+	}
+
+	// only the retried event is rescheduled at current time.
+	assert.Nil(t, gm.CommitEventCache(ctx))
+	assert.Equal(t, &types.TimeEventList{Events: []types.Event{events[0]}},
+		gm.GetTimeEventListAtTime(ctx, curTime))
+}
+
+func TestDecideGrantFreeScoreProposal(t *testing.T) {
+	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, 0)
+	voteManager.InitGenesis(ctx)
+	valManager.InitGenesis(ctx)
+	pm.InitGenesis(ctx)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	passVotes := proposalParam.ChangeParamPassVotes.Plus(types.NewCoinFromInt64(1))
+
+	grants := []model.FreeScoreGrant{
+		{Username: "user1", Score: types.NewMiniDollar(100)},
+		{Username: "user2", Score: types.NewMiniDollar(200)},
+	}
+	grantID, _ := pm.AddProposal(
+		ctx, "c1", pm.CreateGrantFreeScoreProposal(ctx, grants, false, ""), 10)
+	revokeID, _ := pm.AddProposal(
+		ctx, "c2", pm.CreateGrantFreeScoreProposal(ctx, grants[:1], true, ""), 10)
+	notPassID, _ := pm.AddProposal(
+		ctx, "c3", pm.CreateGrantFreeScoreProposal(ctx, grants, false, ""), 10)
+	assert.Nil(t, addProposalInfo(ctx, pm, grantID, passVotes, types.NewCoinFromInt64(0)))
+	assert.Nil(t, addProposalInfo(ctx, pm, revokeID, passVotes, types.NewCoinFromInt64(0)))
+	assert.Nil(t, addProposalInfo(ctx, pm, notPassID, types.NewCoinFromInt64(0), passVotes))
+
+	rm := &repmocks.ReputationKeeper{}
+	rm.On("GrantFreeScore", ctx, types.AccountKey("user1"), types.NewMiniDollar(100), grantID).Return(nil).Once()
+	rm.On("GrantFreeScore", ctx, types.AccountKey("user2"), types.NewMiniDollar(200), grantID).Return(nil).Once()
+	rm.On("GrantFreeScore", ctx, types.AccountKey("user1"), types.NewMiniDollar(-100), revokeID).Return(nil).Once()
+
+	for _, id := range []types.ProposalKey{grantID, revokeID, notPassID} {
+		event := DecideProposalEvent{ProposalType: types.GrantFreeScore, ProposalID: id}
+		err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, rm, &gm)
+		assert.Nil(t, err)
+	}
+	rm.AssertExpectations(t)
+
+	proposal, _ := pm.storage.GetExpiredProposal(ctx, notPassID)
+	assert.Equal(t, types.ProposalNotPass, proposal.GetProposalInfo().Result)
+}
+
+func TestDecideTreasurySpendProposal(t *testing.T) {
+	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, 0)
+	voteManager.InitGenesis(ctx)
+	valManager.InitGenesis(ctx)
+	pm.InitGenesis(ctx)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	passVotes := proposalParam.ChangeParamPassVotes.Plus(types.NewCoinFromInt64(1))
+	zero := types.NewCoinFromInt64(0)
+	recipient := createTestAccount(ctx, am, "recipient", zero)
+	assert.Nil(t, gm.AddToCommunityTreasury(ctx, types.NewCoinFromInt64(150)))
+
+	spendID, _ := pm.AddProposal(
+		ctx, "c1", pm.CreateTreasurySpendProposal(ctx, recipient, types.NewCoinFromInt64(100), ""), 10)
+	notEnoughID, _ := pm.AddProposal(
+		ctx, "c2", pm.CreateTreasurySpendProposal(ctx, recipient, types.NewCoinFromInt64(100), ""), 10)
+	noRecipientID, _ := pm.AddProposal(
+		ctx, "c3", pm.CreateTreasurySpendProposal(ctx, "invalid", types.NewCoinFromInt64(10), ""), 10)
+	notPassID, _ := pm.AddProposal(
+		ctx, "c4", pm.CreateTreasurySpendProposal(ctx, recipient, types.NewCoinFromInt64(10), ""), 10)
+	for _, id := range []types.ProposalKey{spendID, notEnoughID, noRecipientID} {
+		assert.Nil(t, addProposalInfo(ctx, pm, id, passVotes, zero))
+	}
+	assert.Nil(t, addProposalInfo(ctx, pm, notPassID, zero, passVotes))
+
+	testCases := []struct {
+		testName         string
+		proposalID       types.ProposalKey
+		wantResult       types.ProposalResult
+		wantSaving       types.Coin
+		wantTreasury     types.Coin
+		wantFailedEvents int
+	}{
+		{
+			testName:         "treasury spend is executed",
+			proposalID:       spendID,
+			wantResult:       types.ProposalPass,
+			wantSaving:       types.NewCoinFromInt64(100),
+			wantTreasury:     types.NewCoinFromInt64(50),
+			wantFailedEvents: 0,
+		},
+		{
+			testName:         "treasury is not enough",
+			proposalID:       notEnoughID,
+			wantResult:       types.ProposalPass,
+			wantSaving:       types.NewCoinFromInt64(100),
+			wantTreasury:     types.NewCoinFromInt64(50),
+			wantFailedEvents: 1,
+		},
+		{
+			testName:         "recipient doesn't exist",
+			proposalID:       noRecipientID,
+			wantResult:       types.ProposalPass,
+			wantSaving:       types.NewCoinFromInt64(100),
+			wantTreasury:     types.NewCoinFromInt64(50),
+			wantFailedEvents: 2,
+		},
+		{
+			testName:         "proposal doesn't pass",
+			proposalID:       notPassID,
+			wantResult:       types.ProposalNotPass,
+			wantSaving:       types.NewCoinFromInt64(100),
+			wantTreasury:     types.NewCoinFromInt64(50),
+			wantFailedEvents: 2,
+		},
+	}
+	for _, tc := range testCases {
+		event := DecideProposalEvent{ProposalType: types.TreasurySpend, ProposalID: tc.proposalID}
+		err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+		assert.Nil(t, err, tc.testName)
+		proposal, _ := pm.storage.GetExpiredProposal(ctx, tc.proposalID)
+		assert.Equal(t, tc.wantResult, proposal.GetProposalInfo().Result, tc.testName)
+		saving, _ := am.GetSavingFromBank(ctx, recipient)
+		assert.True(t, tc.wantSaving.IsEqual(saving), tc.testName)
+		treasury, _ := gm.GetCommunityTreasury(ctx)
+		assert.True(t, tc.wantTreasury.IsEqual(treasury), tc.testName)
+		failedEvents, _ := gm.GetFailedEvents(ctx)
+		assert.Equal(t, tc.wantFailedEvents, len(failedEvents), tc.testName)
+	}
+
+	// spend recorded as failed event is paid once treasury is refilled
+	failedEvents, _ := gm.GetFailedEvents(ctx)
+	assert.Equal(t, TreasurySpendEvent{
+		ProposalID: notEnoughID, Recipient: recipient, Amount: types.NewCoinFromInt64(100),
+	}, failedEvents[0].Event)
+	assert.Nil(t, gm.AddToCommunityTreasury(ctx, types.NewCoinFromInt64(50)))
+	assert.Nil(t, failedEvents[0].Event.(TreasurySpendEvent).Execute(ctx, am, &gm))
+	saving, _ := am.GetSavingFromBank(ctx, recipient)
+	assert.True(t, types.NewCoinFromInt64(200).IsEqual(saving))
+	treasury, _ := gm.GetCommunityTreasury(ctx)
+	assert.True(t, types.NewCoinFromInt64(0).IsEqual(treasury))
+}
+
+func TestDecideProtocolUpgradeProposal(t *testing.T) {
+	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, 10)
+	voteManager.InitGenesis(ctx)
+	valManager.InitGenesis(ctx)
+	pm.InitGenesis(ctx)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	passVotes := proposalParam.ProtocolUpgradePassVotes.Plus(types.NewCoinFromInt64(1))
+
+	legacyID, _ := pm.AddProposal(
+		ctx, "c1", pm.CreateProtocolUpgradeProposal(ctx, "link", "", 0, ""), 10)
+	passedHeightID, _ := pm.AddProposal(
+		ctx, "c2", pm.CreateProtocolUpgradeProposal(ctx, "link", "upgrade1", 10, ""), 10)
+	scheduleID, _ := pm.AddProposal(
+		ctx, "c3", pm.CreateProtocolUpgradeProposal(ctx, "link", "upgrade2", 100, ""), 10)
+	pendingID, _ := pm.AddProposal(
+		ctx, "c4", pm.CreateProtocolUpgradeProposal(ctx, "link", "upgrade3", 200, ""), 10)
+	for _, id := range []types.ProposalKey{legacyID, passedHeightID, scheduleID, pendingID} {
+		assert.Nil(t, addProposalInfo(ctx, pm, id, passVotes, types.NewCoinFromInt64(0)))
+	}
+
+	for _, id := range []types.ProposalKey{legacyID, passedHeightID} {
+		event := DecideProposalEvent{ProposalType: types.ProtocolUpgrade, ProposalID: id}
+		err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+		assert.Nil(t, err)
+		assert.False(t, pm.DoesUpgradePlanExist(ctx))
+	}
+
+	event := DecideProposalEvent{ProposalType: types.ProtocolUpgrade, ProposalID: scheduleID}
+	err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+	assert.Nil(t, err)
+	plan, err := pm.GetUpgradePlan(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.UpgradePlan{
+		Name: "upgrade2", Height: 100, Link: "link", ProposalID: scheduleID}, plan)
+
+	// pending upgrade is not replaced
+	event = DecideProposalEvent{ProposalType: types.ProtocolUpgrade, ProposalID: pendingID}
+	err = event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+	assert.Equal(t, ErrUpgradePlanExist("upgrade2"), err)
+	plan, err = pm.GetUpgradePlan(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, scheduleID, plan.ProposalID)
+}
+
+func TestSettleProposalDeposit(t *testing.T) {
+	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, types.BlockchainUpgrade1Update8Height)
+	voteManager.InitGenesis(ctx)
+	valManager.InitGenesis(ctx)
+	pm.InitGenesis(ctx)
+	err := gm.SetChainStartTime(ctx, ctx.BlockHeader().Time.Unix())
+	assert.Nil(t, err)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	passVotes := proposalParam.ContentCensorshipPassVotes.Plus(types.NewCoinFromInt64(1))
+	deposit := proposalParam.TextProposalMinDeposit
+	zero := types.NewCoinFromInt64(0)
+	creator := createTestAccount(ctx, am, "creator", zero)
+
+	passID, _ := pm.AddProposal(ctx, creator, pm.CreateTextProposal(ctx, "pass", ""), 10)
+	vetoID, _ := pm.AddProposal(ctx, creator, pm.CreateTextProposal(ctx, "veto", ""), 10)
+	for _, id := range []types.ProposalKey{passID, vetoID} {
+		assert.Nil(t, pm.HoldProposalDeposit(ctx, id, creator, deposit))
+		assert.Nil(t, addProposalInfo(ctx, pm, id, passVotes, zero))
+	}
+	assert.Nil(t, pm.storage.SetProposalTally(ctx, vetoID, &model.ProposalTally{
+		AbstainVotes: zero,
+		VetoVotes:    passVotes,
+	}))
+	totalLino, _ := gm.GetTotalLinoCoin(ctx)
+
+	testCases := []struct {
+		testName      string
+		proposalID    types.ProposalKey
+		wantResult    types.ProposalResult
+		wantSaving    types.Coin
+		wantTotalLino types.Coin
+	}{
+		{
+			testName:      "deposit of passed proposal is returned",
+			proposalID:    passID,
+			wantResult:    types.ProposalPass,
+			wantSaving:    deposit,
+			wantTotalLino: totalLino,
+		},
+		{
+			testName:      "deposit of vetoed proposal is burnt",
+			proposalID:    vetoID,
+			wantResult:    types.ProposalVetoed,
+			wantSaving:    deposit,
+			wantTotalLino: totalLino.Minus(deposit),
+		},
+	}
+	for _, tc := range testCases {
+		event := DecideProposalEvent{ProposalType: types.TextProposal, ProposalID: tc.proposalID}
+		err := event.Execute(ctx, voteManager, valManager, am, pm, postManager, nil, &gm)
+		assert.Nil(t, err, tc.testName)
+		proposal, _ := pm.storage.GetExpiredProposal(ctx, tc.proposalID)
+		assert.Equal(t, tc.wantResult, proposal.GetProposalInfo().Result, tc.testName)
+		assert.False(t, pm.DoesProposalDepositExist(ctx, tc.proposalID), tc.testName)
+		saving, _ := am.GetSavingFromBank(ctx, creator)
+		assert.True(t, tc.wantSaving.IsEqual(saving), tc.testName)
+		total, _ := gm.GetTotalLinoCoin(ctx)
+		assert.True(t, tc.wantTotalLino.IsEqual(total), tc.testName)
+	}
+}
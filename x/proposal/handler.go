@@ -7,6 +7,7 @@ import (
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/global"
 	"github.com/lino-network/lino/x/post"
+	"github.com/lino-network/lino/x/proposal/model"
 	"github.com/lino-network/lino/x/vote"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,11 +21,19 @@ func NewHandler(
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case ChangeParamMsg:
-			return handleChangeParamMsg(ctx, am, proposalManager, gm, msg)
+			return handleChangeParamMsg(ctx, am, proposalManager, gm, vm, msg)
 		case ContentCensorshipMsg:
-			return handleContentCensorshipMsg(ctx, am, proposalManager, postManager, gm, msg)
+			return handleContentCensorshipMsg(ctx, am, proposalManager, postManager, gm, vm, msg)
 		case ProtocolUpgradeMsg:
-			return handleProtocolUpgradeMsg(ctx, am, proposalManager, gm, msg)
+			return handleProtocolUpgradeMsg(ctx, am, proposalManager, gm, vm, msg)
+		case ResolveFailedEventMsg:
+			return handleResolveFailedEventMsg(ctx, am, proposalManager, gm, vm, msg)
+		case GrantFreeScoreMsg:
+			return handleGrantFreeScoreMsg(ctx, am, proposalManager, gm, vm, msg)
+		case TextProposalMsg:
+			return handleTextProposalMsg(ctx, am, proposalManager, gm, vm, msg)
+		case TreasurySpendMsg:
+			return handleTreasurySpendMsg(ctx, am, proposalManager, gm, vm, msg)
 		case VoteProposalMsg:
 			return handleVoteProposalMsg(ctx, proposalManager, vm, msg)
 		default:
@@ -35,7 +44,7 @@ func NewHandler(
 }
 
 func handleChangeParamMsg(
-	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager,
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
 	msg ChangeParamMsg) sdk.Result {
 	if !am.DoesAccountExist(ctx, msg.GetCreator()) {
 		return ErrAccountNotFound().Result()
@@ -47,115 +56,210 @@ func handleChangeParamMsg(
 	}
 
 	proposal := pm.CreateChangeParamProposal(ctx, msg.GetParameter(), msg.GetReason())
-	proposalID, err := pm.AddProposal(ctx, msg.GetCreator(), proposal, param.ChangeParamDecideSec)
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.GetCreator(), proposal, types.ChangeParam,
+		param.ChangeParamDecideSec, param.ChangeParamMinDeposit)
 	if err != nil {
 		return err.Result()
 	}
-	//  set a time event to decide the proposal
-	event := pm.CreateDecideProposalEvent(ctx, types.ChangeParam, proposalID)
+	return sdk.Result{Tags: proposalTags(msg.GetCreator(), proposalID, param.ChangeParamMinDeposit)}
+}
 
-	if err := gm.RegisterProposalDecideEvent(ctx, param.ChangeParamDecideSec, event); err != nil {
-		return err.Result()
+func handleProtocolUpgradeMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	msg ProtocolUpgradeMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.GetCreator()) {
+		return ErrAccountNotFound().Result()
 	}
 
-	// minus coin from account and return when deciding the proposal
-	if err = am.MinusSavingCoin(
-		ctx, msg.GetCreator(), param.ChangeParamMinDeposit, "",
-		string(proposalID), types.ProposalDeposit); err != nil {
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
 		return err.Result()
 	}
 
-	if err := returnCoinTo(
-		ctx, msg.GetCreator(), gm, am, int64(1),
-		param.ChangeParamDecideSec, param.ChangeParamMinDeposit); err != nil {
+	// upgrade must be scheduled in the future and can only be applied once
+	if len(msg.GetName()) != 0 {
+		if msg.GetHeight() <= ctx.BlockHeight() {
+			return ErrInvalidUpgradeHeight().Result()
+		}
+		if pm.IsUpgradeDone(ctx, msg.GetName()) {
+			return ErrInvalidUpgradeName().Result()
+		}
+	}
+
+	proposal := pm.CreateProtocolUpgradeProposal(
+		ctx, msg.GetLink(), msg.GetName(), msg.GetHeight(), msg.GetReason())
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.GetCreator(), proposal, types.ProtocolUpgrade,
+		param.ProtocolUpgradeDecideSec, param.ProtocolUpgradeMinDeposit)
+	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: proposalTags(msg.GetCreator(), proposalID, param.ProtocolUpgradeMinDeposit)}
 }
 
-func handleProtocolUpgradeMsg(
-	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager,
-	msg ProtocolUpgradeMsg) sdk.Result {
+func handleContentCensorshipMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager,
+	postManager post.PostKeeper, gm *global.GlobalManager, vm vote.VoteManager,
+	msg ContentCensorshipMsg) sdk.Result {
 	if !am.DoesAccountExist(ctx, msg.GetCreator()) {
 		return ErrAccountNotFound().Result()
 	}
 
+	if !postManager.DoesPostExist(ctx, msg.GetPermlink()) {
+		return ErrPostNotFound().Result()
+	}
+
 	param, err := pm.paramHolder.GetProposalParam(ctx)
 	if err != nil {
 		return err.Result()
 	}
 
-	proposal := pm.CreateProtocolUpgradeProposal(ctx, msg.GetLink(), msg.GetReason())
-	proposalID, err := pm.AddProposal(ctx, msg.GetCreator(), proposal, param.ProtocolUpgradeDecideSec)
+	proposal := pm.CreateContentCensorshipProposal(ctx, msg.GetPermlink(), msg.GetReason())
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.GetCreator(), proposal, types.ContentCensorship,
+		param.ContentCensorshipDecideSec, param.ContentCensorshipMinDeposit)
 	if err != nil {
 		return err.Result()
 	}
-	//  set a time event to decide the proposal
-	event := pm.CreateDecideProposalEvent(ctx, types.ProtocolUpgrade, proposalID)
+	return sdk.Result{Tags: proposalTags(msg.GetCreator(), proposalID, param.ContentCensorshipMinDeposit).
+		AppendTag(types.TagPermlink, string(msg.GetPermlink()))}
+}
 
-	if err := gm.RegisterProposalDecideEvent(ctx, param.ProtocolUpgradeDecideSec, event); err != nil {
-		return err.Result()
+func handleResolveFailedEventMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	msg ResolveFailedEventMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) {
+		return ErrAccountNotFound().Result()
+	}
+	if !gm.DoesFailedEventExist(ctx, msg.FailedEventID) {
+		return ErrFailedEventNotFound().Result()
 	}
 
-	// minus coin from account and return when deciding the proposal
-	if err = am.MinusSavingCoin(
-		ctx, msg.GetCreator(), param.ProtocolUpgradeMinDeposit,
-		"", string(proposalID), types.ProposalDeposit); err != nil {
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
 		return err.Result()
 	}
 
-	if err := returnCoinTo(
-		ctx, msg.GetCreator(), gm, am, int64(1),
-		param.ProtocolUpgradeDecideSec, param.ProtocolUpgradeMinDeposit); err != nil {
+	proposal := pm.CreateResolveFailedEventProposal(ctx, msg.FailedEventID, msg.Retry, msg.Reason)
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.Creator, proposal, types.ResolveFailedEvent,
+		param.ChangeParamDecideSec, param.ChangeParamMinDeposit)
+	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: proposalTags(msg.Creator, proposalID, param.ChangeParamMinDeposit)}
 }
 
-func handleContentCensorshipMsg(
-	ctx sdk.Context, am acc.AccountManager, proposalManager ProposalManager,
-	postManager post.PostKeeper, gm *global.GlobalManager, msg ContentCensorshipMsg) sdk.Result {
-	if !am.DoesAccountExist(ctx, msg.GetCreator()) {
+func handleGrantFreeScoreMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	msg GrantFreeScoreMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) {
 		return ErrAccountNotFound().Result()
 	}
+	for _, grant := range msg.Grants {
+		if !am.DoesAccountExist(ctx, grant.Username) {
+			return ErrAccountNotFound().Result()
+		}
+	}
 
-	if !postManager.DoesPostExist(ctx, msg.GetPermlink()) {
-		return ErrPostNotFound().Result()
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
+		return err.Result()
 	}
 
-	param, err := proposalManager.paramHolder.GetProposalParam(ctx)
+	proposal := pm.CreateGrantFreeScoreProposal(ctx, msg.Grants, msg.Revoke, msg.Reason)
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.Creator, proposal, types.GrantFreeScore,
+		param.ChangeParamDecideSec, param.ChangeParamMinDeposit)
 	if err != nil {
 		return err.Result()
 	}
+	return sdk.Result{Tags: proposalTags(msg.Creator, proposalID, param.ChangeParamMinDeposit)}
+}
 
-	proposal :=
-		proposalManager.CreateContentCensorshipProposal(
-			ctx, msg.GetPermlink(), msg.GetReason())
-	proposalID, err :=
-		proposalManager.AddProposal(
-			ctx, msg.GetCreator(), proposal, param.ContentCensorshipDecideSec)
+// community treasury is checked when the proposal is executed, a spend that
+// can't be paid is recorded as failed event.
+func handleTreasurySpendMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	msg TreasurySpendMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) || !am.DoesAccountExist(ctx, msg.Recipient) {
+		return ErrAccountNotFound().Result()
+	}
+	amount, err := types.LinoToCoin(msg.Amount)
 	if err != nil {
 		return err.Result()
 	}
-	//  set a time event to decide the proposal
-	event := proposalManager.CreateDecideProposalEvent(ctx, types.ContentCensorship, proposalID)
-	// minus coin from account and return when deciding the proposal
-	if err = am.MinusSavingCoin(
-		ctx, msg.GetCreator(), param.ContentCensorshipMinDeposit,
-		"", string(proposalID), types.ProposalDeposit); err != nil {
+
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposal := pm.CreateTreasurySpendProposal(ctx, msg.Recipient, amount, msg.Reason)
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.Creator, proposal, types.TreasurySpend,
+		param.ChangeParamDecideSec, param.ChangeParamMinDeposit)
+	if err != nil {
 		return err.Result()
 	}
+	return sdk.Result{Tags: proposalTags(msg.Creator, proposalID, param.ChangeParamMinDeposit)}
+}
+
+// text proposal is not executed after it is decided, voters only signal their opinion.
+func handleTextProposalMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	msg TextProposalMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) {
+		return ErrAccountNotFound().Result()
+	}
 
-	if err := gm.RegisterProposalDecideEvent(ctx, param.ContentCensorshipDecideSec, event); err != nil {
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
 		return err.Result()
 	}
 
-	if err := returnCoinTo(
-		ctx, msg.GetCreator(), gm, am, int64(1),
-		param.ContentCensorshipDecideSec, param.ContentCensorshipMinDeposit); err != nil {
+	proposal := pm.CreateTextProposal(ctx, msg.Title, msg.Description)
+	proposalID, err := createProposal(
+		ctx, am, pm, gm, vm, msg.Creator, proposal, types.TextProposal,
+		param.TextProposalDecideSec, param.TextProposalMinDeposit)
+	if err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: proposalTags(msg.Creator, proposalID, param.TextProposalMinDeposit)}
+}
+
+// createProposal - add proposal, snapshot voting power, register decide event
+// and take deposit from creator until the proposal is decided.
+func createProposal(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager, gm *global.GlobalManager, vm vote.VoteManager,
+	creator types.AccountKey, proposal model.Proposal, proposalType types.ProposalType,
+	decideSec int64, minDeposit types.Coin) (types.ProposalKey, sdk.Error) {
+	proposalID, err := pm.AddProposal(ctx, creator, proposal, decideSec)
+	if err != nil {
+		return proposalID, err
+	}
+	// voting power of voters is fixed at the creation of proposal
+	if err := vm.StartVotingPowerSnapshot(ctx, proposalID); err != nil {
+		return proposalID, err
+	}
+	//  set a time event to decide the proposal
+	event := pm.CreateDecideProposalEvent(ctx, proposalType, proposalID)
+
+	if err := gm.RegisterProposalDecideEvent(ctx, decideSec, event); err != nil {
+		return proposalID, err
+	}
+
+	// minus coin from account and return when deciding the proposal
+	if err := am.MinusSavingCoin(
+		ctx, creator, minDeposit, "", string(proposalID), types.ProposalDeposit); err != nil {
+		return proposalID, err
+	}
+
+	if err := holdDeposit(ctx, pm, proposalID, creator, gm, am, decideSec, minDeposit); err != nil {
+		return proposalID, err
+	}
+	return proposalID, nil
 }
 
 func handleVoteProposalMsg(ctx sdk.Context, proposalManager ProposalManager, vm vote.VoteManager, msg VoteProposalMsg) sdk.Result {
@@ -167,7 +271,14 @@ func handleVoteProposalMsg(ctx sdk.Context, proposalManager ProposalManager, vm
 		return ErrNotOngoingProposal().Result()
 	}
 
-	if err := vm.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Result); err != nil {
+	option := msg.GetOption()
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update8Height &&
+		option != types.VoteOptionYes && option != types.VoteOptionNo {
+		return ErrInvalidVoteOption().Result()
+	}
+
+	overrides, err := vm.AddVote(ctx, msg.ProposalID, msg.Voter, option)
+	if err != nil {
 		return err.Result()
 	}
 
@@ -176,12 +287,31 @@ func handleVoteProposalMsg(ctx sdk.Context, proposalManager ProposalManager, vm
 		return err.Result()
 	}
 
-	err = proposalManager.UpdateProposalVotingStatus(ctx, msg.ProposalID, msg.Voter, v.Result, v.VotingPower)
+	err = proposalManager.UpdateProposalVotingStatus(ctx, msg.ProposalID, msg.Voter, option, v.VotingPower, overrides)
 	if err != nil {
 		return err.Result()
 	}
 
-	return sdk.Result{}
+	return sdk.Result{Tags: types.UsernameTags(msg.Voter).
+		AppendTag(types.TagProposalID, string(msg.ProposalID))}
+}
+
+// tags of created proposal and deposit of creator
+func proposalTags(creator types.AccountKey, proposalID types.ProposalKey, deposit types.Coin) sdk.Tags {
+	return types.CoinTags(creator, deposit, types.ProposalDeposit).
+		AppendTag(types.TagProposalID, string(proposalID))
+}
+
+// holdDeposit - deposit of proposal is held by proposal module until the proposal
+// is decided since BlockchainUpgrade1Update8Height, it was returned by coin return
+// event after decide seconds before.
+func holdDeposit(
+	ctx sdk.Context, pm ProposalManager, proposalID types.ProposalKey, creator types.AccountKey,
+	gm *global.GlobalManager, am acc.AccountManager, decideSec int64, coin types.Coin) sdk.Error {
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update8Height {
+		return returnCoinTo(ctx, creator, gm, am, int64(1), decideSec, coin)
+	}
+	return pm.HoldProposalDeposit(ctx, proposalID, creator, coin)
 }
 
 func returnCoinTo(
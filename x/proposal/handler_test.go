@@ -28,6 +28,8 @@ func TestChangeParamProposal(t *testing.T) {
 		GlobalGrowthRate:         types.NewDecFromRat(98, 1000),
 		DeveloperAllocation:      sdk.ZeroDec(),
 		ValidatorAllocation:      sdk.ZeroDec(),
+		TreasuryPenaltyShare:     sdk.ZeroDec(),
+		TreasuryFrictionShare:    sdk.ZeroDec(),
 		InfraAllocation:          sdk.ZeroDec(),
 		ContentCreatorAllocation: types.NewDecFromRat(5, 10),
 	}
@@ -71,7 +73,7 @@ func TestChangeParamProposal(t *testing.T) {
 			},
 			proposalID:          proposalID1,
 			wantOK:              true,
-			wantRes:             sdk.Result{},
+			wantRes:             sdk.Result{Tags: proposalTags(user1, proposalID1, proposalParam.ChangeParamMinDeposit)},
 			wantCreatorBalance:  c460000.Minus(proposalParam.ChangeParamMinDeposit),
 			wantOngoingProposal: []model.Proposal{proposal1},
 			wantProposal:        proposal1,
@@ -165,12 +167,15 @@ func TestContentCensorshipProposal(t *testing.T) {
 		wantProposal        model.Proposal
 	}{
 		{
-			testName:            "user2 censorship user1's post successfully",
-			creator:             user2,
-			permlink:            types.GetPermlink(user1, postID1),
-			proposalID:          proposalID1,
-			wantOK:              true,
-			wantRes:             sdk.Result{},
+			testName:   "user2 censorship user1's post successfully",
+			creator:    user2,
+			permlink:   types.GetPermlink(user1, postID1),
+			proposalID: proposalID1,
+			wantOK:     true,
+			wantRes: sdk.Result{
+				Tags: proposalTags(user2, proposalID1, proposalParam.ContentCensorshipMinDeposit).
+					AppendTag(types.TagPermlink, string(types.GetPermlink(user1, postID1))),
+			},
 			wantCreatorBalance:  c4600.Minus(proposalParam.ContentCensorshipMinDeposit),
 			wantOngoingProposal: []model.Proposal{proposal1},
 			wantProposal:        proposal1,
@@ -249,6 +254,285 @@ func TestContentCensorshipProposal(t *testing.T) {
 	}
 }
 
+func TestResolveFailedEventProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+	curTime := ctx.BlockHeader().Time.Unix()
+	proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+
+	proposalManager.InitGenesis(ctx)
+
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+	user1 := createTestAccount(ctx, am, "user1", c4600)
+	user2 := createTestAccount(
+		ctx, am, "user2", proposalParam.ChangeParamMinDeposit.Minus(types.NewCoinFromInt64(1)))
+	err := gm.RecordFailedEvent(
+		ctx, acc.ReturnCoinEvent{Username: "user3", Amount: c46}, curTime, ErrAccountNotFound())
+	assert.Nil(t, err)
+	proposal1 := &model.ResolveFailedEventProposal{
+		ProposalInfo: model.ProposalInfo{
+			Creator:       user1,
+			ProposalID:    proposalID1,
+			AgreeVotes:    types.NewCoinFromInt64(0),
+			DisagreeVotes: types.NewCoinFromInt64(0),
+			Result:        types.ProposalNotPass,
+			CreatedAt:     curTime,
+			ExpiredAt:     curTime + proposalParam.ChangeParamDecideSec,
+		},
+		FailedEventID: 1,
+		Retry:         true,
+		Reason:        "reason",
+	}
+
+	testCases := []struct {
+		testName            string
+		creator             types.AccountKey
+		failedEventID       int64
+		wantOK              bool
+		wantRes             sdk.Result
+		wantCreatorBalance  types.Coin
+		wantOngoingProposal []model.Proposal
+	}{
+		{
+			testName:      "user1 creates resolve failed event proposal successfully",
+			creator:       user1,
+			failedEventID: 1,
+			wantOK:        true,
+			wantRes: sdk.Result{
+				Tags: proposalTags(user1, proposalID1, proposalParam.ChangeParamMinDeposit),
+			},
+			wantCreatorBalance:  c4600.Minus(proposalParam.ChangeParamMinDeposit),
+			wantOngoingProposal: []model.Proposal{proposal1},
+		},
+		{
+			testName:      "creator doesn't exist",
+			creator:       "invalid",
+			failedEventID: 1,
+			wantOK:        false,
+			wantRes:       ErrAccountNotFound().Result(),
+		},
+		{
+			testName:      "failed event doesn't exist",
+			creator:       user1,
+			failedEventID: 2,
+			wantOK:        false,
+			wantRes:       ErrFailedEventNotFound().Result(),
+		},
+		{
+			testName:      "user2 doesn't have enough money to create proposal",
+			creator:       user2,
+			failedEventID: 1,
+			wantOK:        false,
+			wantRes:       acc.ErrAccountSavingCoinNotEnough().Result(),
+		},
+	}
+	for _, tc := range testCases {
+		msg := NewResolveFailedEventMsg(string(tc.creator), tc.failedEventID, true, "reason")
+		result := handler(ctx, msg)
+		if !assert.Equal(t, tc.wantRes, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantRes)
+		}
+
+		if !tc.wantOK {
+			continue
+		}
+
+		creatorBalance, _ := am.GetSavingFromBank(ctx, tc.creator)
+		if !creatorBalance.IsEqual(tc.wantCreatorBalance) {
+			t.Errorf("%s: diff bank balance: got %v, want %v",
+				tc.testName, creatorBalance, tc.wantCreatorBalance)
+		}
+
+		ongoingList, err := proposalManager.GetOngoingProposalList(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get proposal list, get err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantOngoingProposal, ongoingList) {
+			t.Errorf("%s: diff ongoing proposal, got %v, want %v", tc.testName, ongoingList, tc.wantOngoingProposal)
+		}
+	}
+}
+
+func TestTextProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+	curTime := ctx.BlockHeader().Time.Unix()
+	proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+
+	proposalManager.InitGenesis(ctx)
+
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+	user1 := createTestAccount(ctx, am, "user1", c4600)
+	user2 := createTestAccount(
+		ctx, am, "user2", proposalParam.TextProposalMinDeposit.Minus(types.NewCoinFromInt64(1)))
+	proposal1 := &model.TextProposal{
+		ProposalInfo: model.ProposalInfo{
+			Creator:       user1,
+			ProposalID:    proposalID1,
+			AgreeVotes:    types.NewCoinFromInt64(0),
+			DisagreeVotes: types.NewCoinFromInt64(0),
+			Result:        types.ProposalNotPass,
+			CreatedAt:     curTime,
+			ExpiredAt:     curTime + proposalParam.TextProposalDecideSec,
+		},
+		Title:       "title",
+		Description: "description",
+	}
+
+	testCases := []struct {
+		testName            string
+		creator             types.AccountKey
+		wantOK              bool
+		wantRes             sdk.Result
+		wantCreatorBalance  types.Coin
+		wantOngoingProposal []model.Proposal
+	}{
+		{
+			testName: "user1 creates text proposal successfully",
+			creator:  user1,
+			wantOK:   true,
+			wantRes: sdk.Result{
+				Tags: proposalTags(user1, proposalID1, proposalParam.TextProposalMinDeposit),
+			},
+			wantCreatorBalance:  c4600.Minus(proposalParam.TextProposalMinDeposit),
+			wantOngoingProposal: []model.Proposal{proposal1},
+		},
+		{
+			testName: "creator doesn't exist",
+			creator:  "invalid",
+			wantOK:   false,
+			wantRes:  ErrAccountNotFound().Result(),
+		},
+		{
+			testName: "user2 doesn't have enough money to create proposal",
+			creator:  user2,
+			wantOK:   false,
+			wantRes:  acc.ErrAccountSavingCoinNotEnough().Result(),
+		},
+	}
+	for _, tc := range testCases {
+		msg := NewTextProposalMsg(string(tc.creator), "title", "description")
+		result := handler(ctx, msg)
+		if !assert.Equal(t, tc.wantRes, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantRes)
+		}
+
+		if !tc.wantOK {
+			continue
+		}
+
+		creatorBalance, _ := am.GetSavingFromBank(ctx, tc.creator)
+		if !creatorBalance.IsEqual(tc.wantCreatorBalance) {
+			t.Errorf("%s: diff bank balance: got %v, want %v",
+				tc.testName, creatorBalance, tc.wantCreatorBalance)
+		}
+
+		ongoingList, err := proposalManager.GetOngoingProposalList(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get proposal list, get err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantOngoingProposal, ongoingList) {
+			t.Errorf("%s: diff ongoing proposal, got %v, want %v", tc.testName, ongoingList, tc.wantOngoingProposal)
+		}
+	}
+}
+
+func TestTreasurySpendProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+	curTime := ctx.BlockHeader().Time.Unix()
+	proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+
+	proposalManager.InitGenesis(ctx)
+
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+	user1 := createTestAccount(ctx, am, "user1", c4600)
+	user2 := createTestAccount(
+		ctx, am, "user2", proposalParam.ChangeParamMinDeposit.Minus(types.NewCoinFromInt64(1)))
+	proposal1 := &model.TreasurySpendProposal{
+		ProposalInfo: model.ProposalInfo{
+			Creator:       user1,
+			ProposalID:    proposalID1,
+			AgreeVotes:    types.NewCoinFromInt64(0),
+			DisagreeVotes: types.NewCoinFromInt64(0),
+			Result:        types.ProposalNotPass,
+			CreatedAt:     curTime,
+			ExpiredAt:     curTime + proposalParam.ChangeParamDecideSec,
+		},
+		Recipient: user2,
+		Amount:    types.NewCoinFromInt64(100 * types.Decimals),
+		Reason:    "reason",
+	}
+
+	testCases := []struct {
+		testName            string
+		creator             types.AccountKey
+		recipient           types.AccountKey
+		wantOK              bool
+		wantRes             sdk.Result
+		wantCreatorBalance  types.Coin
+		wantOngoingProposal []model.Proposal
+	}{
+		{
+			testName:  "user1 creates treasury spend proposal successfully",
+			creator:   user1,
+			recipient: user2,
+			wantOK:    true,
+			wantRes: sdk.Result{
+				Tags: proposalTags(user1, proposalID1, proposalParam.ChangeParamMinDeposit),
+			},
+			wantCreatorBalance:  c4600.Minus(proposalParam.ChangeParamMinDeposit),
+			wantOngoingProposal: []model.Proposal{proposal1},
+		},
+		{
+			testName:  "creator doesn't exist",
+			creator:   "invalid",
+			recipient: user2,
+			wantOK:    false,
+			wantRes:   ErrAccountNotFound().Result(),
+		},
+		{
+			testName:  "recipient doesn't exist",
+			creator:   user1,
+			recipient: "invalid",
+			wantOK:    false,
+			wantRes:   ErrAccountNotFound().Result(),
+		},
+		{
+			testName:  "user2 doesn't have enough money to create proposal",
+			creator:   user2,
+			recipient: user1,
+			wantOK:    false,
+			wantRes:   acc.ErrAccountSavingCoinNotEnough().Result(),
+		},
+	}
+	for _, tc := range testCases {
+		msg := NewTreasurySpendMsg(string(tc.creator), string(tc.recipient), "100", "reason")
+		result := handler(ctx, msg)
+		if !assert.Equal(t, tc.wantRes, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantRes)
+		}
+
+		if !tc.wantOK {
+			continue
+		}
+
+		creatorBalance, _ := am.GetSavingFromBank(ctx, tc.creator)
+		if !creatorBalance.IsEqual(tc.wantCreatorBalance) {
+			t.Errorf("%s: diff bank balance: got %v, want %v",
+				tc.testName, creatorBalance, tc.wantCreatorBalance)
+		}
+
+		ongoingList, err := proposalManager.GetOngoingProposalList(ctx)
+		if err != nil {
+			t.Errorf("%s: failed to get proposal list, get err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantOngoingProposal, ongoingList) {
+			t.Errorf("%s: diff ongoing proposal, got %v, want %v", tc.testName, ongoingList, tc.wantOngoingProposal)
+		}
+	}
+}
+
 func TestAddFrozenMoney(t *testing.T) {
 	ctx, am, proposalManager, _, _, _, gm := setupTest(t, 0)
 	proposalManager.InitGenesis(ctx)
@@ -393,8 +677,10 @@ func TestVoteProposalBasic(t *testing.T) {
 				ProposalID: proposalID1,
 				Result:     true,
 			},
-			wantRes: sdk.Result{},
-			wantOK:  true,
+			wantRes: sdk.Result{
+				Tags: types.UsernameTags(user1).AppendTag(types.TagProposalID, string(proposalID1)),
+			},
+			wantOK: true,
 			wantProposal: &model.ContentCensorshipProposal{
 				ProposalInfo: model.ProposalInfo{
 					Creator:       user1,
@@ -449,3 +735,166 @@ func TestVoteProposalBasic(t *testing.T) {
 		}
 	}
 }
+
+func TestVoteWithSnapshotPower(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, types.BlockchainUpgrade1Update13Height)
+	handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+	proposalManager.InitGenesis(ctx)
+
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	user3 := types.AccountKey("user3")
+	createTestAccount(ctx, am, "user1", c4600)
+	_ = vm.AddVoter(ctx, user1, c4600)
+	_ = vm.AddVoter(ctx, user2, c46)
+
+	proposal := &model.ContentCensorshipProposal{
+		Permlink: types.Permlink("postlink"),
+		Reason:   "reason",
+	}
+	proposalID, _ := proposalManager.AddProposal(ctx, user1, proposal, int64(100))
+	err := vm.StartVotingPowerSnapshot(ctx, proposalID)
+	assert.Nil(t, err)
+
+	// power changes after proposal creation don't count
+	err = vm.AddLinoStake(ctx, user1, c4600)
+	assert.Nil(t, err)
+	err = vm.AddVoter(ctx, user3, c4600)
+	assert.Nil(t, err)
+	err = vm.AddDelegation(ctx, user2, user3, c46)
+	assert.Nil(t, err)
+	power, err := vm.GetSnapshotVotingPower(ctx, proposalID, user3)
+	assert.Nil(t, err)
+	assert.True(t, power.IsZero())
+
+	for _, voter := range []types.AccountKey{user1, user2, user3} {
+		result := handler(ctx, VoteProposalMsg{Voter: voter, ProposalID: proposalID, Result: true})
+		assert.Equal(t, sdk.Result{
+			Tags: types.UsernameTags(voter).AppendTag(types.TagProposalID, string(proposalID)),
+		}, result)
+	}
+	p, err := proposalManager.storage.GetOngoingProposal(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, c4600.Plus(c46), p.GetProposalInfo().AgreeVotes)
+
+	// snapshot is removed once the proposal is decided
+	_, err = proposalManager.UpdateProposalPassStatus(ctx, types.ContentCensorship, proposalID, types.NewCoinFromInt64(0))
+	assert.Nil(t, err)
+	err = vm.EndVotingPowerSnapshot(ctx, proposalID)
+	assert.Nil(t, err)
+	power, err = vm.GetSnapshotVotingPower(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c4600.Plus(c4600), power)
+}
+
+func TestDelegatorVoteOverride(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, types.BlockchainUpgrade1Update13Height)
+	handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+	proposalManager.InitGenesis(ctx)
+
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	createTestAccount(ctx, am, "user1", c4600)
+	_ = vm.AddVoter(ctx, user1, c4600)
+	_ = vm.AddVoter(ctx, user2, c46)
+	_ = vm.AddDelegation(ctx, user1, user2, c46)
+
+	proposal := &model.ContentCensorshipProposal{
+		Permlink: types.Permlink("postlink"),
+		Reason:   "reason",
+	}
+	proposalID, _ := proposalManager.AddProposal(ctx, user1, proposal, int64(100))
+	err := vm.StartVotingPowerSnapshot(ctx, proposalID)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName       string
+		voter          types.AccountKey
+		result         bool
+		expectAgree    types.Coin
+		expectDisagree types.Coin
+	}{
+		{
+			testName:       "voter votes with delegated power",
+			voter:          user1,
+			result:         true,
+			expectAgree:    c4600.Plus(c46),
+			expectDisagree: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:       "delegator overrides voter",
+			voter:          user2,
+			result:         false,
+			expectAgree:    c4600,
+			expectDisagree: c46,
+		},
+	}
+	for _, tc := range testCases {
+		result := handler(ctx, VoteProposalMsg{Voter: tc.voter, ProposalID: proposalID, Result: tc.result})
+		assert.Equal(t, sdk.Result{
+			Tags: types.UsernameTags(tc.voter).AppendTag(types.TagProposalID, string(proposalID)),
+		}, result, tc.testName)
+		p, err := proposalManager.storage.GetOngoingProposal(ctx, proposalID)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectAgree, p.GetProposalInfo().AgreeVotes, tc.testName)
+		assert.Equal(t, tc.expectDisagree, p.GetProposalInfo().DisagreeVotes, tc.testName)
+	}
+}
+
+func TestHoldProposalDeposit(t *testing.T) {
+	for _, height := range []int64{0, types.BlockchainUpgrade1Update8Height} {
+		ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, height)
+		handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+		proposalManager.InitGenesis(ctx)
+		proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+		user1 := createTestAccount(ctx, am, "user1", c4600)
+
+		result := handler(ctx, NewTextProposalMsg("user1", "title", "description"))
+		assert.True(t, result.IsOK())
+		proposalID := types.ProposalKey("1")
+		saving, _ := am.GetSavingFromBank(ctx, user1)
+		assert.True(t, c4600.Minus(proposalParam.TextProposalMinDeposit).IsEqual(saving))
+		total, err := proposalManager.GetTotalDeposit(ctx)
+		assert.Nil(t, err)
+		if height < types.BlockchainUpgrade1Update8Height {
+			// deposit is returned by coin return event
+			assert.False(t, proposalManager.DoesProposalDepositExist(ctx, proposalID))
+			assert.Equal(t, types.NewCoinFromInt64(0), total)
+			continue
+		}
+		assert.True(t, proposalManager.DoesProposalDepositExist(ctx, proposalID))
+		assert.Equal(t, proposalParam.TextProposalMinDeposit, total)
+	}
+}
+
+func TestVoteProposalOption(t *testing.T) {
+	for _, height := range []int64{0, types.BlockchainUpgrade1Update8Height} {
+		ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, height)
+		handler := NewHandler(am, proposalManager, postManager, &gm, vm)
+		proposalManager.InitGenesis(ctx)
+
+		user1 := types.AccountKey("user1")
+		user2 := types.AccountKey("user2")
+		_ = vm.AddVoter(ctx, user1, c4600)
+		_ = vm.AddVoter(ctx, user2, c46)
+		proposalID, _ := proposalManager.AddProposal(ctx, user1, &model.TextProposal{}, int64(100))
+		err := vm.StartVotingPowerSnapshot(ctx, proposalID)
+		assert.Nil(t, err)
+
+		abstain := handler(ctx, NewVoteProposalOptionMsg("user1", 1, types.VoteOptionAbstain))
+		veto := handler(ctx, NewVoteProposalOptionMsg("user2", 1, types.VoteOptionVeto))
+		if height < types.BlockchainUpgrade1Update8Height {
+			assert.Equal(t, ErrInvalidVoteOption().Result(), abstain)
+			assert.Equal(t, ErrInvalidVoteOption().Result(), veto)
+			continue
+		}
+		assert.True(t, abstain.IsOK())
+		assert.True(t, veto.IsOK())
+		tally, err := proposalManager.storage.GetProposalTally(ctx, proposalID)
+		assert.Nil(t, err)
+		assert.Equal(t, &model.ProposalTally{AbstainVotes: c4600, VetoVotes: c46}, tally)
+		v, err := vm.GetVote(ctx, proposalID, user2)
+		assert.Nil(t, err)
+		assert.Equal(t, types.VoteOptionVeto, v.GetOption())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/proposal/model"
+	"github.com/lino-network/lino/x/vote"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -53,10 +54,13 @@ func (pm ProposalManager) CreateContentCensorshipProposal(
 }
 
 // CreateProtocolUpgradeProposal - create a protocol upgrade proposal
-func (pm ProposalManager) CreateProtocolUpgradeProposal(ctx sdk.Context, link string, reason string) model.Proposal {
+func (pm ProposalManager) CreateProtocolUpgradeProposal(
+	ctx sdk.Context, link, name string, height int64, reason string) model.Proposal {
 	return &model.ProtocolUpgradeProposal{
 		Link:   link,
 		Reason: reason,
+		Name:   name,
+		Height: height,
 	}
 }
 
@@ -69,6 +73,45 @@ func (pm ProposalManager) CreateChangeParamProposal(
 	}
 }
 
+// CreateResolveFailedEventProposal - create a resolve failed event proposal
+func (pm ProposalManager) CreateResolveFailedEventProposal(
+	ctx sdk.Context, failedEventID int64, retry bool, reason string) model.Proposal {
+	return &model.ResolveFailedEventProposal{
+		FailedEventID: failedEventID,
+		Retry:         retry,
+		Reason:        reason,
+	}
+}
+
+// CreateGrantFreeScoreProposal - create a grant free score proposal
+func (pm ProposalManager) CreateGrantFreeScoreProposal(
+	ctx sdk.Context, grants []model.FreeScoreGrant, revoke bool, reason string) model.Proposal {
+	return &model.GrantFreeScoreProposal{
+		Grants: grants,
+		Revoke: revoke,
+		Reason: reason,
+	}
+}
+
+// CreateTextProposal - create a non-binding text proposal
+func (pm ProposalManager) CreateTextProposal(
+	ctx sdk.Context, title, description string) model.Proposal {
+	return &model.TextProposal{
+		Title:       title,
+		Description: description,
+	}
+}
+
+// CreateTreasurySpendProposal - create a treasury spend proposal
+func (pm ProposalManager) CreateTreasurySpendProposal(
+	ctx sdk.Context, recipient types.AccountKey, amount types.Coin, reason string) model.Proposal {
+	return &model.TreasurySpendProposal{
+		Recipient: recipient,
+		Amount:    amount,
+		Reason:    reason,
+	}
+}
+
 // GetNextProposalID - get next proposal ID from KV store
 func (pm ProposalManager) GetNextProposalID(ctx sdk.Context) (types.ProposalKey, sdk.Error) {
 	nextProposalID, err := pm.storage.GetNextProposalID(ctx)
@@ -138,30 +181,89 @@ func (pm ProposalManager) GetProposalPassParam(
 		return param.ContentCensorshipPassRatio, param.ContentCensorshipPassVotes, nil
 	case types.ProtocolUpgrade:
 		return param.ProtocolUpgradePassRatio, param.ProtocolUpgradePassVotes, nil
+	case types.ResolveFailedEvent, types.GrantFreeScore, types.TreasurySpend:
+		return param.ChangeParamPassRatio, param.ChangeParamPassVotes, nil
+	case types.TextProposal:
+		return param.ContentCensorshipPassRatio, param.ContentCensorshipPassVotes, nil
 	default:
 		return sdk.NewDec(1), types.NewCoinFromInt64(0), ErrIncorrectProposalType()
 	}
 }
 
-// UpdateProposalVotingStatus - update proposal status after voting
+// GetProposalTallyParam - based on proposal type, get quorum and veto threshold
+func (pm ProposalManager) GetProposalTallyParam(
+	ctx sdk.Context, proposalType types.ProposalType) (sdk.Dec, sdk.Dec, sdk.Error) {
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
+		return sdk.NewDec(1), sdk.NewDec(1), err
+	}
+	switch proposalType {
+	case types.ChangeParam:
+		return param.ChangeParamQuorum, param.ChangeParamVetoThreshold, nil
+	case types.ContentCensorship:
+		return param.ContentCensorshipQuorum, param.ContentCensorshipVetoThreshold, nil
+	case types.ProtocolUpgrade:
+		return param.ProtocolUpgradeQuorum, param.ProtocolUpgradeVetoThreshold, nil
+	case types.ResolveFailedEvent, types.GrantFreeScore, types.TreasurySpend:
+		return param.ChangeParamQuorum, param.ChangeParamVetoThreshold, nil
+	case types.TextProposal:
+		return param.ContentCensorshipQuorum, param.ContentCensorshipVetoThreshold, nil
+	default:
+		return sdk.NewDec(1), sdk.NewDec(1), ErrIncorrectProposalType()
+	}
+}
+
+// votesOfOption - votes counted for the option, yes and no votes are
+// kept in proposal info, abstain and veto votes are kept in tally.
+func votesOfOption(
+	info *model.ProposalInfo, tally *model.ProposalTally, option types.VoteOption) *types.Coin {
+	switch option {
+	case types.VoteOptionYes:
+		return &info.AgreeVotes
+	case types.VoteOptionAbstain:
+		return &tally.AbstainVotes
+	case types.VoteOptionVeto:
+		return &tally.VetoVotes
+	default:
+		return &info.DisagreeVotes
+	}
+}
+
+// UpdateProposalVotingStatus - update proposal status after voting, power of overridden
+// votes is taken back from their options.
 func (pm ProposalManager) UpdateProposalVotingStatus(ctx sdk.Context, proposalID types.ProposalKey,
-	voter types.AccountKey, voteResult bool, votingPower types.Coin) sdk.Error {
+	voter types.AccountKey, option types.VoteOption, votingPower types.Coin, overrides []vote.PowerOverride) sdk.Error {
 	proposal, err := pm.storage.GetOngoingProposal(ctx, proposalID)
 	if err != nil {
 		return err
 	}
 	proposalInfo := proposal.GetProposalInfo()
+	tally, err := pm.storage.GetProposalTally(ctx, proposalID)
+	if err != nil {
+		return err
+	}
 
-	if voteResult == true {
-		proposalInfo.AgreeVotes = proposalInfo.AgreeVotes.Plus(votingPower)
-	} else {
-		proposalInfo.DisagreeVotes = proposalInfo.DisagreeVotes.Plus(votingPower)
+	// tally is only stored once someone abstains or vetoes
+	tallyChanged := option == types.VoteOptionAbstain || option == types.VoteOptionVeto
+	votes := votesOfOption(&proposalInfo, tally, option)
+	*votes = votes.Plus(votingPower)
+	for _, override := range overrides {
+		if override.Option == types.VoteOptionAbstain || override.Option == types.VoteOptionVeto {
+			tallyChanged = true
+		}
+		votes := votesOfOption(&proposalInfo, tally, override.Option)
+		*votes = votes.Minus(override.Amount)
 	}
 
 	proposal.SetProposalInfo(proposalInfo)
 	if err := pm.storage.SetOngoingProposal(ctx, proposalID, proposal); err != nil {
 		return err
 	}
+	if tallyChanged {
+		if err := pm.storage.SetProposalTally(ctx, proposalID, tally); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -169,14 +271,17 @@ func (pm ProposalManager) UpdateProposalVotingStatus(ctx sdk.Context, proposalID
 // UpdateProposalPassStatus - update proposal pass status when proposal change from ongoing to expired
 func (pm ProposalManager) UpdateProposalPassStatus(
 	ctx sdk.Context, proposalType types.ProposalType,
-	proposalID types.ProposalKey) (types.ProposalResult, sdk.Error) {
-	return pm.UpdateProposalStatus(ctx, proposalType, proposalID)
+	proposalID types.ProposalKey, totalStake types.Coin) (types.ProposalResult, sdk.Error) {
+	return pm.UpdateProposalStatus(ctx, proposalType, proposalID, totalStake)
 }
 
-// UpdateProposalStatus - update proposal pass status when proposal change from ongoing to expired
+// UpdateProposalStatus - update proposal pass status when proposal change from ongoing to expired.
+// Since BlockchainUpgrade1Update8Height, proposal doesn't pass if votes are less than quorum of
+// total stake, and is vetoed if veto votes are above threshold of all votes. Abstain votes only
+// count toward quorum.
 func (pm ProposalManager) UpdateProposalStatus(
 	ctx sdk.Context, proposalType types.ProposalType,
-	proposalID types.ProposalKey) (types.ProposalResult, sdk.Error) {
+	proposalID types.ProposalKey, totalStake types.Coin) (types.ProposalResult, sdk.Error) {
 	proposal, err := pm.storage.GetOngoingProposal(ctx, proposalID)
 	if err != nil {
 		return types.ProposalNotPass, err
@@ -189,13 +294,27 @@ func (pm ProposalManager) UpdateProposalStatus(
 	if err != nil {
 		return types.ProposalNotPass, err
 	}
-	totalVotes := proposalInfo.AgreeVotes.Plus(proposalInfo.DisagreeVotes)
-	actualRatio := proposalInfo.AgreeVotes.ToDec().Quo(totalVotes.ToDec())
-
-	if !totalVotes.IsGT(minVotes) || !ratio.LT(actualRatio) {
-		proposalInfo.Result = types.ProposalNotPass
+	if ctx.BlockHeight() >= types.BlockchainUpgrade1Update8Height {
+		quorum, vetoThreshold, err := pm.GetProposalTallyParam(ctx, proposalType)
+		if err != nil {
+			return types.ProposalNotPass, err
+		}
+		tally, err := pm.storage.GetProposalTally(ctx, proposalID)
+		if err != nil {
+			return types.ProposalNotPass, err
+		}
+		proposalInfo.Result = tallyResult(
+			proposalInfo, tally, ratio, minVotes, quorum, vetoThreshold, totalStake)
+		pm.storage.DeleteProposalTally(ctx, proposalID)
 	} else {
-		proposalInfo.Result = types.ProposalPass
+		totalVotes := proposalInfo.AgreeVotes.Plus(proposalInfo.DisagreeVotes)
+		actualRatio := proposalInfo.AgreeVotes.ToDec().Quo(totalVotes.ToDec())
+
+		if !totalVotes.IsGT(minVotes) || !ratio.LT(actualRatio) {
+			proposalInfo.Result = types.ProposalNotPass
+		} else {
+			proposalInfo.Result = types.ProposalPass
+		}
 	}
 
 	proposal.SetProposalInfo(proposalInfo)
@@ -209,6 +328,30 @@ func (pm ProposalManager) UpdateProposalStatus(
 	return proposalInfo.Result, nil
 }
 
+// tallyResult - result of proposal with quorum, abstain and veto, quorum or veto
+// threshold is nil in ProposalParam stored before they are added, the check is skipped.
+func tallyResult(
+	info model.ProposalInfo, tally *model.ProposalTally, ratio sdk.Dec, minVotes types.Coin,
+	quorum, vetoThreshold sdk.Dec, totalStake types.Coin) types.ProposalResult {
+	totalVotes := info.AgreeVotes.Plus(info.DisagreeVotes).
+		Plus(tally.AbstainVotes).Plus(tally.VetoVotes)
+	if !quorum.IsNil() && totalStake.IsPositive() && totalVotes.ToDec().Quo(totalStake.ToDec()).LT(quorum) {
+		return types.ProposalNotPass
+	}
+	if !vetoThreshold.IsNil() && totalVotes.IsPositive() && tally.VetoVotes.ToDec().Quo(totalVotes.ToDec()).GT(vetoThreshold) {
+		return types.ProposalVetoed
+	}
+	// abstain votes are not counted in pass ratio
+	countedVotes := info.AgreeVotes.Plus(info.DisagreeVotes).Plus(tally.VetoVotes)
+	if !countedVotes.IsPositive() || !countedVotes.IsGT(minVotes) {
+		return types.ProposalNotPass
+	}
+	if !ratio.LT(info.AgreeVotes.ToDec().Quo(countedVotes.ToDec())) {
+		return types.ProposalNotPass
+	}
+	return types.ProposalPass
+}
+
 // CreateDecideProposalEvent - create a decide proposal event
 func (pm ProposalManager) CreateDecideProposalEvent(
 	ctx sdk.Context, proposalType types.ProposalType, proposalID types.ProposalKey) types.Event {
@@ -252,6 +395,166 @@ func (pm ProposalManager) GetPermlink(ctx sdk.Context, proposalID types.Proposal
 	return p.Permlink, nil
 }
 
+// GetUpgradePlanOfProposal - get upgrade plan from expired protocol upgrade proposal,
+// returns nil if the proposal doesn't schedule an upgrade.
+func (pm ProposalManager) GetUpgradePlanOfProposal(
+	ctx sdk.Context, proposalID types.ProposalKey) (*model.UpgradePlan, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := proposal.(*model.ProtocolUpgradeProposal)
+	if !ok {
+		return nil, ErrIncorrectProposalType()
+	}
+	if len(p.Name) == 0 {
+		return nil, nil
+	}
+	return &model.UpgradePlan{
+		Name:       p.Name,
+		Height:     p.Height,
+		Link:       p.Link,
+		ProposalID: proposalID,
+	}, nil
+}
+
+// ScheduleUpgrade - schedule upgrade plan, only one upgrade is scheduled at a time,
+// returns error if another upgrade is pending.
+func (pm ProposalManager) ScheduleUpgrade(ctx sdk.Context, plan *model.UpgradePlan) sdk.Error {
+	if pm.storage.DoesUpgradePlanExist(ctx) {
+		pending, err := pm.storage.GetUpgradePlan(ctx)
+		if err != nil {
+			return err
+		}
+		return ErrUpgradePlanExist(pending.Name)
+	}
+	return pm.storage.SetUpgradePlan(ctx, plan)
+}
+
+// CancelUpgrade - clear scheduled upgrade plan without marking it as applied
+func (pm ProposalManager) CancelUpgrade(ctx sdk.Context) {
+	pm.storage.DeleteUpgradePlan(ctx)
+}
+
+// DoesUpgradePlanExist - check if an upgrade is scheduled
+func (pm ProposalManager) DoesUpgradePlanExist(ctx sdk.Context) bool {
+	return pm.storage.DoesUpgradePlanExist(ctx)
+}
+
+// GetUpgradePlan - get scheduled upgrade plan
+func (pm ProposalManager) GetUpgradePlan(ctx sdk.Context) (*model.UpgradePlan, sdk.Error) {
+	return pm.storage.GetUpgradePlan(ctx)
+}
+
+// IsUpgradeDone - check if upgrade with name has been applied
+func (pm ProposalManager) IsUpgradeDone(ctx sdk.Context, name string) bool {
+	return pm.storage.DoesDoneUpgradeExist(ctx, name)
+}
+
+// MarkUpgradeDone - record scheduled upgrade as applied and clear the plan
+func (pm ProposalManager) MarkUpgradeDone(ctx sdk.Context) sdk.Error {
+	plan, err := pm.storage.GetUpgradePlan(ctx)
+	if err != nil {
+		return err
+	}
+	if err := pm.storage.SetDoneUpgrade(ctx, plan); err != nil {
+		return err
+	}
+	pm.storage.DeleteUpgradePlan(ctx)
+	return nil
+}
+
+// GetFailedEventResolution - get failed event id and whether to retry it from expired proposal list
+func (pm ProposalManager) GetFailedEventResolution(
+	ctx sdk.Context, proposalID types.ProposalKey) (int64, bool, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	p, ok := proposal.(*model.ResolveFailedEventProposal)
+	if !ok {
+		return 0, false, ErrIncorrectProposalType()
+	}
+	return p.FailedEventID, p.Retry, nil
+}
+
+// GetFreeScoreGrants - get free score grants and whether to revoke them from expired proposal list
+func (pm ProposalManager) GetFreeScoreGrants(
+	ctx sdk.Context, proposalID types.ProposalKey) ([]model.FreeScoreGrant, bool, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p, ok := proposal.(*model.GrantFreeScoreProposal)
+	if !ok {
+		return nil, false, ErrIncorrectProposalType()
+	}
+	return p.Grants, p.Revoke, nil
+}
+
+// GetTreasurySpend - get recipient and amount of treasury spend from expired proposal list
+func (pm ProposalManager) GetTreasurySpend(
+	ctx sdk.Context, proposalID types.ProposalKey) (types.AccountKey, types.Coin, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return "", types.NewCoinFromInt64(0), err
+	}
+
+	p, ok := proposal.(*model.TreasurySpendProposal)
+	if !ok {
+		return "", types.NewCoinFromInt64(0), ErrIncorrectProposalType()
+	}
+	return p.Recipient, p.Amount, nil
+}
+
+// HoldProposalDeposit - keep deposit of proposal until it is decided
+func (pm ProposalManager) HoldProposalDeposit(
+	ctx sdk.Context, proposalID types.ProposalKey, creator types.AccountKey, amount types.Coin) sdk.Error {
+	return pm.storage.SetProposalDeposit(ctx, proposalID, &model.ProposalDeposit{
+		Creator: creator,
+		Amount:  amount,
+	})
+}
+
+// DoesProposalDepositExist - check if deposit of proposal is held by proposal module,
+// deposits of proposals created before BlockchainUpgrade1Update8Height are returned by coin return events.
+func (pm ProposalManager) DoesProposalDepositExist(ctx sdk.Context, proposalID types.ProposalKey) bool {
+	return pm.storage.DoesProposalDepositExist(ctx, proposalID)
+}
+
+// ReleaseProposalDeposit - remove deposit of proposal from proposal module,
+// caller either returns it to creator or burns it.
+func (pm ProposalManager) ReleaseProposalDeposit(
+	ctx sdk.Context, proposalID types.ProposalKey) (*model.ProposalDeposit, sdk.Error) {
+	deposit, err := pm.storage.GetProposalDeposit(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	pm.storage.DeleteProposalDeposit(ctx, proposalID)
+	return deposit, nil
+}
+
+// GetTotalDeposit - get sum of deposits held by proposal module
+func (pm ProposalManager) GetTotalDeposit(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	deposits, err := pm.storage.GetProposalDepositList(ctx)
+	if err != nil {
+		return total, err
+	}
+	for _, deposit := range deposits {
+		total = total.Plus(deposit.Amount)
+	}
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by proposals
+func (pm ProposalManager) RegisterInvariants(ir *types.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "deposit", pm.GetTotalDeposit)
+}
+
 // GetOngoingProposalList - get ongoing proposal list
 func (pm ProposalManager) GetOngoingProposalList(ctx sdk.Context) ([]model.Proposal, sdk.Error) {
 	return pm.storage.GetOngoingProposalList(ctx)
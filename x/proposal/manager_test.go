@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/proposal/model"
+	"github.com/lino-network/lino/x/vote"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,7 +29,7 @@ func TestUpdateProposalVotingStatus(t *testing.T) {
 		testName     string
 		proposalID   types.ProposalKey
 		voter        types.AccountKey
-		voteResult   bool
+		option       types.VoteOption
 		votingPower  types.Coin
 		wantProposal model.Proposal
 	}{
@@ -36,7 +37,7 @@ func TestUpdateProposalVotingStatus(t *testing.T) {
 			testName:    "agree vote",
 			proposalID:  proposalID1,
 			voter:       user1,
-			voteResult:  true,
+			option:      types.VoteOptionYes,
 			votingPower: types.NewCoinFromInt64(1),
 			wantProposal: &model.ContentCensorshipProposal{
 				ProposalInfo: model.ProposalInfo{
@@ -54,7 +55,7 @@ func TestUpdateProposalVotingStatus(t *testing.T) {
 			testName:    "one more agree vote",
 			proposalID:  proposalID1,
 			voter:       user1,
-			voteResult:  true,
+			option:      types.VoteOptionYes,
 			votingPower: types.NewCoinFromInt64(2),
 			wantProposal: &model.ContentCensorshipProposal{
 				ProposalInfo: model.ProposalInfo{
@@ -72,7 +73,7 @@ func TestUpdateProposalVotingStatus(t *testing.T) {
 			testName:    "one disagree vote",
 			proposalID:  proposalID1,
 			voter:       user1,
-			voteResult:  false,
+			option:      types.VoteOptionNo,
 			votingPower: types.NewCoinFromInt64(5),
 			wantProposal: &model.ContentCensorshipProposal{
 				ProposalInfo: model.ProposalInfo{
@@ -88,7 +89,7 @@ func TestUpdateProposalVotingStatus(t *testing.T) {
 		},
 	}
 	for _, tc := range testCases {
-		err := pm.UpdateProposalVotingStatus(ctx, tc.proposalID, tc.voter, tc.voteResult, tc.votingPower)
+		err := pm.UpdateProposalVotingStatus(ctx, tc.proposalID, tc.voter, tc.option, tc.votingPower, nil)
 		if err != nil {
 			t.Errorf("%s: failed to update proposal voting status, got err %v", tc.testName, err)
 		}
@@ -209,7 +210,7 @@ func TestUpdateProposalPassStatus(t *testing.T) {
 			t.Errorf("%s: failed to add proposal info, got err %v", tc.testName, err)
 		}
 
-		res, err := pm.UpdateProposalPassStatus(ctx, tc.proposalType, tc.proposalID)
+		res, err := pm.UpdateProposalPassStatus(ctx, tc.proposalType, tc.proposalID, types.NewCoinFromInt64(0))
 		if err != nil {
 			t.Errorf("%s: failed to update proposal pass status, got err %v", tc.testName, err)
 		}
@@ -232,6 +233,206 @@ func TestUpdateProposalPassStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateProposalStatusWithQuorumAndVeto(t *testing.T) {
+	ctx, _, pm, _, _, _, _ := setupTest(t, types.BlockchainUpgrade1Update8Height)
+	pm.InitGenesis(ctx)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	pv := proposalParam.ContentCensorshipPassVotes
+	zero := types.NewCoinFromInt64(0)
+	one := types.NewCoinFromInt64(1)
+	totalStake := pv.Plus(pv).Plus(pv).Plus(pv).Plus(pv).Plus(pv).Plus(pv).Plus(pv).Plus(pv).Plus(pv)
+
+	testCases := []struct {
+		testName      string
+		agreeVotes    types.Coin
+		disagreeVotes types.Coin
+		abstainVotes  types.Coin
+		vetoVotes     types.Coin
+		totalStake    types.Coin
+		wantResult    types.ProposalResult
+	}{
+		{
+			testName:      "votes below quorum",
+			agreeVotes:    pv.Plus(one),
+			disagreeVotes: zero,
+			abstainVotes:  zero,
+			vetoVotes:     zero,
+			totalStake:    totalStake,
+			wantResult:    types.ProposalNotPass,
+		},
+		{
+			testName:      "abstain votes count toward quorum only",
+			agreeVotes:    pv.Plus(one),
+			disagreeVotes: zero,
+			abstainVotes:  pv.Plus(pv),
+			vetoVotes:     zero,
+			totalStake:    totalStake,
+			wantResult:    types.ProposalPass,
+		},
+		{
+			testName:      "veto votes above threshold",
+			agreeVotes:    pv.Plus(pv),
+			disagreeVotes: zero,
+			abstainVotes:  zero,
+			vetoVotes:     pv.Plus(pv),
+			totalStake:    totalStake,
+			wantResult:    types.ProposalVetoed,
+		},
+		{
+			testName:      "veto votes below threshold count against proposal",
+			agreeVotes:    pv.Plus(pv),
+			disagreeVotes: pv,
+			abstainVotes:  zero,
+			vetoVotes:     pv,
+			totalStake:    totalStake,
+			wantResult:    types.ProposalNotPass,
+		},
+		{
+			testName:      "only abstain votes",
+			agreeVotes:    zero,
+			disagreeVotes: zero,
+			abstainVotes:  totalStake,
+			vetoVotes:     zero,
+			totalStake:    totalStake,
+			wantResult:    types.ProposalNotPass,
+		},
+		{
+			testName:      "no stake skips quorum",
+			agreeVotes:    pv.Plus(one),
+			disagreeVotes: zero,
+			abstainVotes:  zero,
+			vetoVotes:     zero,
+			totalStake:    zero,
+			wantResult:    types.ProposalPass,
+		},
+	}
+	for _, tc := range testCases {
+		proposalID, err := pm.AddProposal(ctx, "user1", &model.ContentCensorshipProposal{}, 100)
+		assert.Nil(t, err, tc.testName)
+		err = addProposalInfo(ctx, pm, proposalID, tc.agreeVotes, tc.disagreeVotes)
+		assert.Nil(t, err, tc.testName)
+		err = pm.storage.SetProposalTally(ctx, proposalID, &model.ProposalTally{
+			AbstainVotes: tc.abstainVotes,
+			VetoVotes:    tc.vetoVotes,
+		})
+		assert.Nil(t, err, tc.testName)
+
+		res, err := pm.UpdateProposalPassStatus(ctx, types.ContentCensorship, proposalID, tc.totalStake)
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, tc.wantResult, res, tc.testName)
+		proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, tc.wantResult, proposal.GetProposalInfo().Result, tc.testName)
+		tally, err := pm.storage.GetProposalTally(ctx, proposalID)
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, zero, tally.VetoVotes, tc.testName)
+	}
+}
+
+func TestTallyResultWithoutTallyParam(t *testing.T) {
+	info := model.ProposalInfo{
+		AgreeVotes:    types.NewCoinFromInt64(2),
+		DisagreeVotes: types.NewCoinFromInt64(0),
+	}
+	tally := &model.ProposalTally{
+		AbstainVotes: types.NewCoinFromInt64(0),
+		VetoVotes:    types.NewCoinFromInt64(1),
+	}
+	res := tallyResult(
+		info, tally, types.NewDecFromRat(50, 100), types.NewCoinFromInt64(0),
+		sdk.Dec{}, sdk.Dec{}, types.NewCoinFromInt64(100))
+	assert.Equal(t, types.ProposalPass, res)
+}
+
+func TestUpdateProposalVotingStatusWithOptions(t *testing.T) {
+	ctx, _, pm, _, _, _, _ := setupTest(t, types.BlockchainUpgrade1Update8Height)
+	pm.InitGenesis(ctx)
+	proposalID, _ := pm.AddProposal(ctx, "user1", &model.ContentCensorshipProposal{}, 100)
+	c10 := types.NewCoinFromInt64(10)
+	c20 := types.NewCoinFromInt64(20)
+
+	err := pm.UpdateProposalVotingStatus(ctx, proposalID, "user1", types.VoteOptionYes, c20, nil)
+	assert.Nil(t, err)
+	err = pm.UpdateProposalVotingStatus(ctx, proposalID, "user2", types.VoteOptionAbstain, c10, nil)
+	assert.Nil(t, err)
+	// user3 delegates 10 to user1 and vetoes on its own
+	err = pm.UpdateProposalVotingStatus(ctx, proposalID, "user3", types.VoteOptionVeto, c10,
+		[]vote.PowerOverride{{Voter: "user1", Option: types.VoteOptionYes, Amount: c10}})
+	assert.Nil(t, err)
+
+	proposal, err := pm.storage.GetOngoingProposal(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, c10, proposal.GetProposalInfo().AgreeVotes)
+	assert.Equal(t, types.NewCoinFromInt64(0), proposal.GetProposalInfo().DisagreeVotes)
+	tally, err := pm.storage.GetProposalTally(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.ProposalTally{AbstainVotes: c10, VetoVotes: c10}, tally)
+}
+
+func TestGetProposalTallyParam(t *testing.T) {
+	ctx, _, pm, _, _, _, _ := setupTest(t, 0)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+
+	testCases := []struct {
+		testName          string
+		proposalType      types.ProposalType
+		wantError         sdk.Error
+		wantQuorum        sdk.Dec
+		wantVetoThreshold sdk.Dec
+	}{
+		{
+			testName:          "change param proposal",
+			proposalType:      types.ChangeParam,
+			wantQuorum:        proposalParam.ChangeParamQuorum,
+			wantVetoThreshold: proposalParam.ChangeParamVetoThreshold,
+		},
+		{
+			testName:          "content censorship proposal",
+			proposalType:      types.ContentCensorship,
+			wantQuorum:        proposalParam.ContentCensorshipQuorum,
+			wantVetoThreshold: proposalParam.ContentCensorshipVetoThreshold,
+		},
+		{
+			testName:          "protocol upgrade proposal",
+			proposalType:      types.ProtocolUpgrade,
+			wantQuorum:        proposalParam.ProtocolUpgradeQuorum,
+			wantVetoThreshold: proposalParam.ProtocolUpgradeVetoThreshold,
+		},
+		{
+			testName:          "grant free score proposal",
+			proposalType:      types.GrantFreeScore,
+			wantQuorum:        proposalParam.ChangeParamQuorum,
+			wantVetoThreshold: proposalParam.ChangeParamVetoThreshold,
+		},
+		{
+			testName:          "text proposal",
+			proposalType:      types.TextProposal,
+			wantQuorum:        proposalParam.ContentCensorshipQuorum,
+			wantVetoThreshold: proposalParam.ContentCensorshipVetoThreshold,
+		},
+		{
+			testName:          "treasury spend proposal",
+			proposalType:      types.TreasurySpend,
+			wantQuorum:        proposalParam.ChangeParamQuorum,
+			wantVetoThreshold: proposalParam.ChangeParamVetoThreshold,
+		},
+		{
+			testName:     "wrong proposal type",
+			proposalType: 23,
+			wantError:    ErrIncorrectProposalType(),
+		},
+	}
+	for _, tc := range testCases {
+		quorum, vetoThreshold, err := pm.GetProposalTallyParam(ctx, tc.proposalType)
+		assert.Equal(t, tc.wantError, err, tc.testName)
+		if tc.wantError != nil {
+			continue
+		}
+		assert.Equal(t, tc.wantQuorum, quorum, tc.testName)
+		assert.Equal(t, tc.wantVetoThreshold, vetoThreshold, tc.testName)
+	}
+}
+
 func TestGetProposalPassParam(t *testing.T) {
 	ctx, _, pm, _, _, _, _ := setupTest(t, 0)
 
@@ -267,6 +468,22 @@ func TestGetProposalPassParam(t *testing.T) {
 			wantPassVotes: proposalParam.ProtocolUpgradePassVotes,
 		},
 
+		{
+			testName:      "test pass param for textProposal",
+			proposalType:  types.TextProposal,
+			wantError:     nil,
+			wantPassRatio: proposalParam.ContentCensorshipPassRatio,
+			wantPassVotes: proposalParam.ContentCensorshipPassVotes,
+		},
+
+		{
+			testName:      "test pass param for treasurySpendProposal",
+			proposalType:  types.TreasurySpend,
+			wantError:     nil,
+			wantPassRatio: proposalParam.ChangeParamPassRatio,
+			wantPassVotes: proposalParam.ChangeParamPassVotes,
+		},
+
 		{
 			testName:      "test wrong proposal type",
 			proposalType:  23,
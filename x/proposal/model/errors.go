@@ -36,3 +36,43 @@ func ErrFailedToUnmarshalProposal(err error) sdk.Error {
 func ErrFailedToUnmarshalNextProposalID(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalNextProposalID, fmt.Sprintf("failed to unmarshal next proposal id: %s", err.Error()))
 }
+
+// ErrUpgradePlanNotFound - error if upgrade plan is not found in KVStore
+func ErrUpgradePlanNotFound() sdk.Error {
+	return types.NewError(types.CodeUpgradePlanNotFound, fmt.Sprintf("upgrade plan is not found"))
+}
+
+// ErrFailedToMarshalUpgradePlan - error if marshal upgrade plan failed
+func ErrFailedToMarshalUpgradePlan(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalUpgradePlan, fmt.Sprintf("failed to marshal upgrade plan: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalUpgradePlan - error if unmarshal upgrade plan failed
+func ErrFailedToUnmarshalUpgradePlan(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalUpgradePlan, fmt.Sprintf("failed to unmarshal upgrade plan: %s", err.Error()))
+}
+
+// ErrFailedToMarshalProposalTally - error if marshal proposal tally failed
+func ErrFailedToMarshalProposalTally(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalProposalTally, fmt.Sprintf("failed to marshal proposal tally: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalProposalTally - error if unmarshal proposal tally failed
+func ErrFailedToUnmarshalProposalTally(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalProposalTally, fmt.Sprintf("failed to unmarshal proposal tally: %s", err.Error()))
+}
+
+// ErrProposalDepositNotFound - error if proposal deposit is not found in KVStore
+func ErrProposalDepositNotFound() sdk.Error {
+	return types.NewError(types.CodeProposalDepositNotFound, fmt.Sprintf("proposal deposit is not found"))
+}
+
+// ErrFailedToMarshalProposalDeposit - error if marshal proposal deposit failed
+func ErrFailedToMarshalProposalDeposit(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalDeposit, fmt.Sprintf("failed to marshal proposal deposit: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalProposalDeposit - error if unmarshal proposal deposit failed
+func ErrFailedToUnmarshalProposalDeposit(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalDeposit, fmt.Sprintf("failed to unmarshal proposal deposit: %s", err.Error()))
+}
@@ -9,6 +9,10 @@ import (
 // 1) change parameter proposal
 // 2) content censorship proposal
 // 3) protocol upgrade proposal
+// 4) resolve failed event proposal
+// 5) grant free score proposal
+// 6) text proposal
+// 7) treasury spend proposal
 type Proposal interface {
 	GetProposalInfo() ProposalInfo
 	SetProposalInfo(ProposalInfo)
@@ -52,11 +56,14 @@ func (p *ContentCensorshipProposal) GetProposalInfo() ProposalInfo { return p.Pr
 // SetProposalInfo - implements Proposal
 func (p *ContentCensorshipProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
 
-// ProtocolUpgradeProposal - protocol upgrade proposal
+// ProtocolUpgradeProposal - protocol upgrade proposal, a proposal with name
+// schedules an upgrade at height when it passes.
 type ProtocolUpgradeProposal struct {
 	ProposalInfo
 	Link   string `json:"link"`
 	Reason string `json:"reason"`
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
 }
 
 // GetProposalInfo - implements Proposal
@@ -65,6 +72,89 @@ func (p *ProtocolUpgradeProposal) GetProposalInfo() ProposalInfo { return p.Prop
 // SetProposalInfo - implements Proposal
 func (p *ProtocolUpgradeProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
 
+// ResolveFailedEventProposal - retry or discard a failed time event
+type ResolveFailedEventProposal struct {
+	ProposalInfo
+	FailedEventID int64  `json:"failed_event_id"`
+	Retry         bool   `json:"retry"`
+	Reason        string `json:"reason"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *ResolveFailedEventProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *ResolveFailedEventProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
+// FreeScoreGrant - free reputation score granted to an account
+type FreeScoreGrant struct {
+	Username types.AccountKey `json:"username"`
+	Score    types.MiniDollar `json:"score"`
+}
+
+// GrantFreeScoreProposal - grant or revoke free reputation score of accounts
+type GrantFreeScoreProposal struct {
+	ProposalInfo
+	Grants []FreeScoreGrant `json:"grants"`
+	Revoke bool             `json:"revoke"`
+	Reason string           `json:"reason"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *GrantFreeScoreProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *GrantFreeScoreProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
+// TextProposal - non-binding proposal, nothing is executed when it passes
+type TextProposal struct {
+	ProposalInfo
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *TextProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *TextProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
+// TreasurySpendProposal - spend coins from community treasury to recipient
+type TreasurySpendProposal struct {
+	ProposalInfo
+	Recipient types.AccountKey `json:"recipient"`
+	Amount    types.Coin       `json:"amount"`
+	Reason    string           `json:"reason"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *TreasurySpendProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *TreasurySpendProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
+// UpgradePlan - upgrade scheduled by a passed protocol upgrade proposal
+type UpgradePlan struct {
+	Name       string            `json:"name"`
+	Height     int64             `json:"height"`
+	Link       string            `json:"link"`
+	ProposalID types.ProposalKey `json:"proposal_id"`
+}
+
+// ProposalTally - abstain and veto votes of a proposal, agree and
+// disagree votes are kept in ProposalInfo
+type ProposalTally struct {
+	AbstainVotes types.Coin `json:"abstain_votes"`
+	VetoVotes    types.Coin `json:"veto_votes"`
+}
+
+// ProposalDeposit - deposit held until the proposal is decided,
+// returned to creator or burnt if proposal is vetoed
+type ProposalDeposit struct {
+	Creator types.AccountKey `json:"creator"`
+	Amount  types.Coin       `json:"amount"`
+}
+
 // NextProposalID - store next proposal ID to KVStore
 type NextProposalID struct {
 	NextProposalID int64 `json:"next_proposal_id"`
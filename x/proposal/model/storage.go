@@ -11,6 +11,10 @@ var (
 	nextProposalIDSubstore  = []byte{0x00}
 	ongoingProposalSubStore = []byte{0x01}
 	expiredProposalSubStore = []byte{0x02}
+	upgradePlanSubStore     = []byte{0x03}
+	doneUpgradeSubStore     = []byte{0x04}
+	proposalTallySubStore   = []byte{0x05}
+	proposalDepositSubStore = []byte{0x06}
 )
 
 // ProposalStorage - proposal storage
@@ -26,6 +30,10 @@ func NewProposalStorage(key sdk.StoreKey) ProposalStorage {
 	cdc.RegisterConcrete(&ChangeParamProposal{}, "changeParam", nil)
 	cdc.RegisterConcrete(&ProtocolUpgradeProposal{}, "upgrade", nil)
 	cdc.RegisterConcrete(&ContentCensorshipProposal{}, "censorship", nil)
+	cdc.RegisterConcrete(&ResolveFailedEventProposal{}, "resolveFailedEvent", nil)
+	cdc.RegisterConcrete(&GrantFreeScoreProposal{}, "grantFreeScore", nil)
+	cdc.RegisterConcrete(&TextProposal{}, "textProposal", nil)
+	cdc.RegisterConcrete(&TreasurySpendProposal{}, "treasurySpend", nil)
 
 	cdc.RegisterInterface((*param.Parameter)(nil), nil)
 	cdc.RegisterConcrete(param.GlobalAllocationParam{}, "allocation", nil)
@@ -38,6 +46,7 @@ func NewProposalStorage(key sdk.StoreKey) ProposalStorage {
 	cdc.RegisterConcrete(param.BandwidthParam{}, "bandwidthParam", nil)
 	cdc.RegisterConcrete(param.AccountParam{}, "accountParam", nil)
 	cdc.RegisterConcrete(param.PostParam{}, "postParam", nil)
+	cdc.RegisterConcrete(param.ReputationParam{}, "reputationParam", nil)
 
 	wire.RegisterCrypto(cdc)
 	vs := ProposalStorage{
@@ -193,6 +202,165 @@ func (ps ProposalStorage) SetNextProposalID(ctx sdk.Context, nextProposalID *Nex
 	return nil
 }
 
+// DoesUpgradePlanExist - check if an upgrade is scheduled
+func (ps ProposalStorage) DoesUpgradePlanExist(ctx sdk.Context) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(getUpgradePlanKey())
+}
+
+// GetUpgradePlan - get scheduled upgrade plan from KVStore
+func (ps ProposalStorage) GetUpgradePlan(ctx sdk.Context) (*UpgradePlan, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	planByte := store.Get(getUpgradePlanKey())
+	if planByte == nil {
+		return nil, ErrUpgradePlanNotFound()
+	}
+	plan := new(UpgradePlan)
+	if err := ps.cdc.UnmarshalBinaryLengthPrefixed(planByte, plan); err != nil {
+		return nil, ErrFailedToUnmarshalUpgradePlan(err)
+	}
+	return plan, nil
+}
+
+// SetUpgradePlan - set scheduled upgrade plan to KVStore, only one upgrade is scheduled at a time
+func (ps ProposalStorage) SetUpgradePlan(ctx sdk.Context, plan *UpgradePlan) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	planByte, err := ps.cdc.MarshalBinaryLengthPrefixed(*plan)
+	if err != nil {
+		return ErrFailedToMarshalUpgradePlan(err)
+	}
+	store.Set(getUpgradePlanKey(), planByte)
+	return nil
+}
+
+// DeleteUpgradePlan - delete scheduled upgrade plan from KVStore
+func (ps ProposalStorage) DeleteUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(getUpgradePlanKey())
+}
+
+// DoesDoneUpgradeExist - check if upgrade with name has been applied
+func (ps ProposalStorage) DoesDoneUpgradeExist(ctx sdk.Context, name string) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(GetDoneUpgradeKey(name))
+}
+
+// GetDoneUpgrade - get applied upgrade plan from KVStore
+func (ps ProposalStorage) GetDoneUpgrade(ctx sdk.Context, name string) (*UpgradePlan, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	planByte := store.Get(GetDoneUpgradeKey(name))
+	if planByte == nil {
+		return nil, ErrUpgradePlanNotFound()
+	}
+	plan := new(UpgradePlan)
+	if err := ps.cdc.UnmarshalBinaryLengthPrefixed(planByte, plan); err != nil {
+		return nil, ErrFailedToUnmarshalUpgradePlan(err)
+	}
+	return plan, nil
+}
+
+// SetDoneUpgrade - set applied upgrade plan to KVStore
+func (ps ProposalStorage) SetDoneUpgrade(ctx sdk.Context, plan *UpgradePlan) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	planByte, err := ps.cdc.MarshalBinaryLengthPrefixed(*plan)
+	if err != nil {
+		return ErrFailedToMarshalUpgradePlan(err)
+	}
+	store.Set(GetDoneUpgradeKey(plan.Name), planByte)
+	return nil
+}
+
+// GetProposalTally - get abstain and veto votes of a proposal,
+// return empty tally if nobody abstained or vetoed
+func (ps ProposalStorage) GetProposalTally(ctx sdk.Context, proposalID types.ProposalKey) (*ProposalTally, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	tallyByte := store.Get(GetProposalTallyKey(proposalID))
+	if tallyByte == nil {
+		return &ProposalTally{
+			AbstainVotes: types.NewCoinFromInt64(0),
+			VetoVotes:    types.NewCoinFromInt64(0),
+		}, nil
+	}
+	tally := new(ProposalTally)
+	if err := ps.cdc.UnmarshalBinaryLengthPrefixed(tallyByte, tally); err != nil {
+		return nil, ErrFailedToUnmarshalProposalTally(err)
+	}
+	return tally, nil
+}
+
+// SetProposalTally - set abstain and veto votes of a proposal to KVStore
+func (ps ProposalStorage) SetProposalTally(ctx sdk.Context, proposalID types.ProposalKey, tally *ProposalTally) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	tallyByte, err := ps.cdc.MarshalBinaryLengthPrefixed(*tally)
+	if err != nil {
+		return ErrFailedToMarshalProposalTally(err)
+	}
+	store.Set(GetProposalTallyKey(proposalID), tallyByte)
+	return nil
+}
+
+// DeleteProposalTally - delete abstain and veto votes of a proposal from KVStore
+func (ps ProposalStorage) DeleteProposalTally(ctx sdk.Context, proposalID types.ProposalKey) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetProposalTallyKey(proposalID))
+}
+
+// DoesProposalDepositExist - check if deposit of a proposal is held by proposal module
+func (ps ProposalStorage) DoesProposalDepositExist(ctx sdk.Context, proposalID types.ProposalKey) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(GetProposalDepositKey(proposalID))
+}
+
+// GetProposalDeposit - get deposit of a proposal from KVStore
+func (ps ProposalStorage) GetProposalDeposit(ctx sdk.Context, proposalID types.ProposalKey) (*ProposalDeposit, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	depositByte := store.Get(GetProposalDepositKey(proposalID))
+	if depositByte == nil {
+		return nil, ErrProposalDepositNotFound()
+	}
+	deposit := new(ProposalDeposit)
+	if err := ps.cdc.UnmarshalBinaryLengthPrefixed(depositByte, deposit); err != nil {
+		return nil, ErrFailedToUnmarshalProposalDeposit(err)
+	}
+	return deposit, nil
+}
+
+// SetProposalDeposit - set deposit of a proposal to KVStore
+func (ps ProposalStorage) SetProposalDeposit(ctx sdk.Context, proposalID types.ProposalKey, deposit *ProposalDeposit) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	depositByte, err := ps.cdc.MarshalBinaryLengthPrefixed(*deposit)
+	if err != nil {
+		return ErrFailedToMarshalProposalDeposit(err)
+	}
+	store.Set(GetProposalDepositKey(proposalID), depositByte)
+	return nil
+}
+
+// DeleteProposalDeposit - delete deposit of a proposal from KVStore
+func (ps ProposalStorage) DeleteProposalDeposit(ctx sdk.Context, proposalID types.ProposalKey) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetProposalDepositKey(proposalID))
+}
+
+// GetProposalDepositList - get deposits of all undecided proposals
+func (ps ProposalStorage) GetProposalDepositList(ctx sdk.Context) ([]ProposalDeposit, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iterator := store.Iterator(subspace(proposalDepositSubStore))
+	defer iterator.Close()
+
+	var depositList []ProposalDeposit
+	for ; iterator.Valid(); iterator.Next() {
+		var deposit ProposalDeposit
+		err := ps.cdc.UnmarshalBinaryLengthPrefixed(iterator.Value(), &deposit)
+		if err != nil {
+			return nil, ErrFailedToUnmarshalProposalDeposit(err)
+		}
+		depositList = append(depositList, deposit)
+	}
+
+	return depositList, nil
+}
+
 // GetOngoingProposalKey - "ongoing proposal substore" + "proposal ID"
 func GetOngoingProposalKey(proposalID types.ProposalKey) []byte {
 	return append(ongoingProposalSubStore, proposalID...)
@@ -203,6 +371,25 @@ func GetExpiredProposalKey(proposalID types.ProposalKey) []byte {
 	return append(expiredProposalSubStore, proposalID...)
 }
 
+func getUpgradePlanKey() []byte {
+	return upgradePlanSubStore
+}
+
+// GetDoneUpgradeKey - "done upgrade substore" + "upgrade name"
+func GetDoneUpgradeKey(name string) []byte {
+	return append(doneUpgradeSubStore, name...)
+}
+
+// GetProposalTallyKey - "proposal tally substore" + "proposal ID"
+func GetProposalTallyKey(proposalID types.ProposalKey) []byte {
+	return append(proposalTallySubStore, proposalID...)
+}
+
+// GetProposalDepositKey - "proposal deposit substore" + "proposal ID"
+func GetProposalDepositKey(proposalID types.ProposalKey) []byte {
+	return append(proposalDepositSubStore, proposalID...)
+}
+
 func getNextProposalIDKey() []byte {
 	return nextProposalIDSubstore
 }
@@ -48,6 +48,8 @@ func TestProposalList(t *testing.T) {
 			ContentCreatorAllocation: sdk.NewDec(0),
 			DeveloperAllocation:      sdk.NewDec(0),
 			ValidatorAllocation:      sdk.NewDec(0),
+			TreasuryPenaltyShare:     sdk.NewDec(0),
+			TreasuryFrictionShare:    sdk.NewDec(0),
 		},
 	}
 
@@ -96,6 +98,8 @@ func TestProposal(t *testing.T) {
 					ContentCreatorAllocation: sdk.NewDec(0),
 					DeveloperAllocation:      sdk.NewDec(0),
 					ValidatorAllocation:      sdk.NewDec(0),
+					TreasuryPenaltyShare:     sdk.NewDec(0),
+					TreasuryFrictionShare:    sdk.NewDec(0),
 				},
 			},
 		},
@@ -171,3 +175,80 @@ func TestNextProposalID(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, nextProposalID, id)
 }
+
+func TestUpgradePlan(t *testing.T) {
+	ctx, ps := setup(t)
+
+	assert.False(t, ps.DoesUpgradePlanExist(ctx))
+	_, err := ps.GetUpgradePlan(ctx)
+	assert.Equal(t, ErrUpgradePlanNotFound(), err)
+
+	plan := &UpgradePlan{Name: "upgrade2", Height: 100, Link: "link", ProposalID: types.ProposalKey("1")}
+	err = ps.SetUpgradePlan(ctx, plan)
+	assert.Nil(t, err)
+	assert.True(t, ps.DoesUpgradePlanExist(ctx))
+	planPtr, err := ps.GetUpgradePlan(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, plan, planPtr)
+
+	assert.False(t, ps.DoesDoneUpgradeExist(ctx, "upgrade2"))
+	err = ps.SetDoneUpgrade(ctx, plan)
+	assert.Nil(t, err)
+	ps.DeleteUpgradePlan(ctx)
+	assert.False(t, ps.DoesUpgradePlanExist(ctx))
+	assert.True(t, ps.DoesDoneUpgradeExist(ctx, "upgrade2"))
+	planPtr, err = ps.GetDoneUpgrade(ctx, "upgrade2")
+	assert.Nil(t, err)
+	assert.Equal(t, plan, planPtr)
+}
+
+func TestProposalTally(t *testing.T) {
+	ctx, ps := setup(t)
+	proposalID := types.ProposalKey("1")
+
+	tally, err := ps.GetProposalTally(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(0), tally.AbstainVotes)
+	assert.Equal(t, types.NewCoinFromInt64(0), tally.VetoVotes)
+
+	tally.AbstainVotes = types.NewCoinFromInt64(10)
+	tally.VetoVotes = types.NewCoinFromInt64(20)
+	err = ps.SetProposalTally(ctx, proposalID, tally)
+	assert.Nil(t, err)
+	tallyPtr, err := ps.GetProposalTally(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, tally, tallyPtr)
+
+	ps.DeleteProposalTally(ctx, proposalID)
+	tallyPtr, err = ps.GetProposalTally(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(0), tallyPtr.VetoVotes)
+}
+
+func TestProposalDeposit(t *testing.T) {
+	ctx, ps := setup(t)
+	proposalID := types.ProposalKey("1")
+
+	assert.False(t, ps.DoesProposalDepositExist(ctx, proposalID))
+	_, err := ps.GetProposalDeposit(ctx, proposalID)
+	assert.Equal(t, ErrProposalDepositNotFound(), err)
+
+	deposit := &ProposalDeposit{Creator: types.AccountKey("user1"), Amount: types.NewCoinFromInt64(100)}
+	err = ps.SetProposalDeposit(ctx, proposalID, deposit)
+	assert.Nil(t, err)
+	err = ps.SetProposalDeposit(ctx, types.ProposalKey("2"), deposit)
+	assert.Nil(t, err)
+	assert.True(t, ps.DoesProposalDepositExist(ctx, proposalID))
+	depositPtr, err := ps.GetProposalDeposit(ctx, proposalID)
+	assert.Nil(t, err)
+	assert.Equal(t, deposit, depositPtr)
+	depositList, err := ps.GetProposalDepositList(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []ProposalDeposit{*deposit, *deposit}, depositList)
+
+	ps.DeleteProposalDeposit(ctx, proposalID)
+	assert.False(t, ps.DoesProposalDepositExist(ctx, proposalID))
+	depositList, err = ps.GetProposalDepositList(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []ProposalDeposit{*deposit}, depositList)
+}
@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal/model"
 )
 
 var _ types.Msg = DeletePostContentMsg{}
@@ -21,7 +22,12 @@ var _ types.Msg = ChangeValidatorParamMsg{}
 var _ types.Msg = ChangeBandwidthParamMsg{}
 var _ types.Msg = ChangeAccountParamMsg{}
 var _ types.Msg = ChangePostParamMsg{}
+var _ types.Msg = ChangeReputationParamMsg{}
 var _ types.Msg = VoteProposalMsg{}
+var _ types.Msg = ResolveFailedEventMsg{}
+var _ types.Msg = GrantFreeScoreMsg{}
+var _ types.Msg = TextProposalMsg{}
+var _ types.Msg = TreasurySpendMsg{}
 
 var _ ChangeParamMsg = ChangeGlobalAllocationParamMsg{}
 var _ ChangeParamMsg = ChangeInfraInternalAllocationParamMsg{}
@@ -32,6 +38,7 @@ var _ ChangeParamMsg = ChangeValidatorParamMsg{}
 var _ ChangeParamMsg = ChangeBandwidthParamMsg{}
 var _ ChangeParamMsg = ChangeAccountParamMsg{}
 var _ ChangeParamMsg = ChangePostParamMsg{}
+var _ ChangeParamMsg = ChangeReputationParamMsg{}
 
 var _ ContentCensorshipMsg = DeletePostContentMsg{}
 
@@ -55,6 +62,8 @@ type ContentCensorshipMsg interface {
 type ProtocolUpgradeMsg interface {
 	GetCreator() types.AccountKey
 	GetLink() string
+	GetName() string
+	GetHeight() int64
 	GetReason() string
 }
 
@@ -65,11 +74,15 @@ type DeletePostContentMsg struct {
 	Reason   string           `json:"reason"`
 }
 
-// UpgradeProtocolMsg - implement of protocol upgrade msg
+// UpgradeProtocolMsg - implement of protocol upgrade msg, upgrade with name and
+// height is scheduled when the proposal passes, otherwise it is informational only.
+// Name and height are omitted when empty to keep sign bytes of earlier msgs.
 type UpgradeProtocolMsg struct {
 	Creator types.AccountKey `json:"creator"`
 	Link    string           `json:"link"`
 	Reason  string           `json:"reason"`
+	Name    string           `json:"name,omitempty"`
+	Height  int64            `json:"height,omitempty"`
 }
 
 // ChangeGlobalAllocationParamMsg - implement of change parameter msg
@@ -135,11 +148,52 @@ type ChangePostParamMsg struct {
 	Reason    string           `json:"reason"`
 }
 
-// VoteProposalMsg - implement of change parameter msg
+// ChangeReputationParamMsg - implement of change parameter msg
+type ChangeReputationParamMsg struct {
+	Creator   types.AccountKey      `json:"creator"`
+	Parameter param.ReputationParam `json:"parameter"`
+	Reason    string                `json:"reason"`
+}
+
+// ResolveFailedEventMsg - propose to retry or discard a failed time event
+type ResolveFailedEventMsg struct {
+	Creator       types.AccountKey `json:"creator"`
+	FailedEventID int64            `json:"failed_event_id"`
+	Retry         bool             `json:"retry"`
+	Reason        string           `json:"reason"`
+}
+
+// GrantFreeScoreMsg - propose to grant or revoke free reputation score of accounts
+type GrantFreeScoreMsg struct {
+	Creator types.AccountKey       `json:"creator"`
+	Grants  []model.FreeScoreGrant `json:"grants"`
+	Revoke  bool                   `json:"revoke"`
+	Reason  string                 `json:"reason"`
+}
+
+// TextProposalMsg - propose a non-binding text proposal
+type TextProposalMsg struct {
+	Creator     types.AccountKey `json:"creator"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+}
+
+// TreasurySpendMsg - propose to spend coins from community treasury
+type TreasurySpendMsg struct {
+	Creator   types.AccountKey `json:"creator"`
+	Recipient types.AccountKey `json:"recipient"`
+	Amount    types.LNO        `json:"amount"`
+	Reason    string           `json:"reason"`
+}
+
+// VoteProposalMsg - implement of change parameter msg,
+// Option is omitted when empty to keep sign bytes of yes/no votes,
+// an unspecified option falls back to Result.
 type VoteProposalMsg struct {
 	Voter      types.AccountKey  `json:"voter"`
 	ProposalID types.ProposalKey `json:"proposal_id"`
 	Result     bool              `json:"result"`
+	Option     types.VoteOption  `json:"option,omitempty"`
 }
 
 //----------------------------------------
@@ -216,11 +270,13 @@ func (msg DeletePostContentMsg) GetConsumeAmount() types.Coin {
 // UpgradeProtocolMsg Msg Implementations
 
 func NewUpgradeProtocolMsg(
-	creator, link, reason string) UpgradeProtocolMsg {
+	creator, link, name string, height int64, reason string) UpgradeProtocolMsg {
 	return UpgradeProtocolMsg{
 		Creator: types.AccountKey(creator),
 		Link:    link,
 		Reason:  reason,
+		Name:    name,
+		Height:  height,
 	}
 }
 
@@ -230,6 +286,12 @@ func (msg UpgradeProtocolMsg) GetCreator() types.AccountKey { return msg.Creator
 // GetLink - implement UpgradeProtocolMsg
 func (msg UpgradeProtocolMsg) GetLink() string { return msg.Link }
 
+// GetName - implement UpgradeProtocolMsg
+func (msg UpgradeProtocolMsg) GetName() string { return msg.Name }
+
+// GetHeight - implement UpgradeProtocolMsg
+func (msg UpgradeProtocolMsg) GetHeight() int64 { return msg.Height }
+
 // GetReason - implement UpgradeProtocolMsg
 func (msg UpgradeProtocolMsg) GetReason() string { return msg.Reason }
 
@@ -251,6 +313,15 @@ func (msg UpgradeProtocolMsg) ValidateBasic() sdk.Error {
 	if len(msg.GetLink()) > types.MaximumLinkURL {
 		return ErrInvalidLink()
 	}
+	// upgrade without name and height is not scheduled
+	if len(msg.Name) != 0 || msg.Height != 0 {
+		if len(msg.Name) == 0 || len(msg.Name) > types.MaximumLengthOfUpgradeName {
+			return ErrInvalidUpgradeName()
+		}
+		if msg.Height <= 0 {
+			return ErrInvalidUpgradeHeight()
+		}
+	}
 	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
 		return ErrReasonTooLong()
 	}
@@ -258,7 +329,8 @@ func (msg UpgradeProtocolMsg) ValidateBasic() sdk.Error {
 }
 
 func (msg UpgradeProtocolMsg) String() string {
-	return fmt.Sprintf("UpgradeProtocolMsg{Creator:%v, Link:%v}", msg.Creator, msg.GetLink())
+	return fmt.Sprintf("UpgradeProtocolMsg{Creator:%v, Link:%v, Name:%v, Height:%v}",
+		msg.Creator, msg.GetLink(), msg.Name, msg.Height)
 }
 
 // GetPermission - implement types.Msg
@@ -334,6 +406,12 @@ func (msg ChangeGlobalAllocationParamMsg) ValidateBasic() sdk.Error {
 	if msg.Parameter.GlobalGrowthRate.GT(param.AnnualInflationCeiling) {
 		return ErrIllegalParameter()
 	}
+	for _, share := range []sdk.Dec{
+		msg.Parameter.TreasuryPenaltyShare, msg.Parameter.TreasuryFrictionShare} {
+		if share.IsNil() || share.IsNegative() || share.GT(sdk.NewDec(1)) {
+			return ErrIllegalParameter()
+		}
+	}
 
 	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
 		return ErrReasonTooLong()
@@ -483,7 +561,8 @@ func (msg ChangeVoteParamMsg) ValidateBasic() sdk.Error {
 	if msg.Parameter.DelegatorCoinReturnIntervalSec <= 0 ||
 		msg.Parameter.VoterCoinReturnIntervalSec <= 0 ||
 		msg.Parameter.DelegatorCoinReturnTimes <= 0 ||
-		msg.Parameter.VoterCoinReturnTimes <= 0 {
+		msg.Parameter.VoterCoinReturnTimes <= 0 ||
+		msg.Parameter.RedelegateIntervalSec <= 0 {
 		return ErrIllegalParameter()
 	}
 
@@ -562,7 +641,8 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 	if msg.Parameter.ContentCensorshipDecideSec <= 0 ||
 		msg.Parameter.ChangeParamExecutionSec <= 0 ||
 		msg.Parameter.ChangeParamDecideSec <= 0 ||
-		msg.Parameter.ProtocolUpgradeDecideSec <= 0 {
+		msg.Parameter.ProtocolUpgradeDecideSec <= 0 ||
+		msg.Parameter.TextProposalDecideSec <= 0 {
 		return ErrIllegalParameter()
 	}
 
@@ -571,7 +651,8 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 		!msg.Parameter.ChangeParamMinDeposit.IsPositive() ||
 		!msg.Parameter.ChangeParamPassVotes.IsPositive() ||
 		!msg.Parameter.ProtocolUpgradePassVotes.IsPositive() ||
-		!msg.Parameter.ProtocolUpgradeMinDeposit.IsPositive() {
+		!msg.Parameter.ProtocolUpgradeMinDeposit.IsPositive() ||
+		!msg.Parameter.TextProposalMinDeposit.IsPositive() {
 		return ErrIllegalParameter()
 	}
 
@@ -584,6 +665,21 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 		return ErrIllegalParameter()
 	}
 
+	for _, ratio := range []sdk.Dec{
+		msg.Parameter.ContentCensorshipQuorum, msg.Parameter.ChangeParamQuorum,
+		msg.Parameter.ProtocolUpgradeQuorum} {
+		if ratio.IsNil() || ratio.IsNegative() || ratio.GT(sdk.NewDec(1)) {
+			return ErrIllegalParameter()
+		}
+	}
+	for _, threshold := range []sdk.Dec{
+		msg.Parameter.ContentCensorshipVetoThreshold, msg.Parameter.ChangeParamVetoThreshold,
+		msg.Parameter.ProtocolUpgradeVetoThreshold} {
+		if threshold.IsNil() || !threshold.IsPositive() || threshold.GT(sdk.NewDec(1)) {
+			return ErrIllegalParameter()
+		}
+	}
+
 	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
 		return ErrReasonTooLong()
 	}
@@ -731,7 +827,20 @@ func (msg ChangeValidatorParamMsg) ValidateBasic() sdk.Error {
 	if msg.Parameter.ValidatorCoinReturnIntervalSec <= 0 ||
 		msg.Parameter.ValidatorCoinReturnTimes <= 0 ||
 		msg.Parameter.AbsentCommitLimitation <= 0 ||
-		msg.Parameter.ValidatorListSize <= 0 {
+		msg.Parameter.ValidatorListSize <= 0 ||
+		msg.Parameter.ValidatorJailDurationSec <= 0 {
+		return ErrIllegalParameter()
+	}
+
+	if msg.Parameter.MaxValidatorPowerPercent <= 0 ||
+		msg.Parameter.MaxValidatorPowerPercent > 100 {
+		return ErrIllegalParameter()
+	}
+
+	if msg.Parameter.SignedBlocksWindow <= 0 ||
+		msg.Parameter.MinSignedPerWindow.IsNil() ||
+		msg.Parameter.MinSignedPerWindow.LT(sdk.ZeroDec()) ||
+		msg.Parameter.MinSignedPerWindow.GT(sdk.OneDec()) {
 		return ErrIllegalParameter()
 	}
 
@@ -923,6 +1032,86 @@ func (msg ChangePostParamMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
 
+//----------------------------------------
+// ChangeReputationParam Msg Implementations
+
+func NewChangeReputationParamMsg(
+	creator string, parameter param.ReputationParam, reason string) ChangeReputationParamMsg {
+	return ChangeReputationParamMsg{
+		Creator:   types.AccountKey(creator),
+		Parameter: parameter,
+		Reason:    reason,
+	}
+}
+
+// GetParameter - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetParameter() param.Parameter { return msg.Parameter }
+
+// GetCreator - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetCreator() types.AccountKey { return msg.Creator }
+
+// GetReason - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetReason() string { return msg.Reason }
+
+// Route - implement sdk.Msg
+func (msg ChangeReputationParamMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg ChangeReputationParamMsg) Type() string { return "ChangeReputationParamMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ChangeReputationParamMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	if msg.Parameter.BestContentIndexN < 0 ||
+		msg.Parameter.BestN <= 0 || msg.Parameter.BestN > types.MaximumReputationBestN ||
+		msg.Parameter.UserMaxN <= 0 || msg.Parameter.UserMaxN > types.MaximumReputationUserMaxN ||
+		msg.Parameter.RoundDurationSeconds <= 0 ||
+		msg.Parameter.SampleWindowSize <= 0 ||
+		msg.Parameter.DecayFactor < 0 || msg.Parameter.DecayFactor > 100 {
+		return ErrIllegalParameter()
+	}
+	if msg.Parameter.ReferralClusterCapEnabled &&
+		!msg.Parameter.ReferralClusterMaxIF.IsPositive() {
+		return ErrIllegalParameter()
+	}
+	return nil
+}
+
+func (msg ChangeReputationParamMsg) String() string {
+	return fmt.Sprintf("ChangeReputationParamMsg{Creator:%v, param:%v}", msg.Creator, msg.Parameter)
+}
+
+// GetPermission - implement types.Msg
+func (msg ChangeReputationParamMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ChangeReputationParamMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ChangeReputationParamMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ChangeReputationParamMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
 //----------------------------------------
 // ChangeBandwidthParamMsg Msg Implementations
 
@@ -1000,6 +1189,266 @@ func (msg ChangeBandwidthParamMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
 
+//----------------------------------------
+// ResolveFailedEventMsg Msg Implementations
+
+func NewResolveFailedEventMsg(
+	creator string, failedEventID int64, retry bool, reason string) ResolveFailedEventMsg {
+	return ResolveFailedEventMsg{
+		Creator:       types.AccountKey(creator),
+		FailedEventID: failedEventID,
+		Retry:         retry,
+		Reason:        reason,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg ResolveFailedEventMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg ResolveFailedEventMsg) Type() string { return "ResolveFailedEventMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ResolveFailedEventMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if msg.FailedEventID <= 0 {
+		return ErrInvalidFailedEventID()
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg ResolveFailedEventMsg) String() string {
+	return fmt.Sprintf("ResolveFailedEventMsg{Creator:%v, FailedEventID:%v, Retry:%v}",
+		msg.Creator, msg.FailedEventID, msg.Retry)
+}
+
+// GetPermission - implement types.Msg
+func (msg ResolveFailedEventMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ResolveFailedEventMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ResolveFailedEventMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ResolveFailedEventMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// GrantFreeScoreMsg Msg Implementations
+
+func NewGrantFreeScoreMsg(
+	creator string, grants []model.FreeScoreGrant, revoke bool, reason string) GrantFreeScoreMsg {
+	return GrantFreeScoreMsg{
+		Creator: types.AccountKey(creator),
+		Grants:  grants,
+		Revoke:  revoke,
+		Reason:  reason,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg GrantFreeScoreMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg GrantFreeScoreMsg) Type() string { return "GrantFreeScoreMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg GrantFreeScoreMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if len(msg.Grants) == 0 || len(msg.Grants) > types.MaximumNumOfFreeScoreGrants {
+		return ErrInvalidFreeScoreGrant()
+	}
+	granted := make(map[types.AccountKey]bool)
+	for _, grant := range msg.Grants {
+		if len(grant.Username) < types.MinimumUsernameLength ||
+			len(grant.Username) > types.MaximumUsernameLength {
+			return ErrInvalidUsername()
+		}
+		if granted[grant.Username] || !grant.Score.IsPositive() {
+			return ErrInvalidFreeScoreGrant()
+		}
+		granted[grant.Username] = true
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg GrantFreeScoreMsg) String() string {
+	return fmt.Sprintf("GrantFreeScoreMsg{Creator:%v, Grants:%v, Revoke:%v}",
+		msg.Creator, msg.Grants, msg.Revoke)
+}
+
+// GetPermission - implement types.Msg
+func (msg GrantFreeScoreMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg GrantFreeScoreMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg GrantFreeScoreMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg GrantFreeScoreMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// TextProposalMsg Msg Implementations
+func NewTextProposalMsg(creator, title, description string) TextProposalMsg {
+	return TextProposalMsg{
+		Creator:     types.AccountKey(creator),
+		Title:       title,
+		Description: description,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg TextProposalMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg TextProposalMsg) Type() string { return "TextProposalMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg TextProposalMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if len(msg.Title) == 0 ||
+		utf8.RuneCountInString(msg.Title) > types.MaximumLengthOfProposalTitle {
+		return ErrInvalidProposalTitle()
+	}
+	if utf8.RuneCountInString(msg.Description) > types.MaximumLengthOfProposalDescription {
+		return ErrProposalDescriptionTooLong()
+	}
+	return nil
+}
+
+func (msg TextProposalMsg) String() string {
+	return fmt.Sprintf("TextProposalMsg{Creator:%v, Title:%v}", msg.Creator, msg.Title)
+}
+
+// GetPermission - implement types.Msg
+func (msg TextProposalMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg TextProposalMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg TextProposalMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg TextProposalMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// TreasurySpendMsg Msg Implementations
+func NewTreasurySpendMsg(creator, recipient string, amount types.LNO, reason string) TreasurySpendMsg {
+	return TreasurySpendMsg{
+		Creator:   types.AccountKey(creator),
+		Recipient: types.AccountKey(recipient),
+		Amount:    amount,
+		Reason:    reason,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg TreasurySpendMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg TreasurySpendMsg) Type() string { return "TreasurySpendMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg TreasurySpendMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength ||
+		len(msg.Recipient) < types.MinimumUsernameLength ||
+		len(msg.Recipient) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if _, err := types.LinoToCoin(msg.Amount); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg TreasurySpendMsg) String() string {
+	return fmt.Sprintf("TreasurySpendMsg{Creator:%v, Recipient:%v, Amount:%v}",
+		msg.Creator, msg.Recipient, msg.Amount)
+}
+
+// GetPermission - implement types.Msg
+func (msg TreasurySpendMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg TreasurySpendMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg TreasurySpendMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg TreasurySpendMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
 //----------------------------------------
 // VoteProposalMsg Msg Implementations
 func NewVoteProposalMsg(voter string, proposalID int64, result bool) VoteProposalMsg {
@@ -1010,6 +1459,27 @@ func NewVoteProposalMsg(voter string, proposalID int64, result bool) VoteProposa
 	}
 }
 
+// NewVoteProposalOptionMsg - vote proposal with yes, no, abstain or veto
+func NewVoteProposalOptionMsg(voter string, proposalID int64, option types.VoteOption) VoteProposalMsg {
+	return VoteProposalMsg{
+		Voter:      types.AccountKey(voter),
+		ProposalID: types.ProposalKey(strconv.FormatInt(proposalID, 10)),
+		Result:     option == types.VoteOptionYes,
+		Option:     option,
+	}
+}
+
+// GetOption - get vote option, fall back to result if option is unspecified
+func (msg VoteProposalMsg) GetOption() types.VoteOption {
+	if msg.Option != types.VoteOptionUnspecified {
+		return msg.Option
+	}
+	if msg.Result {
+		return types.VoteOptionYes
+	}
+	return types.VoteOptionNo
+}
+
 // Route - implement sdk.Msg
 func (msg VoteProposalMsg) Route() string { return RouterKey }
 
@@ -1022,11 +1492,14 @@ func (msg VoteProposalMsg) ValidateBasic() sdk.Error {
 		len(msg.Voter) > types.MaximumUsernameLength {
 		return ErrInvalidUsername()
 	}
+	if msg.Option < types.VoteOptionUnspecified || msg.Option > types.VoteOptionVeto {
+		return ErrInvalidVoteOption()
+	}
 	return nil
 }
 
 func (msg VoteProposalMsg) String() string {
-	return fmt.Sprintf("VoteProposalMsg{Voter:%v, ProposalID:%v, Result:%v}", msg.Voter, msg.ProposalID, msg.Result)
+	return fmt.Sprintf("VoteProposalMsg{Voter:%v, ProposalID:%v, Result:%v, Option:%v}", msg.Voter, msg.ProposalID, msg.Result, msg.Option)
 }
 
 // GetPermission - implement types.Msg
@@ -1,11 +1,14 @@
 package proposal
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,6 +59,26 @@ func TestVoteProposalMsg(t *testing.T) {
 			voteProposalMsg: NewVoteProposalMsg("", 1, true),
 			expectedError:   ErrInvalidUsername(),
 		},
+		{
+			testName:        "abstain",
+			voteProposalMsg: NewVoteProposalOptionMsg("user1", 1, types.VoteOptionAbstain),
+			expectedError:   nil,
+		},
+		{
+			testName:        "veto",
+			voteProposalMsg: NewVoteProposalOptionMsg("user1", 1, types.VoteOptionVeto),
+			expectedError:   nil,
+		},
+		{
+			testName:        "unknown option is illegal",
+			voteProposalMsg: NewVoteProposalOptionMsg("user1", 1, types.VoteOption(5)),
+			expectedError:   ErrInvalidVoteOption(),
+		},
+		{
+			testName:        "negative option is illegal",
+			voteProposalMsg: NewVoteProposalOptionMsg("user1", 1, types.VoteOption(-1)),
+			expectedError:   ErrInvalidVoteOption(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -66,6 +89,15 @@ func TestVoteProposalMsg(t *testing.T) {
 	}
 }
 
+func TestVoteProposalMsgGetOption(t *testing.T) {
+	assert.Equal(t, types.VoteOptionYes, NewVoteProposalMsg("user1", 1, true).GetOption())
+	assert.Equal(t, types.VoteOptionNo, NewVoteProposalMsg("user1", 1, false).GetOption())
+	assert.Equal(t, types.VoteOptionAbstain, NewVoteProposalOptionMsg("user1", 1, types.VoteOptionAbstain).GetOption())
+	msg := NewVoteProposalOptionMsg("user1", 1, types.VoteOptionVeto)
+	assert.Equal(t, types.VoteOptionVeto, msg.GetOption())
+	assert.False(t, msg.Result)
+}
+
 func TestChangeGlobalAllocationParamMsg(t *testing.T) {
 	p1 := param.GlobalAllocationParam{
 		GlobalGrowthRate:         types.NewDecFromRat(98, 1000),
@@ -73,6 +105,8 @@ func TestChangeGlobalAllocationParamMsg(t *testing.T) {
 		ContentCreatorAllocation: types.NewDecFromRat(55, 100),
 		DeveloperAllocation:      types.NewDecFromRat(20, 100),
 		ValidatorAllocation:      types.NewDecFromRat(5, 100),
+		TreasuryPenaltyShare:     types.NewDecFromRat(50, 100),
+		TreasuryFrictionShare:    types.NewDecFromRat(5, 100),
 	}
 	p2 := p1
 	p2.DeveloperAllocation = types.NewDecFromRat(25, 100)
@@ -80,6 +114,15 @@ func TestChangeGlobalAllocationParamMsg(t *testing.T) {
 	p3 := p1
 	p3.GlobalGrowthRate = types.NewDecFromRat(1, 10)
 
+	p4 := p1
+	p4.TreasuryPenaltyShare = types.NewDecFromRat(101, 100)
+
+	p5 := p1
+	p5.TreasuryFrictionShare = types.NewDecFromRat(-1, 100)
+
+	p6 := p1
+	p6.TreasuryPenaltyShare = sdk.Dec{}
+
 	testCases := []struct {
 		testName                       string
 		ChangeGlobalAllocationParamMsg ChangeGlobalAllocationParamMsg
@@ -100,6 +143,21 @@ func TestChangeGlobalAllocationParamMsg(t *testing.T) {
 			ChangeGlobalAllocationParamMsg: NewChangeGlobalAllocationParamMsg("user1", p3, ""),
 			expectedError:                  ErrIllegalParameter(),
 		},
+		{
+			testName:                       "treasury penalty share exceeds one",
+			ChangeGlobalAllocationParamMsg: NewChangeGlobalAllocationParamMsg("user1", p4, ""),
+			expectedError:                  ErrIllegalParameter(),
+		},
+		{
+			testName:                       "negative treasury friction share",
+			ChangeGlobalAllocationParamMsg: NewChangeGlobalAllocationParamMsg("user1", p5, ""),
+			expectedError:                  ErrIllegalParameter(),
+		},
+		{
+			testName:                       "missing treasury penalty share",
+			ChangeGlobalAllocationParamMsg: NewChangeGlobalAllocationParamMsg("user1", p6, ""),
+			expectedError:                  ErrIllegalParameter(),
+		},
 		{
 			testName:                       "empty username is illegal",
 			ChangeGlobalAllocationParamMsg: NewChangeGlobalAllocationParamMsg("", p1, ""),
@@ -194,6 +252,7 @@ func TestChangeVoteParamMsg(t *testing.T) {
 		VoterCoinReturnTimes:           int64(7),
 		DelegatorCoinReturnIntervalSec: int64(7 * 24 * 3600),
 		DelegatorCoinReturnTimes:       int64(7),
+		RedelegateIntervalSec:          int64(7 * 24 * 3600),
 	}
 
 	p2 := p1
@@ -211,6 +270,12 @@ func TestChangeVoteParamMsg(t *testing.T) {
 	p6 := p1
 	p6.DelegatorCoinReturnTimes = int64(0)
 
+	p7 := p1
+	p7.RedelegateIntervalSec = int64(-1)
+
+	p8 := p1
+	p8.RedelegateIntervalSec = int64(0)
+
 	testCases := []struct {
 		testName           string
 		ChangeVoteParamMsg ChangeVoteParamMsg
@@ -246,6 +311,16 @@ func TestChangeVoteParamMsg(t *testing.T) {
 			ChangeVoteParamMsg: NewChangeVoteParamMsg("user1", p5, ""),
 			expectedError:      ErrIllegalParameter(),
 		},
+		{
+			testName:           "negative RedelegateIntervalSec is illegal",
+			ChangeVoteParamMsg: NewChangeVoteParamMsg("user1", p7, ""),
+			expectedError:      ErrIllegalParameter(),
+		},
+		{
+			testName:           "zero RedelegateIntervalSec is illegal",
+			ChangeVoteParamMsg: NewChangeVoteParamMsg("user1", p8, ""),
+			expectedError:      ErrIllegalParameter(),
+		},
 		{
 			testName:           "empty username is illegal",
 			ChangeVoteParamMsg: NewChangeVoteParamMsg("", p1, ""),
@@ -353,6 +428,10 @@ func TestChangeValidatorParamMsg(t *testing.T) {
 		PenaltyByzantine:               types.NewCoinFromInt64(1000 * types.Decimals),
 		ValidatorListSize:              int64(21),
 		AbsentCommitLimitation:         int64(100),
+		ValidatorJailDurationSec:       int64(24 * 3600),
+		SignedBlocksWindow:             int64(2400),
+		MinSignedPerWindow:             types.NewDecFromRat(75, 100),
+		MaxValidatorPowerPercent:       int64(10),
 	}
 
 	p2 := p1
@@ -385,6 +464,24 @@ func TestChangeValidatorParamMsg(t *testing.T) {
 	p11 := p1
 	p11.ValidatorListSize = int64(-1)
 
+	p12 := p1
+	p12.ValidatorJailDurationSec = int64(-1)
+
+	p13 := p1
+	p13.SignedBlocksWindow = int64(0)
+
+	p14 := p1
+	p14.MinSignedPerWindow = types.NewDecFromRat(101, 100)
+
+	p15 := p1
+	p15.MaxValidatorPowerPercent = int64(0)
+
+	p16 := p1
+	p16.MaxValidatorPowerPercent = int64(101)
+
+	p17 := p1
+	p17.ValidatorJailDurationSec = int64(0)
+
 	testCases := []struct {
 		testName                string
 		ChangeValidatorParamMsg ChangeValidatorParamMsg
@@ -445,6 +542,36 @@ func TestChangeValidatorParamMsg(t *testing.T) {
 			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p11, ""),
 			expectedError:           ErrIllegalParameter(),
 		},
+		{
+			testName:                "negative ValidatorJailDurationSec is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p12, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
+		{
+			testName:                "zero ValidatorJailDurationSec is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p17, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
+		{
+			testName:                "zero SignedBlocksWindow is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p13, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
+		{
+			testName:                "MinSignedPerWindow larger than one is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p14, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
+		{
+			testName:                "zero MaxValidatorPowerPercent is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p15, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
+		{
+			testName:                "MaxValidatorPowerPercent larger than 100 is illegal",
+			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("user1", p16, ""),
+			expectedError:           ErrIllegalParameter(),
+		},
 		{
 			testName:                "empty username is illegal",
 			ChangeValidatorParamMsg: NewChangeValidatorParamMsg("", p1, ""),
@@ -489,6 +616,16 @@ func TestChangeProposalParamMsg(t *testing.T) {
 		ProtocolUpgradePassRatio:  types.NewDecFromRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
+
+		ContentCensorshipQuorum:        types.NewDecFromRat(20, 100),
+		ContentCensorshipVetoThreshold: types.NewDecFromRat(334, 1000),
+		ChangeParamQuorum:              types.NewDecFromRat(20, 100),
+		ChangeParamVetoThreshold:       types.NewDecFromRat(334, 1000),
+		ProtocolUpgradeQuorum:          types.NewDecFromRat(40, 100),
+		ProtocolUpgradeVetoThreshold:   types.NewDecFromRat(334, 1000),
 	}
 
 	p2 := p1
@@ -527,6 +664,24 @@ func TestChangeProposalParamMsg(t *testing.T) {
 	p13 := p1
 	p13.ProtocolUpgradeMinDeposit = types.NewCoinFromInt64(-1000000 * types.Decimals)
 
+	p14 := p1
+	p14.TextProposalDecideSec = int64(0)
+
+	p15 := p1
+	p15.TextProposalMinDeposit = types.NewCoinFromInt64(0)
+
+	p16 := p1
+	p16.ChangeParamQuorum = types.NewDecFromRat(101, 100)
+
+	p17 := p1
+	p17.ContentCensorshipQuorum = types.NewDecFromRat(-1, 100)
+
+	p18 := p1
+	p18.ProtocolUpgradeVetoThreshold = types.NewDecFromRat(0, 100)
+
+	p19 := p1
+	p19.ChangeParamQuorum = types.NewDecFromRat(0, 100)
+
 	testCases := []struct {
 		testName               string
 		ChangeProposalParamMsg ChangeProposalParamMsg
@@ -602,6 +757,36 @@ func TestChangeProposalParamMsg(t *testing.T) {
 			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p13, ""),
 			expectedError:          ErrIllegalParameter(),
 		},
+		{
+			testName:               "zero TextProposalDecideSec is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p14, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "zero TextProposalMinDeposit is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p15, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "ChangeParamQuorum larger than one is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p16, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "negative ContentCensorshipQuorum is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p17, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "zero ProtocolUpgradeVetoThreshold is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p18, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "zero ChangeParamQuorum is legal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p19, ""),
+			expectedError:          nil,
+		},
 		{
 			testName: "reason is too long",
 			ChangeProposalParamMsg: NewChangeProposalParamMsg(
@@ -839,6 +1024,115 @@ func TestChangePostParamMsg(t *testing.T) {
 	}
 }
 
+func TestChangeReputationParamMsg(t *testing.T) {
+	p1 := param.ReputationParam{
+		BestContentIndexN:         10,
+		BestN:                     200,
+		UserMaxN:                  50,
+		RoundDurationSeconds:      25 * 3600,
+		SampleWindowSize:          10,
+		DecayFactor:               10,
+		ReferralClusterCapEnabled: false,
+		ReferralClusterMaxIF:      types.NewMiniDollar(10 * 100000000),
+	}
+
+	p2 := p1
+	p2.BestN = 0
+
+	p3 := p1
+	p3.UserMaxN = -1
+
+	p4 := p1
+	p4.RoundDurationSeconds = 0
+
+	p5 := p1
+	p5.SampleWindowSize = 0
+
+	p6 := p1
+	p6.DecayFactor = 101
+
+	p7 := p1
+	p7.ReferralClusterCapEnabled = true
+
+	p8 := p7
+	p8.ReferralClusterMaxIF = types.NewMiniDollar(0)
+
+	p9 := p1
+	p9.BestN = types.MaximumReputationBestN + 1
+
+	p10 := p1
+	p10.UserMaxN = types.MaximumReputationUserMaxN + 1
+
+	testCases := []struct {
+		testName                 string
+		changeReputationParamMsg ChangeReputationParamMsg
+		expectedError            sdk.Error
+	}{
+		{
+			testName:                 "normal case",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p1, ""),
+			expectedError:            nil,
+		},
+		{
+			testName:                 "illegal best n",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p2, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "illegal user max n",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p3, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "best n too large",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p9, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "user max n too large",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p10, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "illegal round duration",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p4, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "illegal sample window size",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p5, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "illegal decay factor",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p6, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "referral cluster cap enabled",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p7, ""),
+			expectedError:            nil,
+		},
+		{
+			testName:                 "illegal referral cluster max impact factor",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p8, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "username too short",
+			changeReputationParamMsg: NewChangeReputationParamMsg("us", p1, ""),
+			expectedError:            ErrInvalidUsername(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.changeReputationParamMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestDeletePostContentMsg(t *testing.T) {
 	testCases := []struct {
 		testName             string
@@ -895,34 +1189,60 @@ func TestUpgradeProtocolMsg(t *testing.T) {
 	}{
 		{
 			testName:           "normal case",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", ""),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", "", 0, ""),
 			expectedError:      nil,
 		},
 		{
 			testName:           "too short username is illegal",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("us", "link", ""),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("us", "link", "", 0, ""),
 			expectedError:      ErrInvalidUsername(),
 		},
 		{
 			testName:           "too long username is illegal",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1user1user1user1user1user1", "link", ""),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1user1user1user1user1user1", "link", "", 0, ""),
 			expectedError:      ErrInvalidUsername(),
 		},
 		{
 			testName:           "empty link is illegal",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", ""),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", "", 0, ""),
 			expectedError:      ErrInvalidLink(),
 		},
 		{
 			testName:           "reason is too long",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", string(make([]byte, types.MaximumLengthOfProposalReason+1))),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", "", 0, string(make([]byte, types.MaximumLengthOfProposalReason+1))),
 			expectedError:      ErrInvalidLink(),
 		},
 		{
 			testName:           "utf8 reason is too long",
-			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", tooLongOfUTF8Reason),
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "", "", 0, tooLongOfUTF8Reason),
 			expectedError:      ErrInvalidLink(),
 		},
+		{
+			testName:           "scheduled upgrade",
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", "upgrade2", 100, ""),
+			expectedError:      nil,
+		},
+		{
+			testName:           "upgrade height without name is illegal",
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", "", 100, ""),
+			expectedError:      ErrInvalidUpgradeName(),
+		},
+		{
+			testName: "too long upgrade name is illegal",
+			upgradeProtocolMsg: NewUpgradeProtocolMsg(
+				"user1", "link", strings.Repeat("a", types.MaximumLengthOfUpgradeName+1), 100, ""),
+			expectedError: ErrInvalidUpgradeName(),
+		},
+		{
+			testName:           "upgrade name without height is illegal",
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", "upgrade2", 0, ""),
+			expectedError:      ErrInvalidUpgradeHeight(),
+		},
+		{
+			testName:           "negative upgrade height is illegal",
+			upgradeProtocolMsg: NewUpgradeProtocolMsg("user1", "link", "upgrade2", -1, ""),
+			expectedError:      ErrInvalidUpgradeHeight(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -933,6 +1253,212 @@ func TestUpgradeProtocolMsg(t *testing.T) {
 	}
 }
 
+func TestResolveFailedEventMsg(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		msg           ResolveFailedEventMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			msg:           NewResolveFailedEventMsg("user1", 1, true, ""),
+			expectedError: nil,
+		},
+		{
+			testName:      "too short username is illegal",
+			msg:           NewResolveFailedEventMsg("us", 1, true, ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "zero failed event id is illegal",
+			msg:           NewResolveFailedEventMsg("user1", 0, false, ""),
+			expectedError: ErrInvalidFailedEventID(),
+		},
+		{
+			testName:      "utf8 reason is too long",
+			msg:           NewResolveFailedEventMsg("user1", 1, false, tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestGrantFreeScoreMsg(t *testing.T) {
+	grant1 := model.FreeScoreGrant{Username: "user1", Score: types.NewMiniDollar(100)}
+	grant2 := model.FreeScoreGrant{Username: "user2", Score: types.NewMiniDollar(200)}
+	tooManyGrants := []model.FreeScoreGrant{}
+	for i := 0; i <= types.MaximumNumOfFreeScoreGrants; i++ {
+		tooManyGrants = append(tooManyGrants, model.FreeScoreGrant{
+			Username: types.AccountKey(fmt.Sprintf("user%d", i)), Score: types.NewMiniDollar(1)})
+	}
+
+	testCases := []struct {
+		testName      string
+		msg           GrantFreeScoreMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			msg:           NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{grant1, grant2}, false, ""),
+			expectedError: nil,
+		},
+		{
+			testName:      "too short username is illegal",
+			msg:           NewGrantFreeScoreMsg("us", []model.FreeScoreGrant{grant1}, false, ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "empty grants",
+			msg:           NewGrantFreeScoreMsg("user1", nil, false, ""),
+			expectedError: ErrInvalidFreeScoreGrant(),
+		},
+		{
+			testName:      "too many grants",
+			msg:           NewGrantFreeScoreMsg("user1", tooManyGrants, false, ""),
+			expectedError: ErrInvalidFreeScoreGrant(),
+		},
+		{
+			testName: "too short granted username is illegal",
+			msg: NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{
+				{Username: "us", Score: types.NewMiniDollar(100)}}, false, ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "duplicate username",
+			msg:           NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{grant1, grant1}, true, ""),
+			expectedError: ErrInvalidFreeScoreGrant(),
+		},
+		{
+			testName: "zero score",
+			msg: NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{
+				{Username: "user1", Score: types.NewMiniDollar(0)}}, false, ""),
+			expectedError: ErrInvalidFreeScoreGrant(),
+		},
+		{
+			testName: "negative score",
+			msg: NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{
+				{Username: "user1", Score: types.NewMiniDollar(-1)}}, true, ""),
+			expectedError: ErrInvalidFreeScoreGrant(),
+		},
+		{
+			testName:      "utf8 reason is too long",
+			msg:           NewGrantFreeScoreMsg("user1", []model.FreeScoreGrant{grant1}, false, tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestTextProposalMsg(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		msg           TextProposalMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			msg:           NewTextProposalMsg("user1", "title", maxLenOfUTF8Reason),
+			expectedError: nil,
+		},
+		{
+			testName:      "empty description",
+			msg:           NewTextProposalMsg("user1", "title", ""),
+			expectedError: nil,
+		},
+		{
+			testName:      "too short username is illegal",
+			msg:           NewTextProposalMsg("us", "title", ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "empty title is illegal",
+			msg:           NewTextProposalMsg("user1", "", "description"),
+			expectedError: ErrInvalidProposalTitle(),
+		},
+		{
+			testName:      "max length utf8 title",
+			msg:           NewTextProposalMsg("user1", strings.Repeat("你", types.MaximumLengthOfProposalTitle), ""),
+			expectedError: nil,
+		},
+		{
+			testName:      "utf8 title is too long",
+			msg:           NewTextProposalMsg("user1", strings.Repeat("你", types.MaximumLengthOfProposalTitle+1), ""),
+			expectedError: ErrInvalidProposalTitle(),
+		},
+		{
+			testName: "utf8 description is too long",
+			msg: NewTextProposalMsg(
+				"user1", "title", strings.Repeat("👌", types.MaximumLengthOfProposalDescription+1)),
+			expectedError: ErrProposalDescriptionTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestTreasurySpendMsg(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		msg           TreasurySpendMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			msg:           NewTreasurySpendMsg("user1", "user2", "100", maxLenOfUTF8Reason),
+			expectedError: nil,
+		},
+		{
+			testName:      "too short creator is illegal",
+			msg:           NewTreasurySpendMsg("us", "user2", "100", ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "too short recipient is illegal",
+			msg:           NewTreasurySpendMsg("user1", "us", "100", ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "illegal amount",
+			msg:           NewTreasurySpendMsg("user1", "user2", "1a", ""),
+			expectedError: types.ErrInvalidCoins("Illegal LNO"),
+		},
+		{
+			testName:      "zero amount",
+			msg:           NewTreasurySpendMsg("user1", "user2", "0", ""),
+			expectedError: types.ErrInvalidCoins("LNO can't be less than lower bound"),
+		},
+		{
+			testName:      "utf8 reason is too long",
+			msg:           NewTreasurySpendMsg("user1", "user2", "100", tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestMsgPermission(t *testing.T) {
 	testCases := []struct {
 		testName         string
@@ -947,7 +1473,7 @@ func TestMsgPermission(t *testing.T) {
 		},
 		{
 			testName:         "upgrade protocol msg",
-			msg:              NewUpgradeProtocolMsg("creator", "link", ""),
+			msg:              NewUpgradeProtocolMsg("creator", "link", "", 0, ""),
 			expectPermission: types.TransactionPermission,
 		},
 		{
@@ -1004,11 +1530,27 @@ func TestMsgPermission(t *testing.T) {
 				"creator", param.PostParam{}, ""),
 			expectPermission: types.TransactionPermission,
 		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+			expectPermission: types.TransactionPermission,
+		},
 		{
 			testName:         "vote proposal msg",
 			msg:              NewVoteProposalMsg("voter", 1, true),
 			expectPermission: types.TransactionPermission,
 		},
+		{
+			testName:         "text proposal msg",
+			msg:              NewTextProposalMsg("creator", "title", "description"),
+			expectPermission: types.TransactionPermission,
+		},
+		{
+			testName:         "treasury spend msg",
+			msg:              NewTreasurySpendMsg("creator", "recipient", "100", "reason"),
+			expectPermission: types.TransactionPermission,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1032,7 +1574,7 @@ func TestGetSignBytes(t *testing.T) {
 		},
 		{
 			testName: "upgrade protocol msg",
-			msg:      NewUpgradeProtocolMsg("creator", "link", ""),
+			msg:      NewUpgradeProtocolMsg("creator", "link", "", 0, ""),
 		},
 		{
 			testName: "change global allocaiton param msg",
@@ -1079,10 +1621,27 @@ func TestGetSignBytes(t *testing.T) {
 			msg: NewChangePostParamMsg(
 				"creator", param.PostParam{}, ""),
 		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+		},
 		{
 			testName: "vote proposal msg",
 			msg:      NewVoteProposalMsg("voter", 1, true),
 		},
+		{
+			testName: "veto proposal msg",
+			msg:      NewVoteProposalOptionMsg("voter", 1, types.VoteOptionVeto),
+		},
+		{
+			testName: "text proposal msg",
+			msg:      NewTextProposalMsg("creator", "title", "description"),
+		},
+		{
+			testName: "treasury spend msg",
+			msg:      NewTreasurySpendMsg("creator", "recipient", "100", "reason"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1104,7 +1663,7 @@ func TestGetSigners(t *testing.T) {
 		},
 		{
 			testName:      "upgrade protocol msg",
-			msg:           NewUpgradeProtocolMsg("creator", "link", ""),
+			msg:           NewUpgradeProtocolMsg("creator", "link", "", 0, ""),
 			expectSigners: []types.AccountKey{"creator"},
 		},
 		{
@@ -1161,11 +1720,22 @@ func TestGetSigners(t *testing.T) {
 				"creator", param.PostParam{}, ""),
 			expectSigners: []types.AccountKey{"creator"},
 		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+			expectSigners: []types.AccountKey{"creator"},
+		},
 		{
 			testName:      "vote proposal msg",
 			msg:           NewVoteProposalMsg("voter", 1, true),
 			expectSigners: []types.AccountKey{"voter"},
 		},
+		{
+			testName:      "text proposal msg",
+			msg:           NewTextProposalMsg("creator", "title", "description"),
+			expectSigners: []types.AccountKey{"creator"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -20,6 +20,7 @@ const (
 	QueryNextProposal    = "next"
 	QueryOngoingProposal = "ongoing"
 	QueryExpiredProposal = "expired"
+	QueryUpgradePlan     = "upgradePlan"
 )
 
 // creates a querier for proposal REST endpoints
@@ -32,6 +33,8 @@ func NewQuerier(pm ProposalManager) sdk.Querier {
 			return queryOngoingProposal(ctx, cdc, path[1:], req, pm)
 		case QueryExpiredProposal:
 			return queryExpiredProposal(ctx, cdc, path[1:], req, pm)
+		case QueryUpgradePlan:
+			return queryUpgradePlan(ctx, cdc, path[1:], req, pm)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown proposal query endpoint")
 		}
@@ -67,3 +70,15 @@ func queryExpiredProposal(ctx sdk.Context, cdc *wire.Codec, path []string, req a
 	}
 	return res, nil
 }
+
+func queryUpgradePlan(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, pm ProposalManager) ([]byte, sdk.Error) {
+	plan, err := pm.GetUpgradePlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(plan)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
@@ -18,6 +18,11 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(ChangeBandwidthParamMsg{}, "lino/changeBandwidthParam", nil)
 	cdc.RegisterConcrete(ChangeAccountParamMsg{}, "lino/changeAccountParam", nil)
 	cdc.RegisterConcrete(ChangePostParamMsg{}, "lino/changePostParam", nil)
+	cdc.RegisterConcrete(ChangeReputationParamMsg{}, "lino/changeReputationParam", nil)
+	cdc.RegisterConcrete(ResolveFailedEventMsg{}, "lino/resolveFailedEvent", nil)
+	cdc.RegisterConcrete(GrantFreeScoreMsg{}, "lino/grantFreeScore", nil)
+	cdc.RegisterConcrete(TextProposalMsg{}, "lino/textProposal", nil)
+	cdc.RegisterConcrete(TreasurySpendMsg{}, "lino/treasurySpend", nil)
 }
 
 var msgCdc = wire.New()
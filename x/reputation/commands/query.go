@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/lino-network/lino/client"
+	rep "github.com/lino-network/lino/x/reputation"
+	"github.com/lino-network/lino/x/reputation/repv2"
+)
+
+// GetRoundCmd returns leaderboard and seed set of a reputation round
+func GetRoundCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reputation-round [round]",
+		Short: "Query leaderboard and seed set of a reputation round, default is the current round",
+		RunE:  getRoundCmd(cdc),
+	}
+}
+
+// GetUserInfoCmd returns breakdown of a user's reputation
+func GetUserInfoCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reputation-user <username>",
+		Short: "Query breakdown of a user's reputation",
+		RunE:  getUserInfoCmd(cdc),
+	}
+}
+
+// GetFreeScoreCmd returns free score granted to a user by proposals
+func GetFreeScoreCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reputation-free-score <username>",
+		Short: "Query free reputation score granted to a user by proposals",
+		RunE:  getFreeScoreCmd(cdc),
+	}
+}
+
+// GetHistoryCmd returns reputation of a user settled in past rounds
+func GetHistoryCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reputation-history <username>",
+		Short: "Query reputation of a user settled in past rounds",
+		RunE:  getHistoryCmd(cdc),
+	}
+}
+
+func getRoundCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) > 1 {
+			return errors.New("You can provide at most one round")
+		}
+
+		var res []byte
+		var err error
+		if len(args) == 0 {
+			res, err = ctx.QueryCustom(rep.QuerierRoute, rep.QueryCurrentRound)
+		} else {
+			res, err = ctx.QueryCustom(rep.QuerierRoute, rep.QueryRound, args[0])
+		}
+		if err != nil {
+			return err
+		}
+		info := repv2.RoundInfo{}
+		if err := cdc.UnmarshalJSON(res, &info); err != nil {
+			return err
+		}
+		output, err := wire.MarshalJSONIndent(cdc, info)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
+
+func getUserInfoCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a username")
+		}
+
+		res, err := ctx.QueryCustom(rep.QuerierRoute, rep.QueryUserInfo, args[0])
+		if err != nil {
+			return err
+		}
+		info := repv2.UserInfo{}
+		if err := cdc.UnmarshalJSON(res, &info); err != nil {
+			return err
+		}
+		output, err := wire.MarshalJSONIndent(cdc, info)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
+
+func getFreeScoreCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a username")
+		}
+
+		res, err := ctx.QueryCustom(rep.QuerierRoute, rep.QueryFreeScore, args[0])
+		if err != nil {
+			return err
+		}
+		records := []rep.FreeScoreRecord{}
+		if err := cdc.UnmarshalJSON(res, &records); err != nil {
+			return err
+		}
+		output, err := wire.MarshalJSONIndent(cdc, records)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
+
+func getHistoryCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a username")
+		}
+
+		res, err := ctx.QueryCustom(rep.QuerierRoute, rep.QueryHistory, args[0])
+		if err != nil {
+			return err
+		}
+		history := []repv2.ReputationRecord{}
+		if err := cdc.UnmarshalJSON(res, &history); err != nil {
+			return err
+		}
+		output, err := wire.MarshalJSONIndent(cdc, history)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
@@ -21,3 +21,8 @@ func ErrPostNotFound(permlink types.Permlink) sdk.Error {
 func ErrQueryFailed() sdk.Error {
 	return types.NewError(types.CodeReputationQueryFailed, fmt.Sprintf("query reputation store failed"))
 }
+
+// ErrRoundNotFound - error when reputation round doesn't exist
+func ErrRoundNotFound(round int64) sdk.Error {
+	return types.NewError(types.CodeReputationRoundNotFound, fmt.Sprintf("round %v doesn't exist", round))
+}
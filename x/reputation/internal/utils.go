@@ -1,27 +0,0 @@
-package internal
-
-// PrefixEndBytes returns the []byte that would end a
-// range query for all []byte with a certain prefix
-// Deals with last byte of prefix being FF without overflowing
-func PrefixEndBytes(prefix []byte) []byte {
-	if len(prefix) == 0 {
-		return nil
-	}
-
-	end := make([]byte, len(prefix))
-	copy(end, prefix)
-
-	for {
-		if end[len(end)-1] != byte(255) {
-			end[len(end)-1]++
-			break
-		} else {
-			end = end[:len(end)-1]
-			if len(end) == 0 {
-				end = nil
-				break
-			}
-		}
-	}
-	return end
-}
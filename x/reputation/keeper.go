@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/reputation/repv2"
 )
 
 type ReputationKeeper interface {
@@ -26,6 +27,24 @@ type ReputationKeeper interface {
 	// return the current round start time
 	GetCurrentRound(ctx sdk.Context) (int64, sdk.Error)
 
+	// return the leaderboard and seed set of the current or a past round.
+	GetCurrentRoundInfo(ctx sdk.Context) (repv2.RoundInfo, sdk.Error)
+	GetRoundInfo(ctx sdk.Context, round int64) (repv2.RoundInfo, sdk.Error)
+
+	// return the breakdown of user's reputation.
+	GetUserInfo(ctx sdk.Context, username types.AccountKey) (repv2.UserInfo, sdk.Error)
+
+	// return user's reputation settled in past rounds, latest last.
+	GetReputationHistory(ctx sdk.Context, username types.AccountKey) ([]repv2.ReputationRecord, sdk.Error)
+
+	// grant free score to user, revoke if score is negative, all grants are recorded.
+	GrantFreeScore(
+		ctx sdk.Context,
+		username types.AccountKey,
+		score types.MiniDollar,
+		proposalID types.ProposalKey) sdk.Error
+	GetFreeScoreRecords(ctx sdk.Context, username types.AccountKey) ([]FreeScoreRecord, sdk.Error)
+
 	// import/export this module to files
 	ExportToFile(ctx sdk.Context, file string) error
 	ImportFromFile(ctx sdk.Context, file string) error
@@ -1,55 +1,151 @@
 package reputation
 
 import (
+	"fmt"
+	"io/ioutil"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
-	model "github.com/lino-network/lino/x/reputation/internal"
+	acc "github.com/lino-network/lino/x/account"
 	repv2 "github.com/lino-network/lino/x/reputation/repv2"
 )
 
+var (
+	// roundParamKey - key of the reputation param used by the current round,
+	// placed after all prefixes used by repv2 store.
+	roundParamKey = []byte{0x10}
+	// freeScoreRecordPrefix - prefix of free score granted to users.
+	freeScoreRecordPrefix = []byte{0x11}
+)
+
+// FreeScoreRecord - free score granted to a user by a proposal, revoked score is negative.
+type FreeScoreRecord struct {
+	ProposalID types.ProposalKey `json:"proposal_id"`
+	Score      types.MiniDollar  `json:"score"`
+	CreatedAt  int64             `json:"created_at"`
+}
+
+// FreeScoreRecordRow - pk: Username
+type FreeScoreRecordRow struct {
+	Username types.AccountKey  `json:"username"`
+	Records  []FreeScoreRecord `json:"records"`
+}
+
+// ReputationTables - state of reputation module, reputations are kept by repv2,
+// the round param and free score records by manager. Files exported by repv2
+// only have reputations and are still imported.
+type ReputationTables struct {
+	Reputations      []repv2.UserReputation `json:"reputations"`
+	RoundParam       *param.ReputationParam `json:"round_param,omitempty"`
+	FreeScoreRecords []FreeScoreRecordRow   `json:"free_score_records,omitempty"`
+}
+
+func getFreeScoreRecordKey(username types.AccountKey) []byte {
+	return append(freeScoreRecordPrefix, username...)
+}
+
 // ReputationManager - adaptor for reputation math model and cosmos application.
 type ReputationManager struct {
 	storeKey    sdk.StoreKey
 	paramHolder param.ParamHolder
+	am          acc.AccountKeeper
+	cdc         *wire.Codec
 }
 
-// NewReputationManager - require holder for BestContentIndexN
-func NewReputationManager(storeKey sdk.StoreKey, holder param.ParamHolder) ReputationKeeper {
+// NewReputationManager - require holder for reputation parameters,
+// and account keeper for referrers of users.
+func NewReputationManager(storeKey sdk.StoreKey, holder param.ParamHolder, am acc.AccountKeeper) ReputationKeeper {
 	return ReputationManager{
 		storeKey:    storeKey,
 		paramHolder: holder,
+		am:          am,
+		cdc:         wire.New(),
 	}
 }
 
-// construct a handler.
+// construct a handler, using parameters of the current round.
 func (rep ReputationManager) getHandlerV2(ctx sdk.Context) repv2.Reputation {
 	store := ctx.KVStore(rep.storeKey)
 	repStore := repv2.NewReputationStore(store, repv2.DefaultInitialReputation)
+	para := rep.getRoundParam(ctx)
 	handler := repv2.NewReputation(
-		repStore, 200, 50,
-		repv2.DefaultRoundDurationSeconds,
-		repv2.DefaultSampleWindowSize,
-		repv2.DefaultDecayFactor)
+		repStore, para.BestN, para.UserMaxN,
+		para.RoundDurationSeconds,
+		para.SampleWindowSize,
+		para.DecayFactor)
 	return handler
 }
 
-func (rep ReputationManager) checkUsername(uid model.Uid) sdk.Error {
+// getRoundParam - return the parameters snapshotted when the current round started,
+// if no snapshot has been made yet, parameters of the next round are returned.
+// Before BlockchainUpgrade1Update11Height, default parameters are used.
+func (rep ReputationManager) getRoundParam(ctx sdk.Context) param.ReputationParam {
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update11Height {
+		return defaultRoundParam()
+	}
+	store := ctx.KVStore(rep.storeKey)
+	paramBytes := store.Get(roundParamKey)
+	if paramBytes == nil {
+		return rep.getNextRoundParam(ctx)
+	}
+	para := param.ReputationParam{}
+	rep.cdc.MustUnmarshalBinaryLengthPrefixed(paramBytes, &para)
+	return para
+}
+
+// getNextRoundParam - return the reputation param from param holder, defaults are
+// used when the param is missing or invalid, e.g. stored before these fields existed.
+func (rep ReputationManager) getNextRoundParam(ctx sdk.Context) param.ReputationParam {
+	para, err := rep.paramHolder.GetReputationParam(ctx)
+	if err != nil || !isValidRoundParam(*para) {
+		return defaultRoundParam()
+	}
+	return *para
+}
+
+// defaultRoundParam - parameters of repv2 before they are read from ReputationParam,
+// referral cluster cap is disabled.
+func defaultRoundParam() param.ReputationParam {
+	return param.ReputationParam{
+		BestN:                repv2.DefaultBestN,
+		UserMaxN:             repv2.DefaultUserMaxN,
+		RoundDurationSeconds: repv2.DefaultRoundDurationSeconds,
+		SampleWindowSize:     repv2.DefaultSampleWindowSize,
+		DecayFactor:          repv2.DefaultDecayFactor,
+	}
+}
+
+func (rep ReputationManager) setRoundParam(ctx sdk.Context, para param.ReputationParam) {
+	store := ctx.KVStore(rep.storeKey)
+	store.Set(roundParamKey, rep.cdc.MustMarshalBinaryLengthPrefixed(para))
+}
+
+func isValidRoundParam(para param.ReputationParam) bool {
+	return para.BestN > 0 && para.BestN <= types.MaximumReputationBestN &&
+		para.UserMaxN > 0 && para.UserMaxN <= types.MaximumReputationUserMaxN &&
+		para.RoundDurationSeconds > 0 &&
+		para.SampleWindowSize > 0 && para.DecayFactor >= 0 && para.DecayFactor <= 100 &&
+		(!para.ReferralClusterCapEnabled || para.ReferralClusterMaxIF.IsPositive())
+}
+
+func (rep ReputationManager) checkUsername(uid string) sdk.Error {
 	if len(uid) == 0 {
 		return ErrAccountNotFound("")
 	}
 	return nil
 }
 
-func (rep ReputationManager) checkPost(pid model.Pid) sdk.Error {
+func (rep ReputationManager) checkPost(pid string) sdk.Error {
 	if len(pid) == 0 {
 		return ErrPostNotFound("")
 	}
 	return nil
 }
 
-func (rep ReputationManager) basicCheck(uid model.Uid, pid model.Pid) sdk.Error {
+func (rep ReputationManager) basicCheck(uid string, pid string) sdk.Error {
 	err := rep.checkUsername(uid)
 	if err != nil {
 		return err
@@ -71,14 +167,41 @@ func (rep ReputationManager) DonateAt(ctx sdk.Context,
 
 	// Update6, start to use new reputation algorithm.
 	handler := rep.getHandlerV2(ctx)
-	dp := handler.DonateAt(repv2.Uid(uid), repv2.Pid(pid), repv2.NewIntFromBig(amount.Int.BigInt()))
+	para := rep.getRoundParam(ctx)
+	if !para.ReferralClusterCapEnabled {
+		dp := handler.DonateAt(repv2.Uid(uid), repv2.Pid(pid), repv2.NewIntFromBig(amount.Int.BigInt()))
+		return types.NewMiniDollarFromBig(dp.Int), nil
+	}
+	// accounts sharing a referrer are in the same cluster, accounts without referrer
+	// are not in any cluster.
+	referrer, err := rep.am.GetReferrer(ctx, username)
+	if err != nil {
+		return types.NewMiniDollar(0), err
+	}
+	if referrer == "" {
+		dp := handler.DonateAt(repv2.Uid(uid), repv2.Pid(pid), repv2.NewIntFromBig(amount.Int.BigInt()))
+		return types.NewMiniDollarFromBig(dp.Int), nil
+	}
+	dp := handler.DonateAtCluster(
+		repv2.Uid(uid), repv2.Pid(pid), repv2.NewIntFromBig(amount.Int.BigInt()),
+		repv2.Uid(referrer), repv2.NewIntFromBig(para.ReferralClusterMaxIF.Int.BigInt()))
 	return types.NewMiniDollarFromBig(dp.Int), nil
 }
 
 // Update - on blocker end, update reputation time related information.
+// Since BlockchainUpgrade1Update11Height, parameter changes only take effect when
+// a new round starts, so that all donations in a round are settled under the same parameters.
 func (rep ReputationManager) Update(ctx sdk.Context) sdk.Error {
 	handler := rep.getHandlerV2(ctx)
+	prev, _ := handler.GetCurrentRound()
 	handler.Update(repv2.Time(ctx.BlockHeader().Time.Unix()))
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update11Height {
+		return nil
+	}
+	current, _ := handler.GetCurrentRound()
+	if current != prev || ctx.KVStore(rep.storeKey).Get(roundParamKey) == nil {
+		rep.setRoundParam(ctx, rep.getNextRoundParam(ctx))
+	}
 	return nil
 }
 
@@ -101,15 +224,157 @@ func (rep ReputationManager) GetCurrentRound(ctx sdk.Context) (int64, sdk.Error)
 	return int64(ts), nil
 }
 
-// ExportToFile state of reputation system.
+// GetCurrentRoundInfo - return the summary of the current round, the leaderboard
+// of posts may still change before the round ends.
+func (rep ReputationManager) GetCurrentRoundInfo(ctx sdk.Context) (repv2.RoundInfo, sdk.Error) {
+	handler := rep.getHandlerV2(ctx)
+	current, _ := handler.GetCurrentRound()
+	return handler.GetRoundInfo(current), nil
+}
+
+// GetRoundInfo - return the summary of @p round, the seed set is set when the round ends.
+func (rep ReputationManager) GetRoundInfo(ctx sdk.Context, round int64) (repv2.RoundInfo, sdk.Error) {
+	handler := rep.getHandlerV2(ctx)
+	current, _ := handler.GetCurrentRound()
+	if round <= 0 || repv2.RoundId(round) > current {
+		return repv2.RoundInfo{}, ErrRoundNotFound(round)
+	}
+	return handler.GetRoundInfo(repv2.RoundId(round)), nil
+}
+
+// GetUserInfo - return the breakdown of @p username's reputation.
+func (rep ReputationManager) GetUserInfo(ctx sdk.Context, username types.AccountKey) (repv2.UserInfo, sdk.Error) {
+	uid := string(username)
+	err := rep.checkUsername(uid)
+	if err != nil {
+		return repv2.UserInfo{}, err
+	}
+
+	handler := rep.getHandlerV2(ctx)
+	return handler.GetUserInfo(repv2.Uid(uid)), nil
+}
+
+// GetReputationHistory - return @p username's reputation settled in past rounds, latest last.
+func (rep ReputationManager) GetReputationHistory(
+	ctx sdk.Context, username types.AccountKey) ([]repv2.ReputationRecord, sdk.Error) {
+	uid := string(username)
+	err := rep.checkUsername(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := rep.getHandlerV2(ctx)
+	return handler.GetUserHistory(repv2.Uid(uid)), nil
+}
+
+// GrantFreeScore - increase @p username's free score by @p score, which is
+// negative when revoking, and record the change actually applied as granted by
+// @p proposalID. Changes by the same proposal are merged, and only the latest
+// MaximumFreeScoreRecords records are kept.
+func (rep ReputationManager) GrantFreeScore(
+	ctx sdk.Context, username types.AccountKey, score types.MiniDollar, proposalID types.ProposalKey) sdk.Error {
+	uid := string(username)
+	err := rep.checkUsername(uid)
+	if err != nil {
+		return err
+	}
+
+	handler := rep.getHandlerV2(ctx)
+	applied := types.NewMiniDollarFromBig(
+		handler.IncFreeScore(repv2.Uid(uid), repv2.NewIntFromBig(score.Int.BigInt())).Int)
+	if applied.IsZero() {
+		return nil
+	}
+
+	records, err := rep.GetFreeScoreRecords(ctx, username)
+	if err != nil {
+		return err
+	}
+	if n := len(records); n > 0 && records[n-1].ProposalID == proposalID {
+		records[n-1].Score = types.NewMiniDollarFromInt(records[n-1].Score.Add(applied.Int))
+	} else {
+		records = append(records, FreeScoreRecord{
+			ProposalID: proposalID,
+			Score:      applied,
+			CreatedAt:  ctx.BlockHeader().Time.Unix(),
+		})
+	}
+	if len(records) > types.MaximumFreeScoreRecords {
+		records = records[len(records)-types.MaximumFreeScoreRecords:]
+	}
+	store := ctx.KVStore(rep.storeKey)
+	store.Set(getFreeScoreRecordKey(username), rep.cdc.MustMarshalBinaryLengthPrefixed(records))
+	return nil
+}
+
+// GetFreeScoreRecords - return all free score granted to @p username, in time order.
+func (rep ReputationManager) GetFreeScoreRecords(
+	ctx sdk.Context, username types.AccountKey) ([]FreeScoreRecord, sdk.Error) {
+	err := rep.checkUsername(string(username))
+	if err != nil {
+		return nil, err
+	}
+
+	store := ctx.KVStore(rep.storeKey)
+	recordsBytes := store.Get(getFreeScoreRecordKey(username))
+	records := []FreeScoreRecord{}
+	if recordsBytes == nil {
+		return records, nil
+	}
+	rep.cdc.MustUnmarshalBinaryLengthPrefixed(recordsBytes, &records)
+	return records, nil
+}
+
+// ExportToFile state of reputation system, including round param and free score records.
 func (rep ReputationManager) ExportToFile(ctx sdk.Context, file string) error {
-	repv2 := rep.getHandlerV2(ctx)
-	return repv2.ExportToFile(file)
+	handler := rep.getHandlerV2(ctx)
+	tables := &ReputationTables{
+		Reputations: handler.Export().Reputations,
+	}
+	store := ctx.KVStore(rep.storeKey)
+	if paramBytes := store.Get(roundParamKey); paramBytes != nil {
+		para := param.ReputationParam{}
+		rep.cdc.MustUnmarshalBinaryLengthPrefixed(paramBytes, &para)
+		tables.RoundParam = &para
+	}
+	itr := sdk.KVStorePrefixIterator(store, freeScoreRecordPrefix)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		records := []FreeScoreRecord{}
+		rep.cdc.MustUnmarshalBinaryLengthPrefixed(itr.Value(), &records)
+		tables.FreeScoreRecords = append(tables.FreeScoreRecords, FreeScoreRecordRow{
+			Username: types.AccountKey(itr.Key()[len(freeScoreRecordPrefix):]),
+			Records:  records,
+		})
+	}
+
+	bz, err := rep.cdc.MarshalJSON(tables)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json for %s due to %s", file, err)
+	}
+	return ioutil.WriteFile(file, bz, 0644)
 }
 
 // ImportFromFile state of reputation system.
 // after update6's code is merged, V2 is the only version that will exist.
 func (rep ReputationManager) ImportFromFile(ctx sdk.Context, file string) error {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s due to %s", file, err)
+	}
+	tables := &ReputationTables{}
+	if err := rep.cdc.UnmarshalJSON(bz, tables); err != nil {
+		return fmt.Errorf("failed to unmarshal: %s", err)
+	}
+
 	handler := rep.getHandlerV2(ctx)
-	return handler.ImportFromFile(file)
+	handler.Import(&repv2.UserReputationTable{Reputations: tables.Reputations})
+	if tables.RoundParam != nil {
+		rep.setRoundParam(ctx, *tables.RoundParam)
+	}
+	store := ctx.KVStore(rep.storeKey)
+	for _, row := range tables.FreeScoreRecords {
+		store.Set(getFreeScoreRecordKey(row.Username), rep.cdc.MustMarshalBinaryLengthPrefixed(row.Records))
+	}
+	return nil
 }
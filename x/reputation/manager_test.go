@@ -4,9 +4,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/cosmos/cosmos-sdk/store"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	accmocks "github.com/lino-network/lino/x/account/mocks"
 	"github.com/lino-network/lino/x/reputation/repv2"
 )
 
@@ -24,6 +27,7 @@ type reputationTestSuite struct {
 	suite.Suite
 	ms     sdk.CommitMultiStore
 	ph     param.ParamHolder
+	am     *accmocks.AccountKeeper
 	rep    ReputationManager
 	height int64
 	ctx    sdk.Context
@@ -59,16 +63,19 @@ func (suite *reputationTestSuite) SetupTest() {
 	ms.MountStoreWithDB(TestRepv2KVStoreKey, sdk.StoreTypeIAVL, db)
 	_ = ms.LoadLatestVersion()
 	ctx := sdk.NewContext(
-		ms, abci.Header{ChainID: "Lino", Height: 1, Time: suite.t}, false, log.NewNopLogger())
+		ms, abci.Header{
+			ChainID: "Lino", Height: types.BlockchainUpgrade1Update11Height, Time: suite.t},
+		false, log.NewNopLogger())
 
 	ph := param.NewParamHolder(TestParamKVStoreKey)
 	_ = ph.InitParam(ctx)
-	rep := NewReputationManager(TestRepv2KVStoreKey, ph)
+	suite.am = &accmocks.AccountKeeper{}
+	rep := NewReputationManager(TestRepv2KVStoreKey, ph, suite.am)
 	suite.ph = ph
 	suite.rep = rep.(ReputationManager)
 	suite.ctx = ctx
 	suite.ms = ms
-	suite.height = 1
+	suite.height = types.BlockchainUpgrade1Update11Height
 }
 
 func (suite *reputationTestSuite) TestGetHandlers() {
@@ -83,6 +90,78 @@ func (suite *reputationTestSuite) TestGetHandlers() {
 	suite.Equal(int64(10), v2impl.DecayFactor)
 }
 
+func (suite *reputationTestSuite) TestRoundParam() {
+	rep := suite.rep
+	suite.timefies()
+	newParam := param.ReputationParam{
+		BestContentIndexN:    10,
+		BestN:                100,
+		UserMaxN:             20,
+		RoundDurationSeconds: 3600,
+		SampleWindowSize:     5,
+		DecayFactor:          20,
+	}
+	err := param.ChangeParamEvent{Param: newParam}.Execute(suite.ctx, suite.ph)
+	suite.Require().Nil(err)
+
+	// in-flight round keeps using parameters it started with.
+	v2impl := rep.getHandlerV2(suite.ctx).(repv2.ReputationImpl)
+	suite.Equal(200, v2impl.BestN)
+	suite.Equal(int64(25*3600), v2impl.RoundDurationSeconds)
+
+	suite.t = suite.t.Add(time.Hour)
+	suite.height++
+	suite.ctx = sdk.NewContext(
+		suite.ms, abci.Header{ChainID: "Lino", Height: suite.height, Time: suite.t},
+		false, log.NewNopLogger())
+	suite.Nil(rep.Update(suite.ctx))
+	v2impl = rep.getHandlerV2(suite.ctx).(repv2.ReputationImpl)
+	suite.Equal(200, v2impl.BestN)
+	suite.Equal(50, v2impl.UserMaxN)
+
+	// new parameters take effect when the next round starts.
+	suite.timefies()
+	v2impl = rep.getHandlerV2(suite.ctx).(repv2.ReputationImpl)
+	suite.Equal(100, v2impl.BestN)
+	suite.Equal(20, v2impl.UserMaxN)
+	suite.Equal(int64(3600), v2impl.RoundDurationSeconds)
+	suite.Equal(int64(5), v2impl.SampleWindowSize)
+	suite.Equal(int64(20), v2impl.DecayFactor)
+	ts, err := rep.GetCurrentRound(suite.ctx)
+	suite.Nil(err)
+	suite.Equal(suite.t.Unix(), ts)
+}
+
+func (suite *reputationTestSuite) TestRoundParamBeforeUpgrade() {
+	rep := suite.rep
+	newParam := param.ReputationParam{
+		BestContentIndexN:    10,
+		BestN:                100,
+		UserMaxN:             20,
+		RoundDurationSeconds: 3600,
+		SampleWindowSize:     5,
+		DecayFactor:          20,
+	}
+	err := param.ChangeParamEvent{Param: newParam}.Execute(suite.ctx, suite.ph)
+	suite.Require().Nil(err)
+
+	// default parameters are used and no snapshot is made before upgrade.
+	ctx := suite.ctx.WithBlockHeight(types.BlockchainUpgrade1Update11Height - 1)
+	suite.Nil(rep.Update(ctx))
+	suite.Nil(ctx.KVStore(rep.storeKey).Get(roundParamKey))
+	v2impl := rep.getHandlerV2(ctx).(repv2.ReputationImpl)
+	suite.Equal(200, v2impl.BestN)
+	suite.Equal(50, v2impl.UserMaxN)
+	suite.Equal(int64(25*3600), v2impl.RoundDurationSeconds)
+
+	// parameters are snapshotted since upgrade.
+	suite.Nil(rep.Update(suite.ctx))
+	suite.NotNil(suite.ctx.KVStore(rep.storeKey).Get(roundParamKey))
+	v2impl = rep.getHandlerV2(suite.ctx).(repv2.ReputationImpl)
+	suite.Equal(100, v2impl.BestN)
+	suite.Equal(20, v2impl.UserMaxN)
+}
+
 func (suite *reputationTestSuite) TestUserPostBasicCheck() {
 	suite.Nil(suite.rep.checkUsername("x"))
 	suite.NotNil(suite.rep.checkUsername(""))
@@ -111,6 +190,148 @@ func (suite *reputationTestSuite) TestGetCurrentRound() {
 	suite.Equal(suite.t.Unix(), ts)
 }
 
+func (suite *reputationTestSuite) TestRoundAndUserInfo() {
+	rep := suite.rep
+	suite.timefies()
+	_, err := rep.DonateAt(suite.ctx, "user1", "post1", types.NewMiniDollar(100))
+	suite.Nil(err)
+
+	info, err := rep.GetCurrentRoundInfo(suite.ctx)
+	suite.Nil(err)
+	suite.Equal(repv2.RoundId(2), info.Id)
+	suite.Equal(repv2.Time(suite.t.Unix()), info.StartAt)
+	suite.Equal([]repv2.PostIFPair{{Pid: "post1", SumIF: repv2.NewInt(1)}}, info.TopN)
+
+	userInfo, err := rep.GetUserInfo(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal(repv2.RoundId(2), userInfo.LastDonationRound)
+	suite.Equal(1, len(userInfo.Unsettled))
+	_, err = rep.GetUserInfo(suite.ctx, "")
+	suite.Equal(ErrAccountNotFound(""), err)
+
+	suite.timefies()
+	info, err = rep.GetRoundInfo(suite.ctx, 2)
+	suite.Nil(err)
+	suite.Equal([]repv2.Pid{"post1"}, info.Result)
+
+	_, err = rep.GetRoundInfo(suite.ctx, 0)
+	suite.Equal(ErrRoundNotFound(0), err)
+	_, err = rep.GetRoundInfo(suite.ctx, 4)
+	suite.Equal(ErrRoundNotFound(4), err)
+}
+
+func (suite *reputationTestSuite) TestGrantFreeScore() {
+	rep := suite.rep
+	records, err := rep.GetFreeScoreRecords(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal([]FreeScoreRecord{}, records)
+
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user1", types.NewMiniDollar(3000), "1"))
+	rv, err := rep.GetReputation(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(3000+repv2.DefaultInitialReputation), rv)
+
+	// revoke more than granted, reputation is floored at zero.
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user1", types.NewMiniDollar(-5000), "2"))
+	rv, err = rep.GetReputation(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(0).String(), rv.String())
+
+	// revoke from zero free score changes nothing and is not recorded,
+	// changes by the same proposal are merged.
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user1", types.NewMiniDollar(-1000), "3"))
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user1", types.NewMiniDollar(1000), "4"))
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user1", types.NewMiniDollar(500), "4"))
+
+	// revoked score is the amount actually revoked.
+	records, err = rep.GetFreeScoreRecords(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Require().Equal(3, len(records))
+	suite.Equal(types.ProposalKey("1"), records[0].ProposalID)
+	suite.Equal("3000", records[0].Score.String())
+	suite.Equal(types.ProposalKey("2"), records[1].ProposalID)
+	suite.Equal("-3000", records[1].Score.String())
+	suite.Equal(types.ProposalKey("4"), records[2].ProposalID)
+	suite.Equal("1500", records[2].Score.String())
+
+	// only the latest records are kept.
+	for i := 0; i < types.MaximumFreeScoreRecords; i++ {
+		suite.Nil(rep.GrantFreeScore(
+			suite.ctx, "user1", types.NewMiniDollar(1), types.ProposalKey(strconv.Itoa(i+5))))
+	}
+	records, err = rep.GetFreeScoreRecords(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal(types.MaximumFreeScoreRecords, len(records))
+	suite.Equal(types.ProposalKey("5"), records[0].ProposalID)
+
+	suite.Equal(ErrAccountNotFound(""), rep.GrantFreeScore(suite.ctx, "", types.NewMiniDollar(1), "3"))
+}
+
+func (suite *reputationTestSuite) TestReputationHistory() {
+	rep := suite.rep
+	suite.timefies()
+	start := suite.t.Unix()
+	_, err := rep.DonateAt(suite.ctx, "user1", "post1", types.NewMiniDollar(100))
+	suite.Nil(err)
+	history, err := rep.GetReputationHistory(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Empty(history)
+
+	suite.timefies()
+	history, err = rep.GetReputationHistory(suite.ctx, "user1")
+	suite.Nil(err)
+	rv, err := rep.GetReputation(suite.ctx, "user1")
+	suite.Nil(err)
+	suite.Equal(1, len(history))
+	suite.Equal(repv2.RoundId(2), history[0].Round)
+	suite.Equal(repv2.Time(start), history[0].StartAt)
+	suite.Equal(rv.String(), history[0].Reputation.String())
+
+	_, err = rep.GetReputationHistory(suite.ctx, "")
+	suite.Equal(ErrAccountNotFound(""), err)
+}
+
+func (suite *reputationTestSuite) TestDonateAtReferralCluster() {
+	rep := suite.rep
+	newParam, err := suite.ph.GetReputationParam(suite.ctx)
+	suite.Require().Nil(err)
+	newParam.ReferralClusterCapEnabled = true
+	newParam.ReferralClusterMaxIF = types.NewMiniDollar(500)
+	err = param.ChangeParamEvent{Param: *newParam}.Execute(suite.ctx, suite.ph)
+	suite.Require().Nil(err)
+	suite.timefies()
+
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user1")).Return(types.AccountKey("ref"), nil)
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user2")).Return(types.AccountKey("ref"), nil)
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user3")).Return(types.AccountKey("ref2"), nil)
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user4")).Return(
+		types.AccountKey(""), ErrAccountNotFound("user4"))
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user5")).Return(types.AccountKey(""), nil)
+	suite.am.On("GetReferrer", mock.Anything, types.AccountKey("user6")).Return(types.AccountKey(""), nil)
+	for _, user := range []types.AccountKey{"user1", "user2", "user3", "user5", "user6"} {
+		suite.Nil(rep.GrantFreeScore(suite.ctx, user, types.NewMiniDollar(1000), "1"))
+	}
+
+	dp, err := rep.DonateAt(suite.ctx, "user1", "post1", types.NewMiniDollar(300))
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(300), dp)
+	dp, err = rep.DonateAt(suite.ctx, "user2", "post1", types.NewMiniDollar(300))
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(200), dp)
+	dp, err = rep.DonateAt(suite.ctx, "user3", "post1", types.NewMiniDollar(300))
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(300), dp)
+	_, err = rep.DonateAt(suite.ctx, "user4", "post1", types.NewMiniDollar(300))
+	suite.Equal(ErrAccountNotFound("user4"), err)
+	// accounts without referrer are not capped together.
+	dp, err = rep.DonateAt(suite.ctx, "user5", "post1", types.NewMiniDollar(300))
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(300), dp)
+	dp, err = rep.DonateAt(suite.ctx, "user6", "post1", types.NewMiniDollar(300))
+	suite.Nil(err)
+	suite.Equal(types.NewMiniDollar(300), dp)
+}
+
 func (suite *reputationTestSuite) TestDonateInvalid() {
 	rep := suite.rep
 	// errors
@@ -217,6 +438,14 @@ func (suite *reputationTestSuite) TestExportImport() {
 		}
 	}
 
+	// round param and free score records are exported with reputations
+	roundParam := rep.getRoundParam(suite.ctx)
+	roundParam.BestN = 100
+	rep.setRoundParam(suite.ctx, roundParam)
+	suite.Nil(rep.GrantFreeScore(suite.ctx, "user11", types.NewMiniDollar(100), "1"))
+	records, err := rep.GetFreeScoreRecords(suite.ctx, "user11")
+	suite.Nil(err)
+
 	dir, err2 := ioutil.TempDir("", "test")
 	suite.Require().Nil(err2)
 	defer os.RemoveAll(dir) // clean up
@@ -240,6 +469,10 @@ func (suite *reputationTestSuite) TestExportImport() {
 
 	err3 := rep.ImportFromFile(suite.ctx, tmpfn)
 	suite.Nil(err3)
+	suite.Equal(100, rep.getRoundParam(suite.ctx).BestN)
+	importedRecords, err := rep.GetFreeScoreRecords(suite.ctx, "user11")
+	suite.Nil(err)
+	suite.Equal(records, importedRecords)
 
 	// check reputation
 	for i, v := range cases {
@@ -4,6 +4,8 @@ package mocks
 
 import linotypes "github.com/lino-network/lino/types"
 import mock "github.com/stretchr/testify/mock"
+import reputation "github.com/lino-network/lino/x/reputation"
+import repv2 "github.com/lino-network/lino/x/reputation/repv2"
 
 import types "github.com/cosmos/cosmos-sdk/types"
 
@@ -72,6 +74,54 @@ func (_m *ReputationKeeper) GetCurrentRound(ctx types.Context) (int64, types.Err
 	return r0, r1
 }
 
+// GetCurrentRoundInfo provides a mock function with given fields: ctx
+func (_m *ReputationKeeper) GetCurrentRoundInfo(ctx types.Context) (repv2.RoundInfo, types.Error) {
+	ret := _m.Called(ctx)
+
+	var r0 repv2.RoundInfo
+	if rf, ok := ret.Get(0).(func(types.Context) repv2.RoundInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(repv2.RoundInfo)
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context) types.Error); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetFreeScoreRecords provides a mock function with given fields: ctx, username
+func (_m *ReputationKeeper) GetFreeScoreRecords(ctx types.Context, username linotypes.AccountKey) ([]reputation.FreeScoreRecord, types.Error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 []reputation.FreeScoreRecord
+	if rf, ok := ret.Get(0).(func(types.Context, linotypes.AccountKey) []reputation.FreeScoreRecord); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reputation.FreeScoreRecord)
+		}
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context, linotypes.AccountKey) types.Error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetReputation provides a mock function with given fields: ctx, username
 func (_m *ReputationKeeper) GetReputation(ctx types.Context, username linotypes.AccountKey) (linotypes.MiniDollar, types.Error) {
 	ret := _m.Called(ctx, username)
@@ -95,6 +145,93 @@ func (_m *ReputationKeeper) GetReputation(ctx types.Context, username linotypes.
 	return r0, r1
 }
 
+// GetReputationHistory provides a mock function with given fields: ctx, username
+func (_m *ReputationKeeper) GetReputationHistory(ctx types.Context, username linotypes.AccountKey) ([]repv2.ReputationRecord, types.Error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 []repv2.ReputationRecord
+	if rf, ok := ret.Get(0).(func(types.Context, linotypes.AccountKey) []repv2.ReputationRecord); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repv2.ReputationRecord)
+		}
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context, linotypes.AccountKey) types.Error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRoundInfo provides a mock function with given fields: ctx, round
+func (_m *ReputationKeeper) GetRoundInfo(ctx types.Context, round int64) (repv2.RoundInfo, types.Error) {
+	ret := _m.Called(ctx, round)
+
+	var r0 repv2.RoundInfo
+	if rf, ok := ret.Get(0).(func(types.Context, int64) repv2.RoundInfo); ok {
+		r0 = rf(ctx, round)
+	} else {
+		r0 = ret.Get(0).(repv2.RoundInfo)
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context, int64) types.Error); ok {
+		r1 = rf(ctx, round)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUserInfo provides a mock function with given fields: ctx, username
+func (_m *ReputationKeeper) GetUserInfo(ctx types.Context, username linotypes.AccountKey) (repv2.UserInfo, types.Error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 repv2.UserInfo
+	if rf, ok := ret.Get(0).(func(types.Context, linotypes.AccountKey) repv2.UserInfo); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(repv2.UserInfo)
+	}
+
+	var r1 types.Error
+	if rf, ok := ret.Get(1).(func(types.Context, linotypes.AccountKey) types.Error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GrantFreeScore provides a mock function with given fields: ctx, username, score, proposalID
+func (_m *ReputationKeeper) GrantFreeScore(ctx types.Context, username linotypes.AccountKey, score linotypes.MiniDollar, proposalID linotypes.ProposalKey) types.Error {
+	ret := _m.Called(ctx, username, score, proposalID)
+
+	var r0 types.Error
+	if rf, ok := ret.Get(0).(func(types.Context, linotypes.AccountKey, linotypes.MiniDollar, linotypes.ProposalKey) types.Error); ok {
+		r0 = rf(ctx, username, score, proposalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Error)
+		}
+	}
+
+	return r0
+}
+
 // ImportFromFile provides a mock function with given fields: ctx, file
 func (_m *ReputationKeeper) ImportFromFile(ctx types.Context, file string) error {
 	ret := _m.Called(ctx, file)
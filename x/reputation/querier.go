@@ -1,6 +1,8 @@
 package reputation
 
 import (
+	"strconv"
+
 	wire "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/types"
@@ -17,7 +19,12 @@ const (
 	// QuerierRoute is the querier route for gov
 	QuerierRoute = ModuleName
 
-	QueryReputation = "rep"
+	QueryReputation   = "rep"
+	QueryCurrentRound = "currentRound"
+	QueryRound        = "round"
+	QueryUserInfo     = "userInfo"
+	QueryFreeScore    = "freeScore"
+	QueryHistory      = "history"
 )
 
 // creates a querier for vote REST endpoints
@@ -28,6 +35,16 @@ func NewQuerier(rm ReputationKeeper) sdk.Querier {
 		switch path[0] {
 		case QueryReputation:
 			return queryReputation(ctx, cdc, path[1:], req, rm)
+		case QueryCurrentRound:
+			return queryCurrentRound(ctx, cdc, path[1:], req, rm)
+		case QueryRound:
+			return queryRound(ctx, cdc, path[1:], req, rm)
+		case QueryUserInfo:
+			return queryUserInfo(ctx, cdc, path[1:], req, rm)
+		case QueryFreeScore:
+			return queryFreeScore(ctx, cdc, path[1:], req, rm)
+		case QueryHistory:
+			return queryHistory(ctx, cdc, path[1:], req, rm)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown reputation query endpoint")
 		}
@@ -48,3 +65,79 @@ func queryReputation(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.R
 	}
 	return res, nil
 }
+
+func queryCurrentRound(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, rm ReputationKeeper) ([]byte, sdk.Error) {
+	info, err := rm.GetCurrentRoundInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(info)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryRound(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, rm ReputationKeeper) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	round, convertErr := strconv.ParseInt(path[0], 10, 64)
+	if convertErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	info, err := rm.GetRoundInfo(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(info)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryUserInfo(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, rm ReputationKeeper) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	info, err := rm.GetUserInfo(ctx, types.AccountKey(path[0]))
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(info)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryFreeScore(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, rm ReputationKeeper) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	records, err := rm.GetFreeScoreRecords(ctx, types.AccountKey(path[0]))
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(records)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func queryHistory(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, rm ReputationKeeper) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	history, err := rm.GetReputationHistory(ctx, types.AccountKey(path[0]))
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(history)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
@@ -4,9 +4,12 @@ const (
 	// Inherited from testnet, the unit of 1 reputation is one coin
 	// of testnet, which is 10^(-5) * 0.012 USD.
 	// Caller need to convert the amount of donation to the number of test coins.
+	DefaultBestN                = 200       // how many top posts are tracked in a round.
+	DefaultUserMaxN             = 50        // how many posts a user's donations can have impact on in a round.
 	DefaultRoundDurationSeconds = 25 * 3600 // how many seconds does a round last, default: 25 hours
 	DefaultSampleWindowSize     = 10        // how many rounds are used to sample out user's customer score.
 	DefaultDecayFactor          = 10        // reputation decay factor %.
+	DefaultUserHistorySize      = 100       // how many settled rounds are kept in user's reputation history.
 
 	// Initial and minimum score is 10^(-5), one coin.
 	DefaultInitialReputation = 1
@@ -73,3 +73,37 @@ func encodeGameMeta(dt *gameMeta) []byte {
 	rst := cdc.MustMarshalBinaryBare(dt)
 	return []byte(rst)
 }
+
+func decodeUserHistory(data []byte) *userHistory {
+	if data == nil {
+		return nil
+	}
+	rst := &userHistory{}
+	cdc.MustUnmarshalBinaryBare(data, rst)
+	return rst
+}
+
+func encodeUserHistory(dt *userHistory) []byte {
+	if dt == nil {
+		return nil
+	}
+	rst := cdc.MustMarshalBinaryBare(dt)
+	return []byte(rst)
+}
+
+func decodeRoundClusterPostMeta(data []byte) *roundClusterPostMeta {
+	if data == nil {
+		return nil
+	}
+	rst := &roundClusterPostMeta{}
+	cdc.MustUnmarshalBinaryBare(data, rst)
+	return rst
+}
+
+func encodeRoundClusterPostMeta(dt *roundClusterPostMeta) []byte {
+	if dt == nil {
+		return nil
+	}
+	rst := cdc.MustMarshalBinaryBare(dt)
+	return []byte(rst)
+}
@@ -25,7 +25,8 @@ func encode$TYPE(dt *TYPE) []byte {
 }
 """
 
-structs = ['userMeta', 'roundMeta', 'roundPostMeta', 'gameMeta']
+structs = ['userMeta', 'roundMeta', 'roundPostMeta', 'gameMeta', 'userHistory',
+           'roundClusterPostMeta']
 
 
 def cap(word):
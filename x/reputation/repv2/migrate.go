@@ -0,0 +1,174 @@
+package repv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	db "github.com/tendermint/tendermint/libs/db"
+)
+
+// 1 testnetcoin = (10^-5 * 0.012) USD = 12 MiniUSD
+const testnetCoinToMiniDollar = 12
+
+// v1Int - score exported by the v1 reputation system. It was encoded as a json
+// number, but quoted numbers are accepted as well.
+type v1Int struct {
+	*big.Int
+}
+
+// UnmarshalJSON - accept both 123 and "123".
+func (i *v1Int) UnmarshalJSON(bz []byte) error {
+	text := strings.Trim(string(bz), "\"")
+	v, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return fmt.Errorf("invalid score: %s", string(bz))
+	}
+	i.Int = v
+	return nil
+}
+
+// v1UserReputation - user's reputation exported by the v1 reputation system,
+// scores are in the unit of testnet coin.
+type v1UserReputation struct {
+	Username      Uid   `json:"username"`
+	CustomerScore v1Int `json:"customer_score"`
+	FreeScore     v1Int `json:"free_score"`
+}
+
+// v1UserReputationTable - state exported by the v1 reputation system.
+type v1UserReputationTable struct {
+	Reputations []v1UserReputation `json:"reputations"`
+}
+
+// v1Reputation - reputation that GetReputation of the v1 reputation system
+// returns for a user imported from its export, in MiniDollar. The export does not
+// carry any unsettled round, so the imported user's reputation is exactly the sum
+// of customer score and free score.
+func v1Reputation(v v1UserReputation) Rep {
+	score := IntAdd(NewIntFromBig(v.CustomerScore.Int), NewIntFromBig(v.FreeScore.Int))
+	return IntMul(score, NewInt(testnetCoinToMiniDollar))
+}
+
+// MigrateFromV1 - convert the state exported by the v1 reputation system to
+// a UserReputationTable, then verify that after importing it, every user's
+// reputation differs from its v1 reputation by at most @p tolerance MiniDollar.
+func MigrateFromV1(v1 []byte, tolerance Rep) (*UserReputationTable, error) {
+	legacy := &v1UserReputationTable{}
+	if err := json.Unmarshal(v1, legacy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal v1 reputation: %s", err)
+	}
+	expected := make(map[Uid]Rep)
+	rst := &UserReputationTable{}
+	seen := make(map[Uid]bool)
+	for _, v := range legacy.Reputations {
+		if len(v.Username) == 0 {
+			return nil, fmt.Errorf("empty username in v1 reputation")
+		}
+		if seen[v.Username] {
+			return nil, fmt.Errorf("duplicated user in v1 reputation: %s", v.Username)
+		}
+		if v.CustomerScore.Int == nil || v.FreeScore.Int == nil {
+			return nil, fmt.Errorf("missing score of user: %s", v.Username)
+		}
+		seen[v.Username] = true
+		expected[v.Username] = v1Reputation(v)
+		rst.Reputations = append(rst.Reputations, UserReputation{
+			Username:      v.Username,
+			CustomerScore: NewIntFromBig(v.CustomerScore.Int),
+			FreeScore:     NewIntFromBig(v.FreeScore.Int),
+			IsMiniDollar:  false,
+		})
+	}
+	if err := verifyMigration(expected, rst, tolerance); err != nil {
+		return nil, err
+	}
+	return rst, nil
+}
+
+// MigrateFileFromV1 - migrate the v1 export @p v1File to @p v2File, which can be
+// imported by ImportFromFile. Returns the number of users migrated.
+func MigrateFileFromV1(v1File, v2File string, tolerance Rep) (int, error) {
+	bytes, err := readFile(v1File)
+	if err != nil {
+		return 0, err
+	}
+	tb, err := MigrateFromV1(bytes, tolerance)
+	if err != nil {
+		return 0, err
+	}
+	if err := writeTableToFile(v2File, tb); err != nil {
+		return 0, err
+	}
+	return len(tb.Reputations), nil
+}
+
+func readFile(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open " + err.Error())
+	}
+	defer f.Close()
+	bytes, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to readall: " + err.Error())
+	}
+	return bytes, nil
+}
+
+// verifyMigration imports @p migrated into an empty store and checks every user's
+// reputation against @p expected, its v1 reputation in MiniDollar.
+func verifyMigration(expected map[Uid]Rep, migrated *UserReputationTable, tolerance Rep) error {
+	store := NewReputationStore(db.NewMemDB(), DefaultInitialReputation)
+	store.Import(migrated)
+	users := []Uid{}
+	store.IterateUsers(func(u Uid) bool {
+		users = append(users, u)
+		return false
+	})
+	if len(users) != len(expected) {
+		return fmt.Errorf("number of users mismatch, v1: %d, migrated: %d", len(expected), len(users))
+	}
+	rep := NewReputation(store, DefaultBestN, DefaultUserMaxN,
+		DefaultRoundDurationSeconds, DefaultSampleWindowSize, DefaultDecayFactor)
+	for _, u := range users {
+		v1Rep, ok := expected[u]
+		if !ok {
+			return fmt.Errorf("reputation of %s is not found in v1 reputation", u)
+		}
+		actual := rep.GetReputation(u)
+		diff := IntSub(actual, v1Rep)
+		if diff.Sign() < 0 {
+			diff.Neg(diff.Int)
+		}
+		if IntGreater(diff, tolerance) {
+			return fmt.Errorf("reputation of %s mismatch, v1: %s, migrated: %s",
+				u, v1Rep.String(), actual.String())
+		}
+	}
+	return nil
+}
+
+func writeTableToFile(file string, tb *UserReputationTable) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %s", err)
+	}
+	defer f.Close()
+	jsonbytes, err := cdc.MarshalJSON(tb)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json for " + file + " due to " + err.Error())
+	}
+	_, err = f.Write(jsonbytes)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+	return nil
+}
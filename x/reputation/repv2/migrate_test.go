@@ -0,0 +1,111 @@
+package repv2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lino-network/lino/x/reputation/repv2/internal"
+)
+
+type MigrateTestSuite struct {
+	suite.Suite
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateTestSuite))
+}
+
+func (suite *MigrateTestSuite) TestMigrateFromV1() {
+	v1 := []byte(`{"reputations":[` +
+		`{"username":"user1","customer_score":100,"free_score":23},` +
+		`{"username":"user2","customer_score":"12345678901234567890","free_score":"0"},` +
+		`{"username":"user3","customer_score":0,"free_score":1}]}`)
+	tb, err := MigrateFromV1(v1, NewInt(0))
+	suite.Require().Nil(err)
+	suite.Require().Equal(3, len(tb.Reputations))
+	suite.Equal(Uid("user1"), tb.Reputations[0].Username)
+	suite.Equal("100", tb.Reputations[0].CustomerScore.String())
+	suite.Equal("23", tb.Reputations[0].FreeScore.String())
+	suite.False(tb.Reputations[0].IsMiniDollar)
+	suite.Equal("12345678901234567890", tb.Reputations[1].CustomerScore.String())
+
+	store := NewReputationStore(internal.NewMockStore(), DefaultInitialReputation)
+	store.Import(tb)
+	rep := NewReputation(store, DefaultBestN, DefaultUserMaxN,
+		DefaultRoundDurationSeconds, DefaultSampleWindowSize, DefaultDecayFactor)
+	suite.Equal("1476", rep.GetReputation("user1").String())
+	suite.Equal("148148146814814814680", rep.GetReputation("user2").String())
+	suite.Equal("12", rep.GetReputation("user3").String())
+}
+
+func (suite *MigrateTestSuite) TestMigrateFromV1Invalid() {
+	testCases := []struct {
+		testName string
+		v1       string
+	}{
+		{"invalid json", `{"reputations":[`},
+		{"invalid score", `{"reputations":[{"username":"u","customer_score":"x","free_score":1}]}`},
+		{"missing score", `{"reputations":[{"username":"u","customer_score":1}]}`},
+		{"empty username", `{"reputations":[{"username":"","customer_score":1,"free_score":1}]}`},
+		{"duplicated user", `{"reputations":[` +
+			`{"username":"u","customer_score":1,"free_score":1},` +
+			`{"username":"u","customer_score":2,"free_score":1}]}`},
+	}
+	for _, tc := range testCases {
+		_, err := MigrateFromV1([]byte(tc.v1), NewInt(0))
+		suite.NotNil(err, "%s", tc.testName)
+	}
+}
+
+func (suite *MigrateTestSuite) TestVerifyMigration() {
+	migrated := &UserReputationTable{
+		Reputations: []UserReputation{
+			{Username: "u", CustomerScore: NewInt(1), FreeScore: NewInt(1)},
+		},
+	}
+	testCases := []struct {
+		testName  string
+		expected  map[Uid]Rep
+		tolerance Rep
+		hasErr    bool
+	}{
+		{"match", map[Uid]Rep{"u": NewInt(24)}, NewInt(0), false},
+		{"within tolerance", map[Uid]Rep{"u": NewInt(26)}, NewInt(2), false},
+		{"reputation mismatch", map[Uid]Rep{"u": NewInt(26)}, NewInt(1), true},
+		{"user not in v1", map[Uid]Rep{"v": NewInt(24)}, NewInt(0), true},
+		{"number of users mismatch", map[Uid]Rep{"u": NewInt(24), "v": NewInt(24)}, NewInt(0), true},
+	}
+	for _, tc := range testCases {
+		err := verifyMigration(tc.expected, migrated, tc.tolerance)
+		suite.Equal(tc.hasErr, err != nil, "%s", tc.testName)
+	}
+}
+
+func (suite *MigrateTestSuite) TestMigrateFileFromV1() {
+	dir, err := ioutil.TempDir("", "test")
+	suite.Require().Nil(err)
+	defer os.RemoveAll(dir)
+
+	v1File := filepath.Join(dir, "v1.json")
+	v2File := filepath.Join(dir, "v2.json")
+	err = ioutil.WriteFile(v1File, []byte(
+		`{"reputations":[{"username":"user1","customer_score":100,"free_score":23}]}`), 0644)
+	suite.Require().Nil(err)
+	n, err := MigrateFileFromV1(v1File, v2File, NewInt(0))
+	suite.Require().Nil(err)
+	suite.Equal(1, n)
+
+	rep := NewReputation(
+		NewReputationStore(internal.NewMockStore(), DefaultInitialReputation),
+		DefaultBestN, DefaultUserMaxN,
+		DefaultRoundDurationSeconds, DefaultSampleWindowSize, DefaultDecayFactor)
+	suite.Require().Nil(rep.ImportFromFile(v2File))
+	suite.Equal("1476", rep.GetReputation("user1").String())
+
+	_, err = MigrateFileFromV1(filepath.Join(dir, "nonexist.json"), v2File, NewInt(0))
+	suite.NotNil(err)
+}
@@ -14,8 +14,13 @@ type Reputation interface {
 	// Note that if migrate is required, it must be done before donate.
 	DonateAt(u Uid, p Pid, s LinoCoin) IF
 
-	// user's freescore += @p r, NOTE: unit is COIN.
-	IncFreeScore(u Uid, r Rep)
+	// Same as DonateAt, except that the sum of impact factors of donations from
+	// accounts of cluster @p c to post @p p in a round is at most @p limit.
+	DonateAtCluster(u Uid, p Pid, s LinoCoin, c Uid, limit IF) IF
+
+	// user's freescore += @p r, NOTE: unit is COIN. Freescore is floored at zero,
+	// returns the change actually applied.
+	IncFreeScore(u Uid, r Rep) Rep
 
 	// needs to be called every endblocker.
 	Update(t Time)
@@ -28,6 +33,19 @@ type Reputation interface {
 	// start time is set to 0.
 	GetCurrentRound() (RoundId, Time) // current round and its start time.
 
+	// summary of round @p r, topN of a ongoing round may still change.
+	GetRoundInfo(r RoundId) RoundInfo
+
+	// breakdown of user's reputation, unsettled donations are settled first.
+	GetUserInfo(u Uid) UserInfo
+
+	// reputation settled in the last DefaultUserHistorySize rounds that user donated, latest last.
+	GetUserHistory(u Uid) []ReputationRecord
+
+	// reputations of all users, unsettled donations are settled first.
+	Export() *UserReputationTable
+	Import(tb *UserReputationTable)
+
 	// ExportImporter
 	ExportToFile(file string) error
 	ImportFromFile(file string) error
@@ -55,32 +73,24 @@ func NewReputation(s ReputationStore, BestN int, UserMaxN int, RoundDurationSeco
 	}
 }
 
-// ExportToFile - implementing ExporteImporter
-func (rep ReputationImpl) ExportToFile(file string) error {
+// Export - implementing Reputation
+func (rep ReputationImpl) Export() *UserReputationTable {
 	// before calling store's export, update reputation.
 	rep.store.IterateUsers(func(u Uid) bool {
 		rep.GetReputation(u)
 		return false
 	})
-	rst := rep.store.Export()
-	f, err := os.Create(file)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %s", err)
-	}
-	defer f.Close()
-	jsonbytes, err := cdc.MarshalJSON(rst)
-	if err != nil {
-		return fmt.Errorf("failed to marshal json for " + file + " due to " + err.Error())
-	}
-	_, err = f.Write(jsonbytes)
-	if err != nil {
-		return err
-	}
-	err = f.Sync()
-	if err != nil {
-		return err
-	}
-	return nil
+	return rep.store.Export()
+}
+
+// Import - implementing Reputation
+func (rep ReputationImpl) Import(tb *UserReputationTable) {
+	rep.store.Import(tb)
+}
+
+// ExportToFile - implementing ExporteImporter
+func (rep ReputationImpl) ExportToFile(file string) error {
+	return writeTableToFile(file, rep.Export())
 }
 
 // ImportFromFile - implementing ExporteImporter
@@ -99,7 +109,7 @@ func (rep ReputationImpl) ImportFromFile(file string) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal: " + err.Error())
 	}
-	rep.store.Import(dt)
+	rep.Import(dt)
 	return nil
 }
 
@@ -199,8 +209,9 @@ func (rep ReputationImpl) computeNewRepData(repData reputationData, consumptions
 	return repData
 }
 
-// update @p user with information of @p current round.
-func (rep ReputationImpl) updateReputation(user *userMeta, current RoundId) {
+// update @p user of @p u with information of @p current round, the settled
+// reputation is appended to user's history.
+func (rep ReputationImpl) updateReputation(u Uid, user *userMeta, current RoundId) {
 	// needs to update user's reputation only when the last settled
 	// round is less than the last donation round, *and* current round
 	// is newer than last donation round(i.e. the last donation round has ended).
@@ -229,6 +240,24 @@ func (rep ReputationImpl) updateReputation(user *userMeta, current RoundId) {
 	user.Reputation = newrep.reputation
 	user.LastSettledRound = user.LastDonationRound
 	user.Unsettled = nil
+	rep.appendHistory(u, user.LastSettledRound, user.Reputation)
+}
+
+// append reputation settled in @p round to history of @p u, keeping
+// the latest DefaultUserHistorySize records only.
+func (rep ReputationImpl) appendHistory(u Uid, round RoundId, reputation Rep) {
+	history := rep.store.GetUserHistory(u)
+	defer func() {
+		rep.store.SetUserHistory(u, history)
+	}()
+	history.Records = append(history.Records, ReputationRecord{
+		Round:      round,
+		StartAt:    rep.store.GetRoundMeta(round).StartAt,
+		Reputation: reputation.Clone(),
+	})
+	if len(history.Records) > DefaultUserHistorySize {
+		history.Records = history.Records[len(history.Records)-DefaultUserHistorySize:]
+	}
 }
 
 // return the reputation of @p u.
@@ -238,7 +267,7 @@ func (rep ReputationImpl) GetReputation(u Uid) Rep {
 		rep.store.SetUserMeta(u, user)
 	}()
 	current := rep.store.GetCurrentRound()
-	rep.updateReputation(user, current)
+	rep.updateReputation(u, user, current)
 	return user.Reputation
 }
 
@@ -256,23 +285,48 @@ func (rep ReputationImpl) DonateAt(u Uid, p Pid, amount LinoCoin) IF {
 	if len(p) == 0 {
 		panic("Length of Pid must be longer than 0")
 	}
+	return rep.donateAt(u, p, amount, amount)
+}
+
+// DonateAtCluster - cluster @p c is identified by caller, e.g. accounts sharing
+// a referrer. Records of clusters are removed when the round ends.
+func (rep ReputationImpl) DonateAtCluster(u Uid, p Pid, amount LinoCoin, c Uid, limit IF) IF {
+	if len(u) == 0 {
+		panic("Length of Uid must be longer than 0")
+	}
+	if len(p) == 0 {
+		panic("Length of Pid must be longer than 0")
+	}
+	var current RoundId = rep.store.GetCurrentRound()
+	cluster := rep.store.GetRoundClusterPostMeta(current, c, p)
+	defer func() {
+		rep.store.SetRoundClusterPostMeta(current, c, p, cluster)
+	}()
+	remaining := IntMax(IntSub(limit, cluster.SumIF), NewInt(0))
+	impact := rep.donateAt(u, p, amount, remaining)
+	cluster.SumIF.Add(impact)
+	return impact
+}
+
+// donateAt: record the donation, the impact factor is at most @p maxImpact.
+func (rep ReputationImpl) donateAt(u Uid, p Pid, amount LinoCoin, maxImpact IF) IF {
 	var current RoundId = rep.store.GetCurrentRound()
 	user := rep.store.GetUserMeta(u)
 	defer func() {
 		rep.store.SetUserMeta(u, user)
 	}()
-	rep.updateReputation(user, current)
+	rep.updateReputation(u, user, current)
 	user.LastDonationRound = current
-	impact := rep.appendDonation(user, p, amount)
+	impact := rep.appendDonation(user, p, amount, maxImpact)
 	rep.incRoundPostSumImpact(current, p, impact)
 	return impact
 }
 
 // appendDonation: append a new donation to user's unsettled list, return the impact
-// factor of this donation.
+// factor of this donation, which is at most @p maxImpact.
 // contract: before calling this, user's reputation needs to
 //           be updated by calling updateReputation.
-func (rep ReputationImpl) appendDonation(user *userMeta, post Pid, amount LinoCoin) IF {
+func (rep ReputationImpl) appendDonation(user *userMeta, post Pid, amount LinoCoin, maxImpact IF) IF {
 	reputation := user.Reputation
 	pos := -1
 	used := NewInt(0)
@@ -286,7 +340,7 @@ func (rep ReputationImpl) appendDonation(user *userMeta, post Pid, amount LinoCo
 		return NewInt(0)
 	}
 	var available IF = IntMax(IntSub(reputation, used), NewInt(0))
-	var impact IF = IntMin(available, amount)
+	var impact IF = IntMin(IntMin(available, amount), maxImpact)
 	if pos != -1 {
 		user.Unsettled[pos].Amount.Add(amount)
 		user.Unsettled[pos].Impact.Add(impact)
@@ -359,17 +413,57 @@ func (rep ReputationImpl) GetCurrentRound() (RoundId, Time) {
 	return rid, rep.store.GetRoundMeta(rid).StartAt
 }
 
+// return the summary of @p r round.
+func (rep ReputationImpl) GetRoundInfo(r RoundId) RoundInfo {
+	meta := rep.store.GetRoundMeta(r)
+	return RoundInfo{
+		Id:      r,
+		StartAt: meta.StartAt,
+		SumIF:   meta.SumIF,
+		TopN:    meta.TopN,
+		Result:  meta.Result,
+	}
+}
+
+// return the reputation breakdown of @p u, same as GetReputation, the user's
+// reputation is updated before returning.
+func (rep ReputationImpl) GetUserInfo(u Uid) UserInfo {
+	user := rep.store.GetUserMeta(u)
+	defer func() {
+		rep.store.SetUserMeta(u, user)
+	}()
+	current := rep.store.GetCurrentRound()
+	rep.updateReputation(u, user, current)
+	return UserInfo{
+		Reputation:        user.Reputation,
+		Consumption:       user.Consumption,
+		Hold:              user.Hold,
+		LastSettledRound:  user.LastSettledRound,
+		LastDonationRound: user.LastDonationRound,
+		Unsettled:         user.Unsettled,
+	}
+}
+
+// return reputation settled in past rounds of @p u, latest last.
+func (rep ReputationImpl) GetUserHistory(u Uid) []ReputationRecord {
+	// settle the last donation round if it has ended.
+	rep.GetReputation(u)
+	return rep.store.GetUserHistory(u).Records
+}
+
 // increase @p u user's reputation by @p score.
 // To make added score permanent, add it on consumption, as reputation is
 // only a temporory result, same in reputation migration.
-func (rep ReputationImpl) IncFreeScore(u Uid, score Rep) {
+func (rep ReputationImpl) IncFreeScore(u Uid, score Rep) Rep {
 	user := rep.store.GetUserMeta(u)
 	defer func() {
 		rep.store.SetUserMeta(u, user)
 	}()
+	prev := user.Consumption.Clone()
 	user.Consumption.Add(score)
 	user.Consumption = IntMax(user.Consumption, NewInt(0))
 	user.Reputation = rep.computeReputation(user.Consumption, user.Hold)
+	return IntSub(user.Consumption, prev)
 }
 
 // On BlockEnd(@p t), select out the seed set of the current round and start
@@ -414,6 +508,8 @@ func (rep ReputationImpl) StartNewRound(t Time) {
 	defer func() {
 		rep.store.SetGameMeta(gameMeta)
 	}()
+	// cluster records are only used in the round.
+	rep.store.DelRoundClusterPostMetas(gameMeta.CurrentRound)
 	newRoundId := gameMeta.CurrentRound + 1
 	gameMeta.CurrentRound = newRoundId
 
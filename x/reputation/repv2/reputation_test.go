@@ -432,7 +432,7 @@ func (suite *ReputationTestSuite) TestUpdateReputationDonateAt() {
 	suite.MoveToNewRound()
 	for i, v := range cases {
 		user := rep.store.GetUserMeta(v.user)
-		rep.updateReputation(user, 2)
+		rep.updateReputation(v.user, user, 2)
 		suite.Equal(v.expected, user, "case: %d", i)
 	}
 }
@@ -504,7 +504,7 @@ func (suite *ReputationTestSuite) TestAppendDonation() {
 	}
 
 	for i, c := range cases {
-		impact := rep.appendDonation(user, c.post, c.amount)
+		impact := rep.appendDonation(user, c.post, c.amount, c.amount)
 		suite.Equal(c.expectedImpact, impact, "case: %d", i)
 		suite.Equal(c.expected, user, "case: %d", i)
 	}
@@ -562,6 +562,88 @@ func (suite *ReputationTestSuite) TestIncRoundPostSumImpactAndUpdate() {
 	}
 }
 
+func (suite *ReputationTestSuite) TestGetRoundInfoAndUserInfo() {
+	rep := suite.rep
+	suite.MoveToNewRound()
+	rep.DonateAt("user1", "post1", NewInt(100))
+	rep.DonateAt("user2", "post2", NewInt(100))
+
+	info := rep.GetRoundInfo(2)
+	suite.Equal(RoundId(2), info.Id)
+	suite.Equal(Time(suite.time.Unix()), info.StartAt)
+	suite.Equal(NewInt(2), info.SumIF)
+	suite.Equal([]PostIFPair{{Pid: "post1", SumIF: NewInt(1)}, {Pid: "post2", SumIF: NewInt(1)}}, info.TopN)
+	suite.Nil(info.Result)
+
+	user := rep.GetUserInfo("user1")
+	suite.Equal(NewInt(DefaultInitialReputation), user.Reputation)
+	suite.Equal(NewInt(DefaultInitialReputation), user.Consumption)
+	suite.EqualZero(user.Hold)
+	suite.Equal(RoundId(0), user.LastSettledRound)
+	suite.Equal(RoundId(2), user.LastDonationRound)
+	suite.Equal([]Donation{{Pid: "post1", Amount: NewInt(100), Impact: NewInt(1)}}, user.Unsettled)
+
+	suite.MoveToNewRound()
+	info = rep.GetRoundInfo(2)
+	suite.Equal([]Pid{"post1"}, info.Result)
+
+	user = rep.GetUserInfo("user1")
+	suite.Equal(RoundId(2), user.LastSettledRound)
+	suite.Nil(user.Unsettled)
+	suite.Equal(rep.GetReputation("user1"), user.Reputation)
+}
+
+func (suite *ReputationTestSuite) TestUserHistory() {
+	rep := suite.rep
+	suite.Empty(rep.GetUserHistory("user1"))
+
+	starts := []Time{}
+	for i := 0; i < DefaultUserHistorySize+2; i++ {
+		suite.MoveToNewRound()
+		starts = append(starts, Time(suite.time.Unix()))
+		rep.DonateAt("user1", "post1", NewInt(100))
+	}
+	// last donation round has not ended yet.
+	history := rep.GetUserHistory("user1")
+	suite.Equal(DefaultUserHistorySize, len(history))
+	suite.Equal(RoundId(3), history[0].Round)
+	suite.Equal(starts[1], history[0].StartAt)
+
+	suite.MoveToNewRound()
+	history = rep.GetUserHistory("user1")
+	suite.Equal(DefaultUserHistorySize, len(history))
+	last := history[len(history)-1]
+	suite.Equal(RoundId(DefaultUserHistorySize+3), last.Round)
+	suite.Equal(starts[len(starts)-1], last.StartAt)
+	suite.Equal(rep.GetReputation("user1"), last.Reputation)
+}
+
+func (suite *ReputationTestSuite) TestDonateAtCluster() {
+	rep := suite.rep
+	suite.MoveToNewRound()
+	rep.IncFreeScore("user1", NewInt(1000))
+	rep.IncFreeScore("user2", NewInt(1000))
+	rep.IncFreeScore("user3", NewInt(1000))
+
+	suite.Equal(NewInt(300), rep.DonateAtCluster("user1", "post1", NewInt(300), "ref", NewInt(500)))
+	suite.Equal(NewInt(200), rep.DonateAtCluster("user2", "post1", NewInt(300), "ref", NewInt(500)))
+	suite.EqualZero(rep.DonateAtCluster("user3", "post1", NewInt(300), "ref", NewInt(500)))
+	// other posts and clusters are not affected.
+	suite.Equal(NewInt(300), rep.DonateAtCluster("user3", "post2", NewInt(300), "ref", NewInt(500)))
+	suite.Equal(NewInt(300), rep.DonateAtCluster("user3", "post1", NewInt(300), "ref2", NewInt(500)))
+	suite.Equal(NewInt(800), rep.GetRoundInfo(2).TopN[0].SumIF)
+
+	// capped impact factor is recorded in user's donation, amount is not.
+	info := rep.GetUserInfo("user2")
+	suite.Equal([]Donation{{Pid: "post1", Amount: NewInt(300), Impact: NewInt(200)}}, info.Unsettled)
+
+	// records are cleared when a new round starts.
+	suite.MoveToNewRound()
+	suite.EqualZero(suite.store.GetRoundClusterPostMeta(2, "ref", "post1").SumIF)
+	rep.IncFreeScore("user4", NewInt(1000))
+	suite.Equal(NewInt(300), rep.DonateAtCluster("user4", "post1", NewInt(300), "ref", NewInt(500)))
+}
+
 func (suite *ReputationTestSuite) TestFirstBlock1() {
 	rep := suite.rep
 	newBlockTime := Time(0)
@@ -590,8 +672,11 @@ func (suite *ReputationTestSuite) TestFirstBlock2() {
 
 func (suite *ReputationTestSuite) TestIncFreeScore() {
 	rep := suite.rep
-	rep.IncFreeScore("user1", NewInt(3000))
+	suite.Equal(NewInt(3000), rep.IncFreeScore("user1", NewInt(3000)))
 	suite.Equal(NewInt(3000+DefaultInitialReputation), rep.GetReputation("user1"))
+	// free score is floored at zero.
+	suite.Equal(NewInt(-3000), rep.IncFreeScore("user1", NewInt(-5000)))
+	suite.Equal(0, rep.GetReputation("user1").Cmp(NewInt(0)))
 }
 
 func (suite *ReputationTestSuite) TestDonationReturnDp1() {
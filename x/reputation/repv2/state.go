@@ -2,10 +2,11 @@ package repv2
 
 // UserReputation - pk: Username
 type UserReputation struct {
-	Username      Uid  `json:"username"`
-	CustomerScore Rep  `json:"customer_score"`
-	FreeScore     Rep  `json:"free_score"`
-	IsMiniDollar  bool `json:"is_mini_dollar,omitempty"`
+	Username      Uid                `json:"username"`
+	CustomerScore Rep                `json:"customer_score"`
+	FreeScore     Rep                `json:"free_score"`
+	IsMiniDollar  bool               `json:"is_mini_dollar,omitempty"`
+	History       []ReputationRecord `json:"history,omitempty"`
 }
 
 // UserReputationTable - pk by Username
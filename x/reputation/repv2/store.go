@@ -54,6 +54,16 @@ type ReputationStore interface {
 	// Global data.
 	GetGameMeta() *gameMeta
 	SetGameMeta(dt *gameMeta)
+
+	// reputation settled in past rounds, latest last.
+	GetUserHistory(u Uid) *userHistory
+	SetUserHistory(u Uid, dt *userHistory)
+
+	// total impact factor of donations from accounts of cluster @p c to @p post in @p r round.
+	GetRoundClusterPostMeta(r RoundId, c Uid, p Pid) *roundClusterPostMeta
+	SetRoundClusterPostMeta(r RoundId, c Uid, p Pid, dt *roundClusterPostMeta)
+	// delete all cluster records of @p r round.
+	DelRoundClusterPostMetas(r RoundId)
 }
 
 // This store implementation does not have state. It is just a wrapper of read/write of
@@ -68,6 +78,8 @@ var (
 	repRoundMetaPrefix     = []byte{0x01}
 	repRoundPostMetaPrefix = []byte{0x02}
 	repGameMetaPrefix      = []byte{0x03}
+	repUserHistoryPrefix   = []byte{0x04}
+	repRoundClusterPrefix  = []byte{0x05}
 )
 
 // LastSettled: till which round has user settled, i.e.
@@ -104,6 +116,15 @@ type gameMeta struct {
 	CurrentRound RoundId `json:"current_round"`
 }
 
+// Records: reputation settled in past rounds, bounded by caller.
+type userHistory struct {
+	Records []ReputationRecord `json:"records"`
+}
+
+type roundClusterPostMeta struct {
+	SumIF IF `json:"s_if"`
+}
+
 func getUserMetaKey(u Uid) []byte {
 	return append(repUserMetaPrefix, []byte(u)...)
 }
@@ -121,6 +142,20 @@ func getGameKey() []byte {
 	return repGameMetaPrefix
 }
 
+func getUserHistoryKey(u Uid) []byte {
+	return append(repUserHistoryPrefix, []byte(u)...)
+}
+
+func getRoundClusterPrefix(r RoundId) []byte {
+	prefix := append(repRoundClusterPrefix, strconv.FormatInt(int64(r), 36)...)
+	return append(prefix, KeySeparator)
+}
+
+func getRoundClusterPostMetaKey(r RoundId, c Uid, p Pid) []byte {
+	prefix := append(getRoundClusterPrefix(r), []byte(c)...)
+	return append(append(prefix, KeySeparator), []byte(p)...)
+}
+
 // no state.
 type reputationStoreImpl struct {
 	store          Store
@@ -151,6 +186,7 @@ func (impl reputationStoreImpl) Export() *UserReputationTable {
 			CustomerScore: v.Reputation,
 			FreeScore:     NewInt(0),
 			IsMiniDollar:  true,
+			History:       impl.GetUserHistory(uid).Records,
 		})
 
 		return false
@@ -162,13 +198,15 @@ func (impl reputationStoreImpl) Import(tb *UserReputationTable) {
 	for _, v := range tb.Reputations {
 		rep := IntAdd(v.FreeScore, v.CustomerScore)
 		// when import from upgrade-1, do a unit conversion.
-		// 1 testnetcoin = (10^-5 * 0.012) USD = 12 MiniUSD
 		if !v.IsMiniDollar {
-			rep.Mul(NewInt(12))
+			rep.Mul(NewInt(testnetCoinToMiniDollar))
 		}
 		impl.SetUserMeta(v.Username, &userMeta{
 			Reputation: rep,
 		})
+		if len(v.History) > 0 {
+			impl.SetUserHistory(v.Username, &userHistory{Records: v.History})
+		}
 	}
 }
 
@@ -258,4 +296,51 @@ func (impl reputationStoreImpl) GetCurrentRound() RoundId {
 	return rst.CurrentRound
 }
 
+func (impl reputationStoreImpl) GetUserHistory(u Uid) *userHistory {
+	buf := impl.store.Get(getUserHistoryKey(u))
+	rst := decodeUserHistory(buf)
+	if rst == nil {
+		return &userHistory{
+			Records: nil,
+		}
+	}
+	return rst
+}
+
+func (impl reputationStoreImpl) SetUserHistory(u Uid, dt *userHistory) {
+	if dt != nil {
+		impl.store.Set(getUserHistoryKey(u), encodeUserHistory(dt))
+	}
+}
+
+func (impl reputationStoreImpl) GetRoundClusterPostMeta(r RoundId, c Uid, p Pid) *roundClusterPostMeta {
+	buf := impl.store.Get(getRoundClusterPostMetaKey(r, c, p))
+	rst := decodeRoundClusterPostMeta(buf)
+	if rst == nil {
+		return &roundClusterPostMeta{
+			SumIF: NewInt(0),
+		}
+	}
+	return rst
+}
+
+func (impl reputationStoreImpl) SetRoundClusterPostMeta(r RoundId, c Uid, p Pid, dt *roundClusterPostMeta) {
+	if dt != nil {
+		impl.store.Set(getRoundClusterPostMetaKey(r, c, p), encodeRoundClusterPostMeta(dt))
+	}
+}
+
+func (impl reputationStoreImpl) DelRoundClusterPostMetas(r RoundId) {
+	prefix := getRoundClusterPrefix(r)
+	var keys [][]byte
+	itr := impl.store.Iterator(prefix, PrefixEndBytes(prefix))
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, itr.Key())
+	}
+	itr.Close()
+	for _, key := range keys {
+		impl.store.Delete(key)
+	}
+}
+
 var _ ReputationStore = &reputationStoreImpl{}
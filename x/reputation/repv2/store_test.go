@@ -31,6 +31,9 @@ func (suite *StoreTestSuite) TestPrefix() {
 		[]byte{byte('2'), byte('f'), byte('/'), byte('a'), byte('b'), byte('c'), byte('d')}...),
 		getRoundPostMetaKey(87, "abcd"))
 	suite.Equal(repGameMetaPrefix, getGameKey())
+	suite.Equal(append(repUserHistoryPrefix, []byte("qwe")...), getUserHistoryKey("qwe"))
+	suite.Equal(append(repRoundClusterPrefix, []byte("b/ref/xy")...),
+		getRoundClusterPostMetaKey(11, "ref", "xy"))
 }
 
 func (suite *StoreTestSuite) TestInitValues() {
@@ -137,6 +140,23 @@ func (suite *StoreTestSuite) TestStoreGetSet() {
 		suite.Equal(rp2, store.GetRoundPostMeta(342, post1))
 	}()
 
+	rc1 := &roundClusterPostMeta{
+		SumIF: NewInt(123),
+	}
+	rc2 := &roundClusterPostMeta{
+		SumIF: NewInt(456),
+	}
+	store.SetRoundClusterPostMeta(3, "ref", post1, rc1)
+	store.SetRoundClusterPostMeta(3, "ref2", post1, rc1)
+	store.SetRoundClusterPostMeta(30, "ref", post1, rc2)
+	suite.Equal(rc1, store.GetRoundClusterPostMeta(3, "ref", post1))
+	store.DelRoundClusterPostMetas(3)
+	defer func() {
+		suite.Equal(&roundClusterPostMeta{SumIF: NewInt(0)}, store.GetRoundClusterPostMeta(3, "ref", post1))
+		suite.Equal(&roundClusterPostMeta{SumIF: NewInt(0)}, store.GetRoundClusterPostMeta(3, "ref2", post1))
+		suite.Equal(rc2, store.GetRoundClusterPostMeta(30, "ref", post1))
+	}()
+
 	store.SetGameMeta(&gameMeta{CurrentRound: 33})
 	store.SetGameMeta(&gameMeta{CurrentRound: 443})
 	defer func() {
@@ -170,6 +190,11 @@ func (suite *StoreTestSuite) TestStoreImportExporter() {
 		}}
 	store.SetUserMeta(user1, u1)
 	store.SetUserMeta(user2, u2)
+	h1 := &userHistory{Records: []ReputationRecord{
+		{Round: 2, StartAt: 100, Reputation: NewInt(100)},
+		{Round: 3, StartAt: 200, Reputation: NewInt(123)},
+	}}
+	store.SetUserHistory(user1, h1)
 
 	// export data
 	data := store.Export()
@@ -184,6 +209,8 @@ func (suite *StoreTestSuite) TestStoreImportExporter() {
 	suite.Equal(RoundId(0), store2.GetUserMeta(user2).LastSettledRound)
 	suite.Empty(store2.GetUserMeta(user1).Unsettled)
 	suite.Empty(store2.GetUserMeta(user2).Unsettled)
+	suite.Equal(h1, store2.GetUserHistory(user1))
+	suite.Empty(store2.GetUserHistory(user2).Records)
 }
 
 func (suite *StoreTestSuite) TestStoreImportExporterFromUpgrade1() {
@@ -26,3 +26,31 @@ type Donation struct {
 	Amount LinoCoin `json:"a"`
 	Impact IF       `json:"i"`
 }
+
+// ReputationRecord - user's reputation settled for a round.
+type ReputationRecord struct {
+	Round      RoundId `json:"round"`
+	StartAt    Time    `json:"start_at"`
+	Reputation Rep     `json:"reputation"`
+}
+
+// RoundInfo - summary of a round. TopN is the leaderboard of posts ordered
+// by sum of impact factors, Result is the seed set selected when the round ends.
+type RoundInfo struct {
+	Id      RoundId      `json:"id"`
+	StartAt Time         `json:"start_at"`
+	SumIF   IF           `json:"sum_if"`
+	TopN    []PostIFPair `json:"top_n"`
+	Result  []Pid        `json:"result"`
+}
+
+// UserInfo - breakdown of user's reputation. Free score is added into
+// consumption, so Consumption is customer score plus free score.
+type UserInfo struct {
+	Reputation        Rep        `json:"reputation"`
+	Consumption       Rep        `json:"consumption"`
+	Hold              Rep        `json:"hold"`
+	LastSettledRound  RoundId    `json:"last_settled_round"`
+	LastDonationRound RoundId    `json:"last_donation_round"`
+	Unsettled         []Donation `json:"unsettled"`
+}
@@ -10,6 +10,7 @@ import (
 	wire "github.com/cosmos/cosmos-sdk/codec"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/validator"
 	"github.com/lino-network/lino/x/validator/model"
 )
 
@@ -39,6 +40,15 @@ func GetValidatorCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetUptimeCmd returns signing statistics and missed blocks of a validator
+func GetUptimeCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validator-uptime <username>",
+		Short: "Query uptime and missed blocks of a validator in the signing window",
+		RunE:  getUptimeCmd(cdc),
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -90,3 +100,28 @@ func (c commander) getValidatorCmd(cmd *cobra.Command, args []string) error {
 	fmt.Println(string(output))
 	return nil
 }
+
+func getUptimeCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a username")
+		}
+
+		res, err := ctx.QueryCustom(validator.QuerierRoute, validator.QueryUptime, args[0])
+		if err != nil {
+			return err
+		}
+		uptime := new(validator.ValidatorUptime)
+		if err := cdc.UnmarshalJSON(res, uptime); err != nil {
+			return err
+		}
+
+		output, err := json.MarshalIndent(uptime, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
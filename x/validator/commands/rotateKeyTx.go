@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/validator"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	pvm "github.com/tendermint/tendermint/privval"
+)
+
+// RotateKeyTxCmd will create a rotate validator key tx and sign it with the given key
+func RotateKeyTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-rotate-key",
+		Short: "change validator public key to the one in new validator key file",
+		RunE:  sendRotateKeyTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	cmd.Flags().String(client.FlagValidatorKeyFile, "", "new priv_validator_key.json of the validator")
+	return cmd
+}
+
+// send rotate key transaction to the blockchain
+func sendRotateKeyTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		name := viper.GetString(client.FlagUser)
+
+		keyFile := viper.GetString(client.FlagValidatorKeyFile)
+		if !cmn.FileExists(keyFile) {
+			return errors.New("You must provide an existing validator key file")
+		}
+		pubKey := pvm.LoadFilePVEmptyState(keyFile, "").GetPubKey()
+
+		// create the message
+		msg := validator.NewRotateValidatorKeyMsg(name, pubKey)
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/validator"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UpdateValidatorTxCmd will create a validator update tx and sign it with the given key
+func UpdateValidatorTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-update",
+		Short: "update validator profile",
+		RunE:  sendUpdateValidatorTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	cmd.Flags().String(client.FlagLink, "", "website of the validator")
+	cmd.Flags().String(client.FlagDescription, "", "description of the validator")
+	cmd.Flags().String(client.FlagContact, "", "contact of the validator")
+	cmd.Flags().String(client.FlagIdentity, "", "identity of the validator, e.g. keybase fingerprint")
+	return cmd
+}
+
+// send validator update transaction to the blockchain
+func sendUpdateValidatorTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		name := viper.GetString(client.FlagUser)
+
+		// create the message
+		msg := validator.NewValidatorUpdateMsg(
+			name,
+			viper.GetString(client.FlagLink),
+			viper.GetString(client.FlagDescription),
+			viper.GetString(client.FlagContact),
+			viper.GetString(client.FlagIdentity))
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
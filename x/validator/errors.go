@@ -51,3 +51,43 @@ func ErrValidatorPubKeyAlreadyExist() sdk.Error {
 func ErrQueryFailed() sdk.Error {
 	return types.NewError(types.CodeValidatorQueryFailed, fmt.Sprintf("query validator store failed"))
 }
+
+// ErrValidatorJailed - error if a jailed validator tries to become oncall validator
+func ErrValidatorJailed() sdk.Error {
+	return types.NewError(types.CodeValidatorJailed, fmt.Sprintf("validator is jailed"))
+}
+
+// ErrValidatorNotJailed - error if a validator who is not jailed tries to unjail
+func ErrValidatorNotJailed() sdk.Error {
+	return types.NewError(types.CodeValidatorNotJailed, fmt.Sprintf("validator is not jailed"))
+}
+
+// ErrJailPeriodNotOver - error if validator tries to unjail before jail period ends
+func ErrJailPeriodNotOver() sdk.Error {
+	return types.NewError(types.CodeJailPeriodNotOver, fmt.Sprintf("jail period is not over"))
+}
+
+// ErrValidatorTombstoned - error if a tombstoned validator tries to deposit or unjail
+func ErrValidatorTombstoned() sdk.Error {
+	return types.NewError(types.CodeValidatorTombstoned, fmt.Sprintf("validator has been tombstoned"))
+}
+
+// ErrInvalidDescription - error if validator description is invalid
+func ErrInvalidDescription() sdk.Error {
+	return types.NewError(types.CodeInvalidDescription, fmt.Sprintf("invalid description"))
+}
+
+// ErrInvalidContact - error if validator contact is invalid
+func ErrInvalidContact() sdk.Error {
+	return types.NewError(types.CodeInvalidValidatorContact, fmt.Sprintf("invalid contact"))
+}
+
+// ErrInvalidIdentity - error if validator identity is invalid
+func ErrInvalidIdentity() sdk.Error {
+	return types.NewError(types.CodeInvalidValidatorIdentity, fmt.Sprintf("invalid identity"))
+}
+
+// ErrInvalidValidatorPubKey - error if validator public key is invalid
+func ErrInvalidValidatorPubKey() sdk.Error {
+	return types.NewError(types.CodeInvalidValidatorPubKey, fmt.Sprintf("invalid validator public key"))
+}
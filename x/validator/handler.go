@@ -21,6 +21,12 @@ func NewHandler(am acc.AccountManager, valManager ValidatorManager, voteManager
 			return handleWithdrawMsg(ctx, valManager, gm, am, msg)
 		case ValidatorRevokeMsg:
 			return handleRevokeMsg(ctx, valManager, gm, am, msg)
+		case ValidatorUnjailMsg:
+			return handleUnjailMsg(ctx, valManager, msg)
+		case RotateValidatorKeyMsg:
+			return handleRotateValidatorKeyMsg(ctx, valManager, msg)
+		case ValidatorUpdateMsg:
+			return handleValidatorUpdateMsg(ctx, valManager, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized validator msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -73,11 +79,16 @@ func handleDepositMsg(
 		return ErrUnbalancedAccount().Result()
 	}
 
+	// jailed validator has to unjail before becoming oncall validator again
+	if valManager.IsJailed(ctx, msg.Username) {
+		return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.ValidatorDeposit)}
+	}
+
 	// Try to become oncall validator
 	if err := valManager.TryBecomeOncallValidator(ctx, msg.Username); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.ValidatorDeposit)}
 }
 
 // Handle Withdraw Msg
@@ -107,7 +118,7 @@ func handleWithdrawMsg(
 		param.ValidatorCoinReturnIntervalSec, coin); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.ValidatorReturnCoin)}
 }
 
 func handleRevokeMsg(
@@ -132,7 +143,32 @@ func handleRevokeMsg(
 		param.ValidatorCoinReturnIntervalSec, coin); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.ValidatorReturnCoin)}
+}
+
+func handleUnjailMsg(
+	ctx sdk.Context, vm ValidatorManager, msg ValidatorUnjailMsg) sdk.Result {
+	if err := vm.UnjailValidator(ctx, msg.Username); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
+}
+
+func handleRotateValidatorKeyMsg(
+	ctx sdk.Context, vm ValidatorManager, msg RotateValidatorKeyMsg) sdk.Result {
+	if err := vm.RotateValidatorKey(ctx, msg.Username, msg.NewValPubKey); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
+}
+
+func handleValidatorUpdateMsg(
+	ctx sdk.Context, vm ValidatorManager, msg ValidatorUpdateMsg) sdk.Result {
+	if err := vm.UpdateValidator(
+		ctx, msg.Username, msg.Link, msg.Description, msg.Contact, msg.Identity); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
 }
 
 func returnCoinTo(
@@ -3,10 +3,12 @@ package validator
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/validator/model"
 	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 
@@ -30,7 +32,7 @@ func TestRegisterBasic(t *testing.T) {
 	valKey := secp256k1.GenPrivKey().PubKey()
 	msg := NewValidatorDepositMsg("user1", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	// check acc1's money has been withdrawn
 	acc1Balance, _ := am.GetSavingFromBank(ctx, user1)
@@ -96,7 +98,7 @@ func TestRevokeBasic(t *testing.T) {
 	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
 	msg := NewValidatorDepositMsg("user1", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	// now user1 should be the only validator
 	verifyList, _ := valManager.storage.GetValidatorList(ctx)
@@ -106,7 +108,7 @@ func TestRevokeBasic(t *testing.T) {
 	// let user1 revoke candidancy
 	msg2 := NewValidatorRevokeMsg("user1")
 	result2 := handler(ctx, msg2)
-	assert.Equal(t, sdk.Result{}, result2)
+	assert.Equal(t, returnResult("user1", valParam.ValidatorMinCommittingDeposit), result2)
 
 	verifyList2, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, 0, len(verifyList2.OncallValidators))
@@ -150,7 +152,7 @@ func TestRevokeOncallValidatorAndSubstitutionExists(t *testing.T) {
 		valKeys[i] = secp256k1.GenPrivKey().PubKey()
 		msg := NewValidatorDepositMsg("user"+strconv.Itoa(i+1), deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	lst, _ := valManager.storage.GetValidatorList(ctx)
@@ -165,7 +167,7 @@ func TestRevokeOncallValidatorAndSubstitutionExists(t *testing.T) {
 	result := handler(ctx, msg)
 
 	lst2, _ := valManager.storage.GetValidatorList(ctx)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 	assert.Equal(t, valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(50*types.Decimals)), lst2.LowestPower)
 	assert.Equal(t, users[4], lst2.LowestValidator)
 
@@ -177,11 +179,11 @@ func TestRevokeOncallValidatorAndSubstitutionExists(t *testing.T) {
 
 	withdrawMsg2 := NewValidatorWithdrawMsg("user2", coinToString(valParam.ValidatorMinWithdraw))
 	resultWithdraw2 := handler(ctx, withdrawMsg2)
-	assert.Equal(t, sdk.Result{}, resultWithdraw2)
+	assert.Equal(t, returnResult("user2", valParam.ValidatorMinWithdraw), resultWithdraw2)
 	//revoke a non oncall valodator wont change anything related to oncall list
 	revokeMsg := NewValidatorRevokeMsg("user2")
 	result2 := handler(ctx, revokeMsg)
-	assert.Equal(t, sdk.Result{}, result2)
+	assert.Equal(t, returnResult("user2", valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(20*types.Decimals)).Minus(valParam.ValidatorMinWithdraw)), result2)
 
 	lst3, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(50*types.Decimals)), lst3.LowestPower)
@@ -194,7 +196,7 @@ func TestRevokeOncallValidatorAndSubstitutionExists(t *testing.T) {
 	// list become the lowest validator
 	revokeMsg2 := NewValidatorRevokeMsg("user6")
 	result3 := handler(ctx, revokeMsg2)
-	assert.Equal(t, sdk.Result{}, result3)
+	assert.Equal(t, returnResult("user6", valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(60*types.Decimals))), result3)
 
 	lst4, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(30*types.Decimals)), lst4.LowestPower)
@@ -219,7 +221,7 @@ func TestRevokeAndDepositAgain(t *testing.T) {
 	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
 	msg := NewValidatorDepositMsg("user1", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	lst, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, 1, len(lst.AllValidators))
@@ -228,7 +230,7 @@ func TestRevokeAndDepositAgain(t *testing.T) {
 	// let user1 revoke candidancy
 	msg2 := NewValidatorRevokeMsg("user1")
 	result2 := handler(ctx, msg2)
-	assert.Equal(t, sdk.Result{}, result2)
+	assert.Equal(t, returnResult("user1", valParam.ValidatorMinCommittingDeposit), result2)
 
 	lstEmpty, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, 0, len(lstEmpty.AllValidators))
@@ -239,7 +241,7 @@ func TestRevokeAndDepositAgain(t *testing.T) {
 	result3 := handler(ctx, msg3)
 
 	lst2, _ := valManager.storage.GetValidatorList(ctx)
-	assert.Equal(t, sdk.Result{}, result3)
+	assert.Equal(t, depositResult(msg3), result3)
 	assert.Equal(t, 1, len(lst2.AllValidators))
 	assert.Equal(t, 1, len(lst2.OncallValidators))
 
@@ -262,7 +264,7 @@ func TestWithdrawBasic(t *testing.T) {
 	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
 	msg := NewValidatorDepositMsg("user1", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	// now user1 should be the only validator
 	verifyList, _ := valManager.storage.GetValidatorList(ctx)
@@ -294,7 +296,7 @@ func TestDepositBasic(t *testing.T) {
 	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
 	msg := NewValidatorDepositMsg("user1", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	// check acc1's money has been withdrawn
 	acc1Balance, _ := am.GetSavingFromBank(ctx, user1)
@@ -313,6 +315,59 @@ func TestDepositBasic(t *testing.T) {
 	assert.Equal(t, true, validator.Deposit.IsEqual(valParam.ValidatorMinCommittingDeposit))
 }
 
+func TestUpdateValidator(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, 0)
+	handler := NewHandler(am, valManager, voteManager, &gm)
+	valManager.InitGenesis(ctx)
+
+	valParam, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	voteManager.AddVoter(ctx, "user1", valParam.ValidatorMinVotingDeposit)
+
+	// update profile of non-exist validator
+	updateMsg := NewValidatorUpdateMsg("user1", "https://lino.network", "", "", "")
+	result := handler(ctx, updateMsg)
+	assert.Equal(t, model.ErrValidatorNotFound().Result(), result)
+
+	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
+	msg := NewValidatorDepositMsg("user1", deposit, secp256k1.GenPrivKey().PubKey(), "https://lino.network")
+	result = handler(ctx, msg)
+	assert.Equal(t, depositResult(msg), result)
+
+	updateMsg = NewValidatorUpdateMsg(
+		"user1", "https://validator.lino.network", "lino validator", "validator@lino.network", "0xABCD")
+	result = handler(ctx, updateMsg)
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, result)
+
+	validator, _ := valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, "https://validator.lino.network", validator.Link)
+	assert.Equal(t, "lino validator", validator.Description)
+	assert.Equal(t, "validator@lino.network", validator.Contact)
+	assert.Equal(t, "0xABCD", validator.Identity)
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit, validator.Deposit)
+
+	// update doesn't change validator list
+	verifyList, _ := valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user1}, verifyList.OncallValidators)
+
+	// profile can be cleared
+	updateMsg = NewValidatorUpdateMsg("user1", "", "", "", "")
+	result = handler(ctx, updateMsg)
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, result)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, "", validator.Link)
+	assert.Equal(t, "", validator.Description)
+	assert.Equal(t, "", validator.Contact)
+	assert.Equal(t, "", validator.Identity)
+
+	// tombstoned validator can't update profile
+	validator.Tombstoned = true
+	valManager.storage.SetValidator(ctx, user1, validator)
+	result = handler(ctx, updateMsg)
+	assert.Equal(t, ErrValidatorTombstoned().Result(), result)
+}
+
 func TestCommittingDepositExceedVotingDeposit(t *testing.T) {
 	ctx, am, valManager, voteManager, gm := setupTest(t, 0)
 	handler := NewHandler(am, valManager, voteManager, &gm)
@@ -368,7 +423,7 @@ func TestValidatorReplacement(t *testing.T) {
 		valKeys[i] = secp256k1.GenPrivKey().PubKey()
 		msg := NewValidatorDepositMsg("user"+strconv.Itoa(i+1), deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	// check validator list, the lowest power is 10
@@ -388,11 +443,11 @@ func TestValidatorReplacement(t *testing.T) {
 	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
 	msg := NewValidatorDepositMsg("noPowerUser", deposit, valKey, "")
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	//check the user hasn't been added to oncall validators but in the pool
 	verifyList2, _ := valManager.storage.GetValidatorList(ctx)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 	assert.Equal(t, true,
 		verifyList2.LowestPower.IsEqual(valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(10*types.Decimals))))
 	assert.Equal(t, users[0], verifyList2.LowestValidator)
@@ -409,7 +464,7 @@ func TestValidatorReplacement(t *testing.T) {
 	deposit = coinToString(valParam.ValidatorMinCommittingDeposit.Plus(types.NewCoinFromInt64(88 * types.Decimals)))
 	msg = NewValidatorDepositMsg("powerfulUser", deposit, valKey, "")
 	result = handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, depositResult(msg), result)
 
 	verifyList3, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, true,
@@ -495,6 +550,31 @@ func TestRegisterWithDupKey(t *testing.T) {
 
 }
 
+func TestRotateValidatorKeyHandler(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, 0)
+	handler := NewHandler(am, valManager, voteManager, &gm)
+	valManager.InitGenesis(ctx)
+
+	valParam, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	voteManager.AddVoter(ctx, user1, valParam.ValidatorMinVotingDeposit)
+
+	valKey1 := secp256k1.GenPrivKey().PubKey()
+	valKey2 := secp256k1.GenPrivKey().PubKey()
+	msg := NewValidatorDepositMsg("user1", coinToString(valParam.ValidatorMinCommittingDeposit), valKey1, "")
+	assert.Equal(t, depositResult(msg), handler(ctx, msg))
+
+	result := handler(ctx, NewRotateValidatorKeyMsg("user1", valKey2))
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, result)
+	rotation, err := valManager.storage.GetKeyRotation(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, valKey2, rotation.NewPubKey)
+
+	result = handler(ctx, NewRotateValidatorKeyMsg("user1", valKey1))
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist().Result(), result)
+}
+
 func TestAddFrozenMoney(t *testing.T) {
 	ctx, am, valManager, _, gm := setupTest(t, 0)
 	valManager.InitGenesis(ctx)
@@ -559,3 +639,76 @@ func TestAddFrozenMoney(t *testing.T) {
 		}
 	}
 }
+
+func TestJailAndUnjail(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, 0)
+	ctx = ctx.WithBlockHeader(abci.Header{Height: types.BlockchainUpgrade1Update14Height, Time: time.Unix(0, 0)})
+	handler := NewHandler(am, valManager, voteManager, &gm)
+	valManager.InitGenesis(ctx)
+
+	valParam, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	minBalance := types.NewCoinFromInt64(10000 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	user2 := createTestAccount(ctx, am, "user2", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	voteManager.AddVoter(ctx, user1, valParam.ValidatorMinVotingDeposit)
+	voteManager.AddVoter(ctx, user2, valParam.ValidatorMinVotingDeposit)
+
+	deposit := coinToString(valParam.ValidatorMinCommittingDeposit)
+	msg1 := NewValidatorDepositMsg("user1", deposit, secp256k1.GenPrivKey().PubKey(), "")
+	msg2 := NewValidatorDepositMsg("user2", deposit, secp256k1.GenPrivKey().PubKey(), "")
+	assert.Equal(t, depositResult(msg1), handler(ctx, msg1))
+	assert.Equal(t, depositResult(msg2), handler(ctx, msg2))
+
+	// validator is not jailed yet
+	assert.Equal(t, ErrValidatorNotJailed().Result(), handler(ctx, NewValidatorUnjailMsg("user1")))
+
+	// user1 is jailed since its deposit is not enough after penalty
+	_, err := valManager.PunishOncallValidator(ctx, user1, valParam.PenaltyMissCommit, types.PunishAbsentCommit)
+	assert.Nil(t, err)
+	lst, _ := valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user2}, lst.OncallValidators)
+	assert.Equal(t, 2, len(lst.AllValidators))
+	validator, _ := valManager.storage.GetValidator(ctx, user1)
+	assert.True(t, validator.Jailed)
+	assert.Equal(t, valParam.ValidatorJailDurationSec, validator.JailedUntil)
+
+	// deposit during jail period won't make user1 oncall validator
+	msg3 := NewValidatorDepositMsg("user1", coinToString(valParam.PenaltyMissCommit), nil, "")
+	assert.Equal(t, depositResult(msg3), handler(ctx, msg3))
+	lst, _ = valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user2}, lst.OncallValidators)
+
+	// unjail before jail period ends
+	ctx = ctx.WithBlockHeader(abci.Header{Height: types.BlockchainUpgrade1Update14Height, Time: time.Unix(valParam.ValidatorJailDurationSec-1, 0)})
+	assert.Equal(t, ErrJailPeriodNotOver().Result(), handler(ctx, NewValidatorUnjailMsg("user1")))
+
+	// unjail after jail period ends
+	ctx = ctx.WithBlockHeader(abci.Header{Height: types.BlockchainUpgrade1Update14Height, Time: time.Unix(valParam.ValidatorJailDurationSec, 0)})
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, handler(ctx, NewValidatorUnjailMsg("user1")))
+	lst, _ = valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user2, user1}, lst.OncallValidators)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.False(t, validator.Jailed)
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit, validator.Deposit)
+
+	// byzantine validator is tombstoned and can't come back
+	_, err = valManager.PunishOncallValidator(ctx, user2, valParam.PenaltyByzantine, types.PunishByzantine)
+	assert.Nil(t, err)
+	lst, _ = valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user1}, lst.OncallValidators)
+	assert.Equal(t, []types.AccountKey{user1}, lst.AllValidators)
+	assert.Equal(t, ErrValidatorTombstoned().Result(), handler(ctx, NewValidatorUnjailMsg("user2")))
+	msg4 := NewValidatorDepositMsg("user2", coinToString(valParam.PenaltyMissCommit), nil, "")
+	assert.Equal(t, ErrValidatorTombstoned().Result(), handler(ctx, msg4))
+}
+
+// result of a successful deposit msg
+func depositResult(msg ValidatorDepositMsg) sdk.Result {
+	coin, _ := types.LinoToCoin(msg.Deposit)
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.ValidatorDeposit)}
+}
+
+// result of a successful withdraw or revoke msg
+func returnResult(username types.AccountKey, coin types.Coin) sdk.Result {
+	return sdk.Result{Tags: types.CoinTags(username, coin, types.ValidatorReturnCoin)}
+}
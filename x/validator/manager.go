@@ -1,12 +1,17 @@
 package validator
 
 import (
+	"bytes"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	acc "github.com/lino-network/lino/x/account"
+	"github.com/lino-network/lino/x/global"
 	"github.com/lino-network/lino/x/validator/model"
+	"github.com/lino-network/lino/x/vote"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -14,6 +19,16 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
+// ValidatorUptime - signing statistics of validator in the signing window
+type ValidatorUptime struct {
+	Username           types.AccountKey `json:"username"`
+	SignedBlocksWindow int64            `json:"signed_blocks_window"`
+	TrackedBlocks      int64            `json:"tracked_blocks"`
+	MissedBlocks       int64            `json:"missed_blocks"`
+	Uptime             sdk.Dec          `json:"uptime"`
+	MissedBlockHeights []int64          `json:"missed_block_heights"`
+}
+
 // ValidatorManager - validator manager
 type ValidatorManager struct {
 	storage     model.ValidatorStorage
@@ -107,7 +122,8 @@ func (vm ValidatorManager) GetInitValidators(ctx sdk.Context) ([]abci.ValidatorU
 
 // GetValidatorUpdates - after a block, compare updated validator set with
 // recorded validator set before block execution
-func (vm ValidatorManager) GetValidatorUpdates(ctx sdk.Context) ([]abci.ValidatorUpdate, sdk.Error) {
+func (vm ValidatorManager) GetValidatorUpdates(
+	ctx sdk.Context, voteManager vote.VoteManager) ([]abci.ValidatorUpdate, sdk.Error) {
 	validatorList, err := vm.storage.GetValidatorList(ctx)
 	if err != nil {
 		return nil, err
@@ -120,7 +136,8 @@ func (vm ValidatorManager) GetValidatorUpdates(ctx sdk.Context) ([]abci.Validato
 			if err != nil {
 				return nil, err
 			}
-			if validator.Deposit.IsZero() {
+			// jailed and tombstoned validators are kept to remember their punishment
+			if validator.Deposit.IsZero() && !validator.Jailed && !validator.Tombstoned {
 				vm.storage.DeleteValidator(ctx, validator.Username)
 			}
 			updates = append(updates, abci.ValidatorUpdate{
@@ -130,11 +147,44 @@ func (vm ValidatorManager) GetValidatorUpdates(ctx sdk.Context) ([]abci.Validato
 		}
 	}
 
-	for _, curValidator := range validatorList.OncallValidators {
+	rotated, rotationUpdates, err := vm.applyKeyRotations(ctx, validatorList)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, rotationUpdates...)
+
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update6Height {
+		for _, curValidator := range validatorList.OncallValidators {
+			validator, err := vm.storage.GetValidator(ctx, curValidator)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, abci.ValidatorUpdate{
+				PubKey: tmtypes.TM2PB.PubKey(validator.PubKey),
+				Power:  validator.ABCIValidator.Power,
+			})
+		}
+		return updates, nil
+	}
+
+	powers, err := vm.getStakePowers(ctx, voteManager, validatorList.OncallValidators)
+	if err != nil {
+		return nil, err
+	}
+	for i, curValidator := range validatorList.OncallValidators {
 		validator, err := vm.storage.GetValidator(ctx, curValidator)
 		if err != nil {
 			return nil, err
 		}
+		// only update validators whose power or key changed or just become oncall
+		if validator.ABCIValidator.Power == powers[i] && !rotated[curValidator] &&
+			types.FindAccountInList(curValidator, validatorList.PreBlockValidators) != -1 {
+			continue
+		}
+		validator.ABCIValidator.Power = powers[i]
+		if err := vm.storage.SetValidator(ctx, curValidator, validator); err != nil {
+			return nil, err
+		}
 		updates = append(updates, abci.ValidatorUpdate{
 			PubKey: tmtypes.TM2PB.PubKey(validator.PubKey),
 			Power:  validator.ABCIValidator.Power,
@@ -143,6 +193,207 @@ func (vm ValidatorManager) GetValidatorUpdates(ctx sdk.Context) ([]abci.Validato
 	return updates, nil
 }
 
+// apply all pending key rotations, old key of validator in tendermint is removed.
+// new key will be added with validator's power if it's oncall validator.
+func (vm ValidatorManager) applyKeyRotations(
+	ctx sdk.Context, validatorList *model.ValidatorList) (map[types.AccountKey]bool, []abci.ValidatorUpdate, sdk.Error) {
+	rotations, err := vm.storage.GetAllKeyRotations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rotated := make(map[types.AccountKey]bool)
+	updates := []abci.ValidatorUpdate{}
+	for _, row := range rotations {
+		vm.storage.DeleteKeyRotation(ctx, row.Username)
+		// validator has been deleted after revoke
+		if !vm.storage.DoesValidatorExist(ctx, row.Username) {
+			continue
+		}
+		validator, err := vm.storage.GetValidator(ctx, row.Username)
+		if err != nil {
+			return nil, nil, err
+		}
+		// old key of validator who left oncall list has been removed already
+		if types.FindAccountInList(row.Username, validatorList.PreBlockValidators) != -1 &&
+			types.FindAccountInList(row.Username, validatorList.OncallValidators) != -1 {
+			updates = append(updates, abci.ValidatorUpdate{
+				PubKey: tmtypes.TM2PB.PubKey(validator.PubKey),
+				Power:  0,
+			})
+		}
+		// old key is retired, its evidence and signatures are still attributed to validator
+		retired, err := vm.storage.GetRetiredKeys(ctx, row.Username)
+		if err != nil {
+			return nil, nil, err
+		}
+		retired.Addresses = append(retired.Addresses, validator.ABCIValidator.Address)
+		if err := vm.storage.SetRetiredKeys(ctx, row.Username, retired); err != nil {
+			return nil, nil, err
+		}
+		validator.PubKey = row.KeyRotation.NewPubKey
+		validator.ABCIValidator.Address = row.KeyRotation.NewPubKey.Address()
+		if err := vm.storage.SetValidator(ctx, row.Username, validator); err != nil {
+			return nil, nil, err
+		}
+		rotated[row.Username] = true
+	}
+	return rotated, updates, nil
+}
+
+// get power of validators in tendermint engine, which is their deposit plus voting power in LNO,
+// capped at MaxValidatorPowerPercent of total capped power to limit concentration
+func (vm ValidatorManager) getStakePowers(
+	ctx sdk.Context, voteManager vote.VoteManager, validators []types.AccountKey) ([]int64, sdk.Error) {
+	param, err := vm.paramHolder.GetValidatorParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	powers := make([]int64, len(validators))
+	for i, validatorName := range validators {
+		validator, err := vm.storage.GetValidator(ctx, validatorName)
+		if err != nil {
+			return nil, err
+		}
+		votingPower, err := voteManager.GetVotingPower(ctx, validatorName)
+		if err != nil {
+			return nil, err
+		}
+		stake := validator.Deposit.Plus(votingPower)
+		powers[i] = stake.Amount.Quo(sdk.NewInt(types.Decimals)).Int64()
+	}
+
+	powerCap := getPowerCap(powers, param.MaxValidatorPowerPercent)
+	for i := range powers {
+		if powers[i] > powerCap {
+			powers[i] = powerCap
+		}
+		// power 0 means removing validator from tendermint
+		if powers[i] <= 0 {
+			powers[i] = 1
+		}
+	}
+	return powers, nil
+}
+
+// get the largest power cap that no capped power exceeds percent of total capped power.
+// Capping the top k powers, cap = percent * (sum of the rest) / (100 - k * percent),
+// which is valid if the (k+1)th largest power doesn't exceed it. If there are too few
+// validators to meet the percent, all powers are capped at the smallest one.
+func getPowerCap(powers []int64, percent int64) int64 {
+	if len(powers) == 0 {
+		return 0
+	}
+	sorted := make([]int64, len(powers))
+	copy(sorted, powers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	rest := int64(0)
+	for _, power := range sorted {
+		rest += power
+	}
+	for k, power := range sorted {
+		if int64(k)*percent >= 100 {
+			break
+		}
+		powerCap := rest * percent / (100 - int64(k)*percent)
+		if power <= powerCap {
+			return powerCap
+		}
+		rest -= power
+	}
+	return sorted[len(sorted)-1]
+}
+
+// DistributeInflationToValidator - distribute hourly validator inflation to oncall validators.
+// Since BlockchainUpgrade1Update7Height, inflation is distributed in proportion to backing stake,
+// which is validator's deposit plus voting power. Share of delegators beyond the commission validator
+// sets as a voter is added to delegators' reward in vote module.
+func (vm ValidatorManager) DistributeInflationToValidator(
+	ctx sdk.Context, am acc.AccountManager, gm *global.GlobalManager, voteManager vote.VoteManager) sdk.Error {
+	lst, err := vm.storage.GetValidatorList(ctx)
+	if err != nil {
+		return err
+	}
+	coin, err := gm.GetValidatorHourlyInflation(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update7Height {
+		return vm.distributeInflationEvenly(ctx, am, lst.OncallValidators, coin)
+	}
+
+	stakes := make([]types.Coin, len(lst.OncallValidators))
+	totalStake := types.NewCoinFromInt64(0)
+	for i, validatorName := range lst.OncallValidators {
+		validator, err := vm.storage.GetValidator(ctx, validatorName)
+		if err != nil {
+			return err
+		}
+		votingPower, err := voteManager.GetVotingPower(ctx, validatorName)
+		if err != nil {
+			return err
+		}
+		stakes[i] = validator.Deposit.Plus(votingPower)
+		totalStake = totalStake.Plus(stakes[i])
+	}
+	if !totalStake.IsPositive() {
+		return vm.distributeInflationEvenly(ctx, am, lst.OncallValidators, coin)
+	}
+
+	remaining := coin
+	for i, validatorName := range lst.OncallValidators {
+		// last validator takes the remaining to avoid losing coins in truncation
+		share := remaining
+		if i != len(lst.OncallValidators)-1 {
+			share = truncateDecToCoin(coin.ToDec().Mul(stakes[i].ToDec()).Quo(totalStake.ToDec()))
+		}
+		remaining = remaining.Minus(share)
+
+		toDelegators := types.NewCoinFromInt64(0)
+		delegatedPower, err := voteManager.GetDelegatedPower(ctx, validatorName)
+		if err != nil {
+			return err
+		}
+		if delegatedPower.IsPositive() && stakes[i].IsPositive() {
+			rate, err := voteManager.GetCommissionRate(ctx, validatorName)
+			if err != nil {
+				return err
+			}
+			toDelegators = truncateDecToCoin(
+				share.ToDec().Mul(delegatedPower.ToDec()).Quo(stakes[i].ToDec()).Mul(sdk.OneDec().Sub(rate)))
+		}
+		if toDelegators.IsPositive() {
+			if err := voteManager.AddDelegatorReward(ctx, validatorName, toDelegators); err != nil {
+				return err
+			}
+		}
+		if err := am.AddSavingCoin(
+			ctx, validatorName, share.Minus(toDelegators), "", "", types.ValidatorInflation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// give inflation to each validator evenly
+func (vm ValidatorManager) distributeInflationEvenly(
+	ctx sdk.Context, am acc.AccountManager, validators []types.AccountKey, coin types.Coin) sdk.Error {
+	for i, validator := range validators {
+		ratPerValidator := coin.ToDec().Quo(sdk.NewDec(int64(len(validators) - i)))
+		coinPerValidator := types.DecToCoin(ratPerValidator)
+		if err := am.AddSavingCoin(
+			ctx, validator, coinPerValidator, "", "", types.ValidatorInflation); err != nil {
+			return err
+		}
+		coin = coin.Minus(coinPerValidator)
+	}
+	return nil
+}
+
+func truncateDecToCoin(dec sdk.Dec) types.Coin {
+	return types.NewCoinFromBigInt(dec.TruncateInt().BigInt())
+}
+
 // GetValidatorList - get validator list from KV Store
 func (vm ValidatorManager) GetValidatorList(ctx sdk.Context) (*model.ValidatorList, sdk.Error) {
 	return vm.storage.GetValidatorList(ctx)
@@ -169,6 +420,10 @@ func (vm ValidatorManager) UpdateSigningStats(
 	if err != nil {
 		return err
 	}
+	param, err := vm.paramHolder.GetValidatorParam(ctx)
+	if err != nil {
+		return err
+	}
 
 	// map address to whether that validator has signed.
 	addressSigned := make(map[string]bool)
@@ -176,20 +431,35 @@ func (vm ValidatorManager) UpdateSigningStats(
 		addressSigned[string(voteInfo.Validator.Address)] = voteInfo.SignedLastBlock
 	}
 
-	// go through oncall validator list to get all address and name mapping
+	// go through oncall validator list to get all address and name mapping,
+	// validator signs with its retired key until tendermint applies the rotation
 	for _, curValidator := range lst.OncallValidators {
 		validator, getErr := vm.storage.GetValidator(ctx, curValidator)
 		if getErr != nil {
 			return err
 		}
-		signed, exist := addressSigned[string(validator.ABCIValidator.Address)]
-		if !exist || !signed {
-			validator.AbsentCommit++
-		} else {
+		addresses, err := vm.getValidatorAddresses(ctx, validator)
+		if err != nil {
+			return err
+		}
+		signed := false
+		for _, address := range addresses {
+			signed = signed || addressSigned[string(address)]
+		}
+		if signed {
 			validator.ProducedBlocks++
-			if validator.AbsentCommit > 0 {
-				validator.AbsentCommit--
+		}
+		if isSigningWindowEnabled(param) {
+			// absent commit is the number of missed blocks in signing window
+			missed, err := vm.updateSigningInfo(ctx, curValidator, signed, param.SignedBlocksWindow)
+			if err != nil {
+				return err
 			}
+			validator.AbsentCommit = missed
+		} else if !signed {
+			validator.AbsentCommit++
+		} else if validator.AbsentCommit > 0 {
+			validator.AbsentCommit--
 		}
 		if err := vm.storage.SetValidator(ctx, curValidator, validator); err != nil {
 			return err
@@ -200,6 +470,8 @@ func (vm ValidatorManager) UpdateSigningStats(
 }
 
 // PunishOncallValidator - punish oncall validator if 1) byzantine or 2) missing blocks reach limiation
+// since BlockchainUpgrade1Update14Height, validator will be jailed if its remaining deposit is not enough,
+// and tombstoned if it is byzantine
 func (vm ValidatorManager) PunishOncallValidator(
 	ctx sdk.Context, username types.AccountKey, penalty types.Coin, punishType types.PunishType) (types.Coin, sdk.Error) {
 	actualPenalty := penalty
@@ -217,6 +489,7 @@ func (vm ValidatorManager) PunishOncallValidator(
 
 	if punishType == types.PunishAbsentCommit {
 		validator.AbsentCommit = 0
+		vm.storage.DeleteSigningInfo(ctx, username)
 	}
 
 	param, err := vm.paramHolder.GetValidatorParam(ctx)
@@ -224,15 +497,34 @@ func (vm ValidatorManager) PunishOncallValidator(
 		return actualPenalty, err
 	}
 
-	// remove this validator if its remaining deposit is not enough
-	// OR, we explicitly want to fire this validator
-	// all deposit will be added back to inflation pool
-	if punishType == types.PunishByzantine || !validator.Deposit.IsGTE(param.ValidatorMinCommittingDeposit) {
+	if ctx.BlockHeight() < types.BlockchainUpgrade1Update14Height {
+		// remove this validator if its remaining deposit is not enough
+		// OR, we explicitly want to fire this validator
+		// all deposit will be added back to inflation pool
+		if punishType == types.PunishByzantine || !validator.Deposit.IsGTE(param.ValidatorMinCommittingDeposit) {
+			if err := vm.RemoveValidatorFromAllLists(ctx, validator.Username); err != nil {
+				return actualPenalty, err
+			}
+			actualPenalty = actualPenalty.Plus(validator.Deposit)
+			validator.Deposit = types.NewCoinFromInt64(0)
+		}
+	} else if punishType == types.PunishByzantine {
+		// tombstone this validator if it double signs, it can never be oncall again
+		// all deposit will be added back to inflation pool
 		if err := vm.RemoveValidatorFromAllLists(ctx, validator.Username); err != nil {
 			return actualPenalty, err
 		}
 		actualPenalty = actualPenalty.Plus(validator.Deposit)
 		validator.Deposit = types.NewCoinFromInt64(0)
+		validator.Tombstoned = true
+	} else if !validator.Deposit.IsGTE(param.ValidatorMinCommittingDeposit) && !validator.Jailed {
+		// jail this validator if its remaining deposit is not enough,
+		// it keeps the remaining deposit and can unjail after jail period
+		if err := vm.removeValidatorFromOncallList(ctx, validator.Username); err != nil {
+			return actualPenalty, err
+		}
+		validator.Jailed = true
+		validator.JailedUntil = ctx.BlockHeader().Time.Unix() + param.ValidatorJailDurationSec
 	}
 
 	if err := vm.storage.SetValidator(ctx, username, validator); err != nil {
@@ -265,19 +557,29 @@ func (vm ValidatorManager) FireIncompetentValidator(
 			return totalPenalty, err
 		}
 
+		addresses, err := vm.getValidatorAddresses(ctx, validator)
+		if err != nil {
+			return totalPenalty, err
+		}
+		isByzantine := false
 		for _, evidence := range byzantineValidators {
-			if reflect.DeepEqual(validator.ABCIValidator.Address, evidence.Validator.Address) {
+			if containsAddress(addresses, evidence.Validator.Address) {
 				actualPenalty, err := vm.PunishOncallValidator(
 					ctx, validator.Username, param.PenaltyByzantine, types.PunishByzantine)
 				if err != nil {
 					return totalPenalty, err
 				}
 				totalPenalty = totalPenalty.Plus(actualPenalty)
+				isByzantine = true
 				break
 			}
 		}
+		// byzantine validator has been tombstoned
+		if isByzantine && ctx.BlockHeight() >= types.BlockchainUpgrade1Update14Height {
+			continue
+		}
 
-		if validator.AbsentCommit > param.AbsentCommitLimitation {
+		if isAbsentCommitOverLimit(param, validator.AbsentCommit) {
 			actualPenalty, err := vm.PunishOncallValidator(
 				ctx, validator.Username, param.PenaltyMissCommit, types.PunishAbsentCommit)
 			if err != nil {
@@ -324,21 +626,9 @@ func (vm ValidatorManager) RegisterValidator(
 	}
 
 	// make sure the pub key has not been registered
-	lst, err := vm.GetValidatorList(ctx)
-	if err != nil {
+	if err := vm.checkPubKeyNotUsed(ctx, pubKey); err != nil {
 		return err
 	}
-
-	for _, validatorName := range lst.AllValidators {
-		validator, err := vm.storage.GetValidator(ctx, validatorName)
-		if err != nil {
-			return err
-		}
-		// XXX(yumin): ABCIValidator no longer has pubkey, changed to address
-		if reflect.DeepEqual(validator.ABCIValidator.Address, pubKey.Address().Bytes()) {
-			return ErrValidatorPubKeyAlreadyExist()
-		}
-	}
 	// XXX(yumin): const power?
 	curValidator := &model.Validator{
 		ABCIValidator: abci.Validator{
@@ -357,6 +647,81 @@ func (vm ValidatorManager) RegisterValidator(
 	return nil
 }
 
+// RotateValidatorKey - schedule a consensus key change of validator, the new key
+// takes effect when validator set is updated at the end of block
+func (vm ValidatorManager) RotateValidatorKey(
+	ctx sdk.Context, username types.AccountKey, pubKey crypto.PubKey) sdk.Error {
+	validator, err := vm.storage.GetValidator(ctx, username)
+	if err != nil {
+		return err
+	}
+	if validator.Tombstoned {
+		return ErrValidatorTombstoned()
+	}
+	if reflect.DeepEqual(validator.ABCIValidator.Address, pubKey.Address().Bytes()) {
+		return ErrValidatorPubKeyAlreadyExist()
+	}
+	if err := vm.checkPubKeyNotUsed(ctx, pubKey); err != nil {
+		return err
+	}
+	return vm.storage.SetKeyRotation(ctx, username, &model.KeyRotation{NewPubKey: pubKey})
+}
+
+// make sure the pub key is not used by any validator, including jailed and tombstoned
+// validators, retired keys and pending key rotations
+func (vm ValidatorManager) checkPubKeyNotUsed(ctx sdk.Context, pubKey crypto.PubKey) sdk.Error {
+	validators, err := vm.storage.GetAllValidators(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range validators {
+		// XXX(yumin): ABCIValidator no longer has pubkey, changed to address
+		if reflect.DeepEqual(row.Validator.ABCIValidator.Address, pubKey.Address().Bytes()) {
+			return ErrValidatorPubKeyAlreadyExist()
+		}
+	}
+
+	retired, err := vm.storage.GetAllRetiredKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range retired {
+		if containsAddress(row.RetiredKeys.Addresses, pubKey.Address().Bytes()) {
+			return ErrValidatorPubKeyAlreadyExist()
+		}
+	}
+
+	rotations, err := vm.storage.GetAllKeyRotations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range rotations {
+		if reflect.DeepEqual(row.KeyRotation.NewPubKey.Address().Bytes(), pubKey.Address().Bytes()) {
+			return ErrValidatorPubKeyAlreadyExist()
+		}
+	}
+	return nil
+}
+
+// get current and retired addresses of validator
+func (vm ValidatorManager) getValidatorAddresses(
+	ctx sdk.Context, validator *model.Validator) ([][]byte, sdk.Error) {
+	retired, err := vm.storage.GetRetiredKeys(ctx, validator.Username)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]byte{validator.ABCIValidator.Address}, retired.Addresses...), nil
+}
+
+func containsAddress(addresses [][]byte, address []byte) bool {
+	for _, addr := range addresses {
+		if bytes.Equal(addr, address) {
+			return true
+		}
+	}
+	return false
+}
+
 // Deposit - deposit money to validator
 func (vm ValidatorManager) Deposit(
 	ctx sdk.Context, username types.AccountKey, coin types.Coin, link string) sdk.Error {
@@ -364,6 +729,9 @@ func (vm ValidatorManager) Deposit(
 	if err != nil {
 		return err
 	}
+	if validator.Tombstoned {
+		return ErrValidatorTombstoned()
+	}
 	validator.Deposit = validator.Deposit.Plus(coin)
 	if len(link) > 0 {
 		validator.Link = link
@@ -374,6 +742,23 @@ func (vm ValidatorManager) Deposit(
 	return nil
 }
 
+// UpdateValidator - update validator profile
+func (vm ValidatorManager) UpdateValidator(
+	ctx sdk.Context, username types.AccountKey, link, description, contact, identity string) sdk.Error {
+	validator, err := vm.storage.GetValidator(ctx, username)
+	if err != nil {
+		return err
+	}
+	if validator.Tombstoned {
+		return ErrValidatorTombstoned()
+	}
+	validator.Link = link
+	validator.Description = description
+	validator.Contact = contact
+	validator.Identity = identity
+	return vm.storage.SetValidator(ctx, username, validator)
+}
+
 // ValidatorWithdraw - this method won't check if it is a legal withdraw, caller should check by itself
 func (vm ValidatorManager) ValidatorWithdraw(ctx sdk.Context, username types.AccountKey, coin types.Coin) sdk.Error {
 	if coin.IsZero() {
@@ -415,6 +800,12 @@ func (vm ValidatorManager) TryBecomeOncallValidator(ctx sdk.Context, username ty
 	if err != nil {
 		return err
 	}
+	if curValidator.Tombstoned {
+		return ErrValidatorTombstoned()
+	}
+	if curValidator.Jailed {
+		return ErrValidatorJailed()
+	}
 	// check minimum requirements
 	if !curValidator.Deposit.IsGTE(param.ValidatorMinCommittingDeposit) {
 		return ErrInsufficientDeposit()
@@ -438,7 +829,10 @@ func (vm ValidatorManager) TryBecomeOncallValidator(ctx sdk.Context, username ty
 	// add to list directly if validator list is not full
 	if int64(len(lst.OncallValidators)) < param.ValidatorListSize {
 		lst.OncallValidators = append(lst.OncallValidators, curValidator.Username)
+		// signing window starts over when validator becomes oncall
+		vm.storage.DeleteSigningInfo(ctx, username)
 	} else if curValidator.Deposit.IsGT(lst.LowestPower) {
+		vm.storage.DeleteSigningInfo(ctx, username)
 		// replace the validator with lowest power
 		for idx, validatorKey := range lst.OncallValidators {
 			validator, err := vm.storage.GetValidator(ctx, validatorKey)
@@ -461,6 +855,84 @@ func (vm ValidatorManager) TryBecomeOncallValidator(ctx sdk.Context, username ty
 	return nil
 }
 
+// GetValidatorUptime - get signing window statistics and missed blocks of validator
+func (vm ValidatorManager) GetValidatorUptime(ctx sdk.Context, username types.AccountKey) (*ValidatorUptime, sdk.Error) {
+	if !vm.storage.DoesValidatorExist(ctx, username) {
+		return nil, model.ErrValidatorNotFound()
+	}
+	param, err := vm.paramHolder.GetValidatorParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uptime := &ValidatorUptime{
+		Username:           username,
+		SignedBlocksWindow: param.SignedBlocksWindow,
+		Uptime:             sdk.OneDec(),
+		MissedBlockHeights: []int64{},
+	}
+	if !isSigningWindowEnabled(param) || !vm.storage.DoesSigningInfoExist(ctx, username) {
+		return uptime, nil
+	}
+	info, err := vm.storage.GetSigningInfo(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	window := param.SignedBlocksWindow
+	// window size changed, a new window will start from next block
+	if info.Window != window {
+		return uptime, nil
+	}
+	tracked := info.IndexOffset
+	if tracked > window {
+		tracked = window
+	}
+	uptime.TrackedBlocks = tracked
+	uptime.MissedBlocks = info.MissedBlocksCounter
+	if tracked > 0 {
+		uptime.Uptime = sdk.NewDec(tracked - info.MissedBlocksCounter).QuoInt(sdk.NewInt(tracked))
+	}
+	for offset := info.IndexOffset - tracked; offset < info.IndexOffset; offset++ {
+		if getBit(info.MissedBlocks, offset%window) {
+			uptime.MissedBlockHeights = append(uptime.MissedBlockHeights, info.StartHeight+offset)
+		}
+	}
+	return uptime, nil
+}
+
+// IsJailed - check if validator is jailed
+func (vm ValidatorManager) IsJailed(ctx sdk.Context, username types.AccountKey) bool {
+	validator, err := vm.storage.GetValidator(ctx, username)
+	if err != nil {
+		return false
+	}
+	return validator.Jailed
+}
+
+// UnjailValidator - release a jailed validator after jail period, and try to
+// put it back to the oncall validator list
+func (vm ValidatorManager) UnjailValidator(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	validator, err := vm.storage.GetValidator(ctx, username)
+	if err != nil {
+		return err
+	}
+	if validator.Tombstoned {
+		return ErrValidatorTombstoned()
+	}
+	if !validator.Jailed {
+		return ErrValidatorNotJailed()
+	}
+	if ctx.BlockHeader().Time.Unix() < validator.JailedUntil {
+		return ErrJailPeriodNotOver()
+	}
+
+	validator.Jailed = false
+	validator.JailedUntil = 0
+	if err := vm.storage.SetValidator(ctx, username, validator); err != nil {
+		return err
+	}
+	return vm.TryBecomeOncallValidator(ctx, username)
+}
+
 // RemoveValidatorFromAllLists - remove the user from both oncall and allValidators lists
 func (vm ValidatorManager) RemoveValidatorFromAllLists(ctx sdk.Context, username types.AccountKey) sdk.Error {
 	lst, err := vm.storage.GetValidatorList(ctx)
@@ -484,6 +956,80 @@ func (vm ValidatorManager) RemoveValidatorFromAllLists(ctx sdk.Context, username
 	return nil
 }
 
+// record if validator signed the last block in its signing window, return number of missed blocks in window
+func (vm ValidatorManager) updateSigningInfo(
+	ctx sdk.Context, username types.AccountKey, signed bool, window int64) (int64, sdk.Error) {
+	info := &model.SigningInfo{}
+	if vm.storage.DoesSigningInfoExist(ctx, username) {
+		var err sdk.Error
+		info, err = vm.storage.GetSigningInfo(ctx, username)
+		if err != nil {
+			return 0, err
+		}
+	}
+	// start a new window if it's the first record or window size changed
+	if info.Window != window {
+		// signing info of last block is recorded in this block
+		info = &model.SigningInfo{
+			Window:       window,
+			StartHeight:  ctx.BlockHeight() - 1,
+			MissedBlocks: make([]byte, (window+7)/8),
+		}
+	}
+
+	idx := info.IndexOffset % window
+	info.IndexOffset++
+	missedBefore := getBit(info.MissedBlocks, idx)
+	if !signed && !missedBefore {
+		setBit(info.MissedBlocks, idx, true)
+		info.MissedBlocksCounter++
+	} else if signed && missedBefore {
+		setBit(info.MissedBlocks, idx, false)
+		info.MissedBlocksCounter--
+	}
+
+	if err := vm.storage.SetSigningInfo(ctx, username, info); err != nil {
+		return 0, err
+	}
+	return info.MissedBlocksCounter, nil
+}
+
+// signing window is enabled once SignedBlocksWindow is set, before that
+// absent commits are counted against AbsentCommitLimitation
+func isSigningWindowEnabled(valParam *param.ValidatorParam) bool {
+	return valParam.SignedBlocksWindow > 0 && !valParam.MinSignedPerWindow.IsNil()
+}
+
+func isAbsentCommitOverLimit(valParam *param.ValidatorParam, absentCommit int64) bool {
+	if !isSigningWindowEnabled(valParam) {
+		return absentCommit > valParam.AbsentCommitLimitation
+	}
+	minSigned := valParam.MinSignedPerWindow.MulInt(sdk.NewInt(valParam.SignedBlocksWindow)).TruncateInt64()
+	return absentCommit > valParam.SignedBlocksWindow-minSigned
+}
+
+func getBit(bits []byte, idx int64) bool {
+	return bits[idx/8]&(1<<uint(idx%8)) != 0
+}
+
+func setBit(bits []byte, idx int64, value bool) {
+	if value {
+		bits[idx/8] |= 1 << uint(idx%8)
+	} else {
+		bits[idx/8] &^= 1 << uint(idx%8)
+	}
+}
+
+// remove the user from oncall list only, the user is still a candidate in allValidators list
+func (vm ValidatorManager) removeValidatorFromOncallList(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	lst, err := vm.storage.GetValidatorList(ctx)
+	if err != nil {
+		return err
+	}
+	lst.OncallValidators = remove(username, lst.OncallValidators)
+	return vm.storage.SetValidatorList(ctx, lst)
+}
+
 // if any change happens in oncall validator(remove, punish),
 // we should call this function to adjust validator list
 func (vm ValidatorManager) AdjustValidatorList(ctx sdk.Context) sdk.Error {
@@ -565,8 +1111,9 @@ func (vm ValidatorManager) getBestCandidate(ctx sdk.Context) (types.AccountKey,
 		if err != nil {
 			return bestCandidate, err
 		}
-		// not in the oncall list and has a larger power
-		if types.FindAccountInList(validatorName, lst.OncallValidators) == -1 &&
+		// not jailed, not in the oncall list and has a larger power
+		if !validator.Jailed && !validator.Tombstoned &&
+			types.FindAccountInList(validatorName, lst.OncallValidators) == -1 &&
 			validator.Deposit.IsGT(bestCandidatePower) {
 			bestCandidate = validator.Username
 			bestCandidatePower = validator.Deposit
@@ -576,6 +1123,22 @@ func (vm ValidatorManager) getBestCandidate(ctx sdk.Context) (types.AccountKey,
 
 }
 
+// GetTotalDeposit - get sum of deposit of all validators
+func (vm ValidatorManager) GetTotalDeposit(ctx sdk.Context) (types.Coin, sdk.Error) {
+	total := types.NewCoinFromInt64(0)
+	if err := vm.storage.IterateValidators(ctx, func(validator *model.Validator) {
+		total = total.Plus(validator.Deposit)
+	}); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by validators
+func (vm ValidatorManager) RegisterInvariants(ir *types.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "deposit", vm.GetTotalDeposit)
+}
+
 // Export storage state.
 func (vm ValidatorManager) Export(ctx sdk.Context) *model.ValidatorTables {
 	return vm.storage.Export(ctx)
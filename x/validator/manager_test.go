@@ -14,6 +14,7 @@ import (
 
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/validator/model"
+	"github.com/lino-network/lino/x/vote"
 )
 
 func TestByzantines(t *testing.T) {
@@ -38,7 +39,7 @@ func TestByzantines(t *testing.T) {
 		name := "user" + strconv.Itoa(i)
 		msg := NewValidatorDepositMsg(name, deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	// byzantine
@@ -49,6 +50,7 @@ func TestByzantines(t *testing.T) {
 			Address: valKeys[idx].Address(),
 			Power:   1000}})
 	}
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update14Height)
 	_, err := valManager.FireIncompetentValidator(ctx, byzantines)
 	assert.Nil(t, err)
 
@@ -59,6 +61,10 @@ func TestByzantines(t *testing.T) {
 	for _, idx := range byzantineList {
 		assert.Equal(t, -1, types.FindAccountInList(users[idx], validatorList3.OncallValidators))
 		assert.Equal(t, -1, types.FindAccountInList(users[idx], validatorList3.AllValidators))
+
+		validator, _ := valManager.storage.GetValidator(ctx, users[idx])
+		assert.True(t, validator.Tombstoned)
+		assert.True(t, validator.Deposit.IsZero())
 	}
 
 }
@@ -85,7 +91,7 @@ func TestAbsentValidatorWillBeFired(t *testing.T) {
 		name := "user" + strconv.Itoa(i)
 		msg := NewValidatorDepositMsg(name, deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	// construct signing list
@@ -146,16 +152,27 @@ func TestAbsentValidatorWillBeFired(t *testing.T) {
 		}
 	}
 
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update14Height)
 	_, err = valManager.FireIncompetentValidator(ctx, []abci.Evidence{})
 	assert.Nil(t, err)
 	validatorList2, _ := valManager.storage.GetValidatorList(ctx)
 
 	assert.Equal(t, 18, len(validatorList2.OncallValidators))
-	assert.Equal(t, 18, len(validatorList2.AllValidators))
+	assert.Equal(t, 21, len(validatorList2.AllValidators))
 
+	// absent validators are jailed, not removed
 	for _, idx := range absentList {
 		assert.Equal(t, -1, types.FindAccountInList(types.AccountKey("user"+strconv.Itoa(idx)), validatorList2.OncallValidators))
-		assert.Equal(t, -1, types.FindAccountInList(types.AccountKey("user"+strconv.Itoa(idx)), validatorList2.AllValidators))
+		assert.NotEqual(t, -1, types.FindAccountInList(types.AccountKey("user"+strconv.Itoa(idx)), validatorList2.AllValidators))
+
+		validator, _ := valManager.storage.GetValidator(ctx, types.AccountKey("user"+strconv.Itoa(idx)))
+		assert.True(t, validator.Jailed)
+		assert.False(t, validator.Tombstoned)
+		assert.Equal(t, ctx.BlockHeader().Time.Unix()+param.ValidatorJailDurationSec, validator.JailedUntil)
+
+		validatorMinDeposit, _ := valParam.ValidatorMinCommittingDeposit.ToInt64()
+		num := int64((idx+1)*10) + validatorMinDeposit/types.Decimals - 200
+		assert.Equal(t, types.NewCoinFromInt64(num*types.Decimals), validator.Deposit)
 	}
 }
 
@@ -181,7 +198,7 @@ func TestAbsentValidatorWontBeFired(t *testing.T) {
 		name := "user" + strconv.Itoa(i)
 		msg := NewValidatorDepositMsg(name, deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	// construct signing list
@@ -263,6 +280,91 @@ func TestAbsentValidatorWontBeFired(t *testing.T) {
 	}
 }
 
+func TestSigningWindow(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, 1)
+	handler := NewHandler(am, valManager, voteManager, &gm)
+	valManager.InitGenesis(ctx)
+
+	valParam, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	voteManager.AddVoter(ctx, user1, valParam.ValidatorMinVotingDeposit)
+	valKey := secp256k1.GenPrivKey().PubKey()
+	msg := NewValidatorDepositMsg("user1", coinToString(valParam.ValidatorMinCommittingDeposit), valKey, "")
+	assert.Equal(t, depositResult(msg), handler(ctx, msg))
+
+	window := valParam.SignedBlocksWindow
+	voteInfos := func(signed bool) []abci.VoteInfo {
+		return []abci.VoteInfo{{
+			Validator:       abci.Validator{Address: valKey.Address(), Power: 1000},
+			SignedLastBlock: signed,
+		}}
+	}
+
+	// no block has been tracked yet
+	uptime, err := valManager.GetValidatorUptime(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, &ValidatorUptime{
+		Username:           user1,
+		SignedBlocksWindow: window,
+		Uptime:             sdk.OneDec(),
+		MissedBlockHeights: []int64{},
+	}, uptime)
+
+	// alternating sign and miss, half of the window is missed
+	for i := int64(0); i < window; i++ {
+		err := valManager.UpdateSigningStats(ctx, voteInfos(i%2 == 0))
+		assert.Nil(t, err)
+	}
+	validator, _ := valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, window/2, validator.AbsentCommit)
+	assert.Equal(t, window/2, validator.ProducedBlocks)
+	uptime, err = valManager.GetValidatorUptime(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, window, uptime.TrackedBlocks)
+	assert.Equal(t, window/2, uptime.MissedBlocks)
+	assert.True(t, types.NewDecFromRat(1, 2).Equal(uptime.Uptime))
+	assert.Equal(t, int(window/2), len(uptime.MissedBlockHeights))
+	assert.Equal(t, []int64{1, 3}, uptime.MissedBlockHeights[:2])
+
+	// missed blocks slide out of the window
+	for i := int64(0); i < window; i++ {
+		err := valManager.UpdateSigningStats(ctx, voteInfos(true))
+		assert.Nil(t, err)
+	}
+	uptime, err = valManager.GetValidatorUptime(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, window, uptime.TrackedBlocks)
+	assert.Equal(t, int64(0), uptime.MissedBlocks)
+	assert.True(t, sdk.OneDec().Equal(uptime.Uptime))
+	assert.Equal(t, []int64{}, uptime.MissedBlockHeights)
+
+	// missed blocks don't exceed the limitation
+	minSigned := valParam.MinSignedPerWindow.MulInt(sdk.NewInt(window)).TruncateInt64()
+	for i := int64(0); i < window; i++ {
+		err := valManager.UpdateSigningStats(ctx, voteInfos(i >= window-minSigned))
+		assert.Nil(t, err)
+	}
+	_, err = valManager.FireIncompetentValidator(ctx, []abci.Evidence{})
+	assert.Nil(t, err)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.False(t, validator.Jailed)
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit, validator.Deposit)
+
+	// missing more blocks than allowed in window will be punished
+	for i := int64(0); i < window; i++ {
+		err := valManager.UpdateSigningStats(ctx, voteInfos(false))
+		assert.Nil(t, err)
+	}
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update14Height)
+	_, err = valManager.FireIncompetentValidator(ctx, []abci.Evidence{})
+	assert.Nil(t, err)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.True(t, validator.Jailed)
+	assert.Equal(t, int64(0), validator.AbsentCommit)
+	assert.False(t, valManager.storage.DoesSigningInfoExist(ctx, user1))
+}
+
 func TestGetOncallList(t *testing.T) {
 	ctx, am, valManager, voteManager, gm := setupTest(t, 0)
 	handler := NewHandler(am, valManager, voteManager, &gm)
@@ -285,7 +387,7 @@ func TestGetOncallList(t *testing.T) {
 		name := "user" + strconv.Itoa(i)
 		msg := NewValidatorDepositMsg(name, deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	lst, _ := valManager.GetValidatorList(ctx)
@@ -318,7 +420,8 @@ func TestPunishmentBasic(t *testing.T) {
 	handler(ctx, msg1)
 	handler(ctx, msg2)
 
-	// punish user2 as byzantine (explicitly remove)
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update14Height)
+	// punish user2 as byzantine (explicitly remove and tombstone)
 	valManager.PunishOncallValidator(ctx, types.AccountKey("user2"), valParam.PenaltyByzantine, types.PunishByzantine)
 	lst, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, 1, len(lst.OncallValidators))
@@ -327,15 +430,62 @@ func TestPunishmentBasic(t *testing.T) {
 
 	validator, _ := valManager.storage.GetValidator(ctx, "user2")
 	assert.Equal(t, true, validator.Deposit.IsZero())
+	assert.Equal(t, true, validator.Tombstoned)
 
-	// punish user1 as missing vote (wont explicitly remove)
-	valManager.PunishOncallValidator(ctx, types.AccountKey("user1"), valParam.PenaltyMissVote, types.PunishDidntVote)
+	// punish user1 as missing vote (jailed since deposit is not enough)
+	actualPenalty, err := valManager.PunishOncallValidator(ctx, types.AccountKey("user1"), valParam.PenaltyMissVote, types.PunishDidntVote)
+	assert.Nil(t, err)
+	assert.Equal(t, valParam.PenaltyMissVote, actualPenalty)
 	lst2, _ := valManager.storage.GetValidatorList(ctx)
 	assert.Equal(t, 0, len(lst2.OncallValidators))
-	assert.Equal(t, 0, len(lst2.AllValidators))
+	assert.Equal(t, 1, len(lst2.AllValidators))
 
 	validator2, _ := valManager.storage.GetValidator(ctx, "user1")
-	assert.Equal(t, true, validator2.Deposit.IsZero())
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit.Minus(valParam.PenaltyMissVote), validator2.Deposit)
+	assert.Equal(t, true, validator2.Jailed)
+	assert.Equal(t, false, validator2.Tombstoned)
+}
+
+func TestPunishmentBeforeUpgrade(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, types.BlockchainUpgrade1Update14Height-1)
+	handler := NewHandler(am, valManager, voteManager, &gm)
+	valManager.InitGenesis(ctx)
+
+	valParam, _ := valManager.paramHolder.GetValidatorParam(ctx)
+
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	createTestAccount(ctx, am, "user1", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+	createTestAccount(ctx, am, "user2", minBalance.Plus(valParam.ValidatorMinCommittingDeposit))
+
+	voteManager.AddVoter(ctx, "user1", valParam.ValidatorMinVotingDeposit)
+	voteManager.AddVoter(ctx, "user2", valParam.ValidatorMinVotingDeposit)
+
+	msg1 := NewValidatorDepositMsg("user1", coinToString(valParam.ValidatorMinCommittingDeposit), secp256k1.GenPrivKey().PubKey(), "")
+	msg2 := NewValidatorDepositMsg("user2", coinToString(valParam.ValidatorMinCommittingDeposit), secp256k1.GenPrivKey().PubKey(), "")
+	handler(ctx, msg1)
+	handler(ctx, msg2)
+
+	// byzantine validator is removed without tombstone
+	actualPenalty, err := valManager.PunishOncallValidator(ctx, types.AccountKey("user2"), valParam.PenaltyByzantine, types.PunishByzantine)
+	assert.Nil(t, err)
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit, actualPenalty)
+	lst, _ := valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{"user1"}, lst.OncallValidators)
+	assert.Equal(t, []types.AccountKey{"user1"}, lst.AllValidators)
+	validator, _ := valManager.storage.GetValidator(ctx, "user2")
+	assert.True(t, validator.Deposit.IsZero())
+	assert.False(t, validator.Tombstoned)
+
+	// validator with insufficient deposit is removed and all its deposit is taken
+	actualPenalty, err = valManager.PunishOncallValidator(ctx, types.AccountKey("user1"), valParam.PenaltyMissVote, types.PunishDidntVote)
+	assert.Nil(t, err)
+	assert.Equal(t, valParam.ValidatorMinCommittingDeposit, actualPenalty)
+	lst, _ = valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, 0, len(lst.OncallValidators))
+	assert.Equal(t, 0, len(lst.AllValidators))
+	validator, _ = valManager.storage.GetValidator(ctx, "user1")
+	assert.True(t, validator.Deposit.IsZero())
+	assert.False(t, validator.Jailed)
 }
 
 func TestPunishmentAndSubstitutionExists(t *testing.T) {
@@ -360,7 +510,7 @@ func TestPunishmentAndSubstitutionExists(t *testing.T) {
 		valKeys[i] = secp256k1.GenPrivKey().PubKey()
 		msg := NewValidatorDepositMsg("user"+strconv.Itoa(i+1), deposit, valKeys[i], "")
 		result := handler(ctx, msg)
-		assert.Equal(t, sdk.Result{}, result)
+		assert.Equal(t, depositResult(msg), result)
 	}
 
 	// lowest is user4 with power (min + 400)
@@ -454,7 +604,7 @@ func TestInitValidators(t *testing.T) {
 }
 
 func TestGetValidatorUpdates(t *testing.T) {
-	ctx, am, valManager, _, _ := setupTest(t, 0)
+	ctx, am, valManager, voteManager, _ := setupTest(t, 0)
 	valManager.InitGenesis(ctx)
 
 	minBalance := types.NewCoinFromInt64(100 * types.Decimals)
@@ -538,7 +688,7 @@ func TestGetValidatorUpdates(t *testing.T) {
 			t.Errorf("%s: failed to set validator list, got err %v", tc.testName, err)
 		}
 
-		actualList, err := valManager.GetValidatorUpdates(ctx)
+		actualList, err := valManager.GetValidatorUpdates(ctx, voteManager)
 		if err != nil {
 			t.Errorf("%s: failed to get validator list, got err %v", tc.testName, err)
 		}
@@ -548,6 +698,272 @@ func TestGetValidatorUpdates(t *testing.T) {
 	}
 }
 
+func TestGetValidatorUpdatesWithStakePower(t *testing.T) {
+	ctx, am, valManager, voteManager, _ := setupTest(t, types.BlockchainUpgrade1Update6Height)
+	valManager.InitGenesis(ctx)
+
+	param, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	// 11 validators with power 400000 and one with power 1300000
+	users := []types.AccountKey{}
+	valKeys := []crypto.PubKey{}
+	for i := 0; i < 12; i++ {
+		user := createTestAccount(ctx, am, "user"+strconv.Itoa(i), types.NewCoinFromInt64(0))
+		valKey := secp256k1.GenPrivKey().PubKey()
+		deposit := param.ValidatorMinCommittingDeposit
+		if i == 11 {
+			deposit = types.NewCoinFromInt64(1000000 * types.Decimals)
+		}
+		voteManager.AddVoter(ctx, user, param.ValidatorMinVotingDeposit)
+		valManager.RegisterValidator(ctx, user, valKey, deposit, "")
+		users = append(users, user)
+		valKeys = append(valKeys, valKey)
+	}
+	lst := &model.ValidatorList{
+		OncallValidators: users,
+	}
+	err := valManager.storage.SetValidatorList(ctx, lst)
+	assert.Nil(t, err)
+
+	// validator with large stake is capped at 10% of total capped power
+	expectedUpdates := []abci.ValidatorUpdate{}
+	for i := 0; i < 11; i++ {
+		expectedUpdates = append(expectedUpdates, abci.ValidatorUpdate{
+			PubKey: tmtypes.TM2PB.PubKey(valKeys[i]),
+			Power:  400000,
+		})
+	}
+	expectedUpdates = append(expectedUpdates, abci.ValidatorUpdate{
+		PubKey: tmtypes.TM2PB.PubKey(valKeys[11]),
+		Power:  488888,
+	})
+	updates, err := valManager.GetValidatorUpdates(ctx, voteManager)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedUpdates, updates)
+	validator, _ := valManager.storage.GetValidator(ctx, users[11])
+	assert.Equal(t, int64(488888), validator.ABCIValidator.Power)
+
+	// no update if power doesn't change
+	lst.PreBlockValidators = users
+	err = valManager.storage.SetValidatorList(ctx, lst)
+	assert.Nil(t, err)
+	updates, err = valManager.GetValidatorUpdates(ctx, voteManager)
+	assert.Nil(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{}, updates)
+
+	// stake change of user0 changes the cap as well
+	err = voteManager.AddLinoStake(ctx, users[0], types.NewCoinFromInt64(100000*types.Decimals))
+	assert.Nil(t, err)
+	updates, err = valManager.GetValidatorUpdates(ctx, voteManager)
+	assert.Nil(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{
+		{PubKey: tmtypes.TM2PB.PubKey(valKeys[0]), Power: 500000},
+		{PubKey: tmtypes.TM2PB.PubKey(valKeys[11]), Power: 500000},
+	}, updates)
+}
+
+func TestGetPowerCap(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		powers      []int64
+		percent     int64
+		expectedCap int64
+	}{
+		{
+			testName:    "no validator",
+			powers:      []int64{},
+			percent:     10,
+			expectedCap: 0,
+		},
+		{
+			testName:    "no power exceeds percent of total",
+			powers:      []int64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10},
+			percent:     10,
+			expectedCap: 10,
+		},
+		{
+			testName:    "cap one power",
+			powers:      []int64{400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 1300},
+			percent:     10,
+			expectedCap: 488,
+		},
+		{
+			testName:    "cap more powers than the largest",
+			powers:      []int64{100, 100, 100, 100, 100, 100, 100, 100, 100, 500, 600},
+			percent:     10,
+			expectedCap: 112,
+		},
+		{
+			testName:    "too few validators to meet the percent",
+			powers:      []int64{300, 100, 200},
+			percent:     10,
+			expectedCap: 100,
+		},
+		{
+			testName:    "no cap at 100 percent",
+			powers:      []int64{300, 100, 200},
+			percent:     100,
+			expectedCap: 600,
+		},
+	}
+	for _, tc := range testCases {
+		powerCap := getPowerCap(tc.powers, tc.percent)
+		if powerCap != tc.expectedCap {
+			t.Errorf("%s: diff cap, got %v, want %v", tc.testName, powerCap, tc.expectedCap)
+		}
+		total := int64(0)
+		for _, power := range tc.powers {
+			if power > powerCap {
+				power = powerCap
+			}
+			total += power
+		}
+		if len(tc.powers)*int(tc.percent) >= 100 && powerCap*100 > total*tc.percent {
+			t.Errorf("%s: cap %v exceeds %v percent of total %v", tc.testName, powerCap, tc.percent, total)
+		}
+	}
+}
+
+func TestDistributeInflationToValidatorByStake(t *testing.T) {
+	ctx, am, valManager, voteManager, gm := setupTest(t, types.BlockchainUpgrade1Update7Height)
+	valManager.InitGenesis(ctx)
+
+	// backing stake of user0, user1 and user2 is 2, 4 and 4 times of min deposit,
+	// half of user1's stake is delegated by delegator
+	param, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	minDeposit := param.ValidatorMinCommittingDeposit
+	users := []types.AccountKey{}
+	deposits := []types.Coin{minDeposit, minDeposit, minDeposit.Plus(minDeposit)}
+	for i := 0; i < 3; i++ {
+		user := createTestAccount(ctx, am, "user"+strconv.Itoa(i), types.NewCoinFromInt64(0))
+		assert.Nil(t, voteManager.AddVoter(ctx, user, deposits[i]))
+		assert.Nil(t, valManager.RegisterValidator(
+			ctx, user, secp256k1.GenPrivKey().PubKey(), deposits[i], ""))
+		users = append(users, user)
+	}
+	delegator := types.AccountKey("delegator")
+	assert.Nil(t, voteManager.AddVoter(ctx, delegator, types.NewCoinFromInt64(0)))
+	assert.Nil(t, voteManager.AddDelegation(ctx, users[1], delegator, minDeposit.Plus(minDeposit)))
+	err := valManager.storage.SetValidatorList(ctx, &model.ValidatorList{
+		OncallValidators: users,
+	})
+	assert.Nil(t, err)
+
+	inflation := types.NewCoinFromInt64(1000)
+	_, err = gm.GetValidatorHourlyInflation(ctx)
+	assert.Nil(t, err)
+
+	// validator keeps all inflation by default
+	assert.Nil(t, gm.AddToValidatorInflationPool(ctx, inflation))
+	err = valManager.DistributeInflationToValidator(ctx, am, &gm, voteManager)
+	assert.Nil(t, err)
+	expectBalances := []types.Coin{
+		types.NewCoinFromInt64(200), types.NewCoinFromInt64(400), types.NewCoinFromInt64(400)}
+	for i, user := range users {
+		saving, err := am.GetSavingFromBank(ctx, user)
+		assert.Nil(t, err)
+		assert.Equal(t, expectBalances[i], saving)
+	}
+
+	// 90% of delegator's share goes to delegator with commission set in vote module
+	assert.Nil(t, voteManager.SetCommissionRate(ctx, users[1], sdk.NewDecWithPrec(1, 1)))
+	assert.Nil(t, gm.AddToValidatorInflationPool(ctx, inflation))
+	err = valManager.DistributeInflationToValidator(ctx, am, &gm, voteManager)
+	assert.Nil(t, err)
+	expectBalances = []types.Coin{
+		types.NewCoinFromInt64(400), types.NewCoinFromInt64(620), types.NewCoinFromInt64(800)}
+	for i, user := range users {
+		saving, err := am.GetSavingFromBank(ctx, user)
+		assert.Nil(t, err)
+		assert.Equal(t, expectBalances[i], saving)
+	}
+	rewards, err := voteManager.GetPendingDelegatorRewards(ctx, delegator)
+	assert.Nil(t, err)
+	assert.Equal(t, []vote.PendingReward{{Voter: users[1], Amount: types.NewCoinFromInt64(180)}}, rewards)
+}
+
+func TestRotateValidatorKey(t *testing.T) {
+	ctx, am, valManager, voteManager, _ := setupTest(t, 0)
+	valManager.InitGenesis(ctx)
+
+	user1 := createTestAccount(ctx, am, "user1", types.NewCoinFromInt64(0))
+	user2 := createTestAccount(ctx, am, "user2", types.NewCoinFromInt64(0))
+	valKey1 := secp256k1.GenPrivKey().PubKey()
+	valKey2 := secp256k1.GenPrivKey().PubKey()
+	valKey3 := secp256k1.GenPrivKey().PubKey()
+
+	param, _ := valManager.paramHolder.GetValidatorParam(ctx)
+	valManager.RegisterValidator(ctx, user1, valKey1, param.ValidatorMinCommittingDeposit, "")
+	valManager.RegisterValidator(ctx, user2, valKey2, param.ValidatorMinCommittingDeposit, "")
+	valManager.TryBecomeOncallValidator(ctx, user1)
+	valManager.TryBecomeOncallValidator(ctx, user2)
+	lst, _ := valManager.storage.GetValidatorList(ctx)
+	lst.PreBlockValidators = lst.OncallValidators
+	valManager.storage.SetValidatorList(ctx, lst)
+
+	// keys used by other validator or by itself can't be used
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist(), valManager.RotateValidatorKey(ctx, user1, valKey2))
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist(), valManager.RotateValidatorKey(ctx, user1, valKey1))
+	assert.Nil(t, valManager.RotateValidatorKey(ctx, user1, valKey3))
+	// key in pending rotation can't be used
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist(), valManager.RotateValidatorKey(ctx, user2, valKey3))
+
+	// key doesn't change until validator set is updated
+	validator, _ := valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, valKey1, validator.PubKey)
+
+	updates, err := valManager.GetValidatorUpdates(ctx, voteManager)
+	assert.Nil(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{
+		{PubKey: tmtypes.TM2PB.PubKey(valKey1), Power: 0},
+		{PubKey: tmtypes.TM2PB.PubKey(valKey3), Power: types.TendermintValidatorPower},
+		{PubKey: tmtypes.TM2PB.PubKey(valKey2), Power: types.TendermintValidatorPower},
+	}, updates)
+
+	// deposit and oncall position are kept
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, valKey3, validator.PubKey)
+	assert.Equal(t, valKey3.Address().Bytes(), []byte(validator.ABCIValidator.Address))
+	assert.Equal(t, param.ValidatorMinCommittingDeposit, validator.Deposit)
+	lst, _ = valManager.storage.GetValidatorList(ctx)
+	assert.Equal(t, []types.AccountKey{user1, user2}, lst.OncallValidators)
+	assert.False(t, valManager.storage.DoesKeyRotationExist(ctx, user1))
+
+	// no more update after rotation is applied
+	updates, err = valManager.GetValidatorUpdates(ctx, voteManager)
+	assert.Nil(t, err)
+	assert.Equal(t, []abci.ValidatorUpdate{
+		{PubKey: tmtypes.TM2PB.PubKey(valKey3), Power: types.TendermintValidatorPower},
+		{PubKey: tmtypes.TM2PB.PubKey(valKey2), Power: types.TendermintValidatorPower},
+	}, updates)
+
+	// old key is retired and can't be used again
+	retired, err := valManager.storage.GetRetiredKeys(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{valKey1.Address().Bytes()}, retired.Addresses)
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist(), valManager.RotateValidatorKey(ctx, user2, valKey1))
+
+	// signature of retired key is counted until tendermint applies the rotation
+	err = valManager.UpdateSigningStats(ctx, []abci.VoteInfo{
+		{Validator: abci.Validator{Address: valKey1.Address()}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: valKey2.Address()}, SignedLastBlock: true},
+	})
+	assert.Nil(t, err)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.Equal(t, int64(1), validator.ProducedBlocks)
+
+	ctx = ctx.WithBlockHeight(types.BlockchainUpgrade1Update14Height)
+	// evidence of retired key punishes the validator
+	_, err = valManager.FireIncompetentValidator(ctx, []abci.Evidence{
+		{Validator: abci.Validator{Address: valKey1.Address()}},
+	})
+	assert.Nil(t, err)
+	validator, _ = valManager.storage.GetValidator(ctx, user1)
+	assert.True(t, validator.Tombstoned)
+
+	// key of tombstoned validator can't be used
+	assert.Equal(t, ErrValidatorPubKeyAlreadyExist(), valManager.RotateValidatorKey(ctx, user2, valKey3))
+}
+
 func TestIsLegalWithdraw(t *testing.T) {
 	ctx, am, valManager, _, _ := setupTest(t, 0)
 	minBalance := types.NewCoinFromInt64(100 * types.Decimals)
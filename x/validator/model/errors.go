@@ -16,6 +16,14 @@ func ErrValidatorListNotFound() sdk.Error {
 	return types.NewError(types.CodeValidatorListNotFound, fmt.Sprintf("validator list is not found"))
 }
 
+func ErrSigningInfoNotFound() sdk.Error {
+	return types.NewError(types.CodeSigningInfoNotFound, fmt.Sprintf("signing info is not found"))
+}
+
+func ErrKeyRotationNotFound() sdk.Error {
+	return types.NewError(types.CodeKeyRotationNotFound, fmt.Sprintf("key rotation is not found"))
+}
+
 // marshal error
 func ErrFailedToMarshalValidator(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToMarshalValidator, fmt.Sprintf("failed to marshal validator: %s", err.Error()))
@@ -33,3 +41,27 @@ func ErrFailedToUnmarshalValidator(err error) sdk.Error {
 func ErrFailedToUnmarshalValidatorList(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalValidatorList, fmt.Sprintf("failed to unmarshal validator list: %s", err.Error()))
 }
+
+func ErrFailedToMarshalSigningInfo(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalSigningInfo, fmt.Sprintf("failed to marshal signing info: %s", err.Error()))
+}
+
+func ErrFailedToUnmarshalSigningInfo(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalSigningInfo, fmt.Sprintf("failed to unmarshal signing info: %s", err.Error()))
+}
+
+func ErrFailedToMarshalKeyRotation(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalKeyRotation, fmt.Sprintf("failed to marshal key rotation: %s", err.Error()))
+}
+
+func ErrFailedToUnmarshalKeyRotation(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalKeyRotation, fmt.Sprintf("failed to unmarshal key rotation: %s", err.Error()))
+}
+
+func ErrFailedToMarshalRetiredKeys(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalRetiredKeys, fmt.Sprintf("failed to marshal retired keys: %s", err.Error()))
+}
+
+func ErrFailedToUnmarshalRetiredKeys(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalRetiredKeys, fmt.Sprintf("failed to unmarshal retired keys: %s", err.Error()))
+}
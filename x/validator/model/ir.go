@@ -26,6 +26,12 @@ type ValidatorIR struct {
 	ByzantineCommit int64            `json:"byzantine_commit"`
 	ProducedBlocks  int64            `json:"produced_blocks"`
 	Link            string           `json:"link"`
+	Jailed          bool             `json:"jailed"`
+	JailedUntil     int64            `json:"jailed_until"`
+	Tombstoned      bool             `json:"tombstoned"`
+	Description     string           `json:"description"`
+	Contact         string           `json:"contact"`
+	Identity        string           `json:"identity"`
 }
 
 // ValidatorRowIR - pk: (Username)
@@ -35,8 +41,17 @@ type ValidatorRowIR struct {
 	Validator ValidatorIR `json:"validator"`
 }
 
+// KeyRotationRowIR - pk: (Username), pubkey type changed.
+type KeyRotationRowIR struct {
+	Username  types.AccountKey `json:"username"`
+	NewPubKey ABCIPubKeyIR     `json:"new_pubkey"`
+}
+
 // ValidatorTablesIR - Validators changed.
 type ValidatorTablesIR struct {
-	Validators    []ValidatorRowIR `json:"validators"`
-	ValidatorList ValidatorListRow `json:"validator_list"`
+	Validators    []ValidatorRowIR   `json:"validators"`
+	ValidatorList ValidatorListRow   `json:"validator_list"`
+	SigningInfos  []SigningInfoRow   `json:"signing_infos"`
+	KeyRotations  []KeyRotationRowIR `json:"key_rotations"`
+	RetiredKeys   []RetiredKeysRow   `json:"retired_keys"`
 }
@@ -1,6 +1,8 @@
 package model
 
 import (
+	tmtypes "github.com/tendermint/tendermint/types"
+
 	"github.com/lino-network/lino/types"
 )
 
@@ -24,10 +26,43 @@ type ValidatorListRow struct {
 	List ValidatorList `json:"list"`
 }
 
+// SigningInfoRow - pk: (Username)
+type SigningInfoRow struct {
+	Username    types.AccountKey `json:"username"`
+	SigningInfo SigningInfo      `json:"signing_info"`
+}
+
+// KeyRotationRow - pk: (Username)
+type KeyRotationRow struct {
+	Username    types.AccountKey `json:"username"`
+	KeyRotation KeyRotation      `json:"key_rotation"`
+}
+
+// RetiredKeysRow - pk: (Username)
+type RetiredKeysRow struct {
+	Username    types.AccountKey `json:"username"`
+	RetiredKeys RetiredKeys      `json:"retired_keys"`
+}
+
+// ToIR -
+func (v KeyRotationRow) ToIR() KeyRotationRowIR {
+	abciPubKey := tmtypes.TM2PB.PubKey(v.KeyRotation.NewPubKey)
+	return KeyRotationRowIR{
+		Username: v.Username,
+		NewPubKey: ABCIPubKeyIR{
+			Type: abciPubKey.Type,
+			Data: abciPubKey.Data,
+		},
+	}
+}
+
 // ValidatorTables state of validators
 type ValidatorTables struct {
 	Validators    []ValidatorRow   `json:"validators"`
 	ValidatorList ValidatorListRow `json:"validator_list"`
+	SigningInfos  []SigningInfoRow `json:"signing_infos"`
+	KeyRotations  []KeyRotationRow `json:"key_rotations"`
+	RetiredKeys   []RetiredKeysRow `json:"retired_keys"`
 }
 
 // ToIR -
@@ -37,5 +72,10 @@ func (v ValidatorTables) ToIR() *ValidatorTablesIR {
 		rst.Validators = append(rst.Validators, v.ToIR())
 	}
 	rst.ValidatorList = v.ValidatorList
+	rst.SigningInfos = v.SigningInfos
+	for _, v := range v.KeyRotations {
+		rst.KeyRotations = append(rst.KeyRotations, v.ToIR())
+	}
+	rst.RetiredKeys = v.RetiredKeys
 	return rst
 }
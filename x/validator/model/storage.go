@@ -12,6 +12,9 @@ import (
 var (
 	validatorSubstore     = []byte{0x00}
 	validatorListSubstore = []byte{0x01}
+	signingInfoSubstore   = []byte{0x02}
+	keyRotationSubstore   = []byte{0x03}
+	retiredKeysSubstore   = []byte{0x04}
 )
 
 type ValidatorStorage struct {
@@ -58,6 +61,21 @@ func (vs ValidatorStorage) GetValidator(ctx sdk.Context, accKey types.AccountKey
 	return validator, nil
 }
 
+// IterateValidators - call fn on every validator
+func (vs ValidatorStorage) IterateValidators(ctx sdk.Context, fn func(validator *Validator)) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, validatorSubstore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		validator := new(Validator)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), validator); err != nil {
+			return ErrFailedToUnmarshalValidator(err)
+		}
+		fn(validator)
+	}
+	return nil
+}
+
 func (vs ValidatorStorage) SetValidator(ctx sdk.Context, accKey types.AccountKey, validator *Validator) sdk.Error {
 	store := ctx.KVStore(vs.key)
 	validatorByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*validator)
@@ -74,6 +92,25 @@ func (vs ValidatorStorage) DeleteValidator(ctx sdk.Context, username types.Accou
 	return nil
 }
 
+// GetAllValidators - get all validators in store, including jailed and tombstoned validators
+func (vs ValidatorStorage) GetAllValidators(ctx sdk.Context) ([]ValidatorRow, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, validatorSubstore)
+	defer itr.Close()
+	rows := []ValidatorRow{}
+	for ; itr.Valid(); itr.Next() {
+		validator := new(Validator)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), validator); err != nil {
+			return nil, ErrFailedToUnmarshalValidator(err)
+		}
+		rows = append(rows, ValidatorRow{
+			Username:  types.AccountKey(itr.Key()[1:]),
+			Validator: *validator,
+		})
+	}
+	return rows, nil
+}
+
 func (vs ValidatorStorage) GetValidatorList(ctx sdk.Context) (*ValidatorList, sdk.Error) {
 	store := ctx.KVStore(vs.key)
 	listByte := store.Get(GetValidatorListKey())
@@ -97,6 +134,134 @@ func (vs ValidatorStorage) SetValidatorList(ctx sdk.Context, lst *ValidatorList)
 	return nil
 }
 
+func (vs ValidatorStorage) DoesSigningInfoExist(ctx sdk.Context, accKey types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(GetSigningInfoKey(accKey))
+}
+
+func (vs ValidatorStorage) GetSigningInfo(ctx sdk.Context, accKey types.AccountKey) (*SigningInfo, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	infoByte := store.Get(GetSigningInfoKey(accKey))
+	if infoByte == nil {
+		return nil, ErrSigningInfoNotFound()
+	}
+	info := new(SigningInfo)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(infoByte, info); err != nil {
+		return nil, ErrFailedToUnmarshalSigningInfo(err)
+	}
+	return info, nil
+}
+
+func (vs ValidatorStorage) SetSigningInfo(ctx sdk.Context, accKey types.AccountKey, info *SigningInfo) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	infoByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*info)
+	if err != nil {
+		return ErrFailedToMarshalSigningInfo(err)
+	}
+	store.Set(GetSigningInfoKey(accKey), infoByte)
+	return nil
+}
+
+func (vs ValidatorStorage) DeleteSigningInfo(ctx sdk.Context, accKey types.AccountKey) {
+	store := ctx.KVStore(vs.key)
+	store.Delete(GetSigningInfoKey(accKey))
+}
+
+func (vs ValidatorStorage) DoesKeyRotationExist(ctx sdk.Context, accKey types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(GetKeyRotationKey(accKey))
+}
+
+func (vs ValidatorStorage) GetKeyRotation(ctx sdk.Context, accKey types.AccountKey) (*KeyRotation, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	rotationByte := store.Get(GetKeyRotationKey(accKey))
+	if rotationByte == nil {
+		return nil, ErrKeyRotationNotFound()
+	}
+	rotation := new(KeyRotation)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(rotationByte, rotation); err != nil {
+		return nil, ErrFailedToUnmarshalKeyRotation(err)
+	}
+	return rotation, nil
+}
+
+func (vs ValidatorStorage) SetKeyRotation(ctx sdk.Context, accKey types.AccountKey, rotation *KeyRotation) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	rotationByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*rotation)
+	if err != nil {
+		return ErrFailedToMarshalKeyRotation(err)
+	}
+	store.Set(GetKeyRotationKey(accKey), rotationByte)
+	return nil
+}
+
+func (vs ValidatorStorage) DeleteKeyRotation(ctx sdk.Context, accKey types.AccountKey) {
+	store := ctx.KVStore(vs.key)
+	store.Delete(GetKeyRotationKey(accKey))
+}
+
+// GetAllKeyRotations - get all pending key rotations
+func (vs ValidatorStorage) GetAllKeyRotations(ctx sdk.Context) ([]KeyRotationRow, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, keyRotationSubstore)
+	defer itr.Close()
+	rows := []KeyRotationRow{}
+	for ; itr.Valid(); itr.Next() {
+		rotation := new(KeyRotation)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), rotation); err != nil {
+			return nil, ErrFailedToUnmarshalKeyRotation(err)
+		}
+		rows = append(rows, KeyRotationRow{
+			Username:    types.AccountKey(itr.Key()[1:]),
+			KeyRotation: *rotation,
+		})
+	}
+	return rows, nil
+}
+
+// GetRetiredKeys - get retired keys of validator, empty if it has never rotated key
+func (vs ValidatorStorage) GetRetiredKeys(ctx sdk.Context, accKey types.AccountKey) (*RetiredKeys, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	retiredByte := store.Get(GetRetiredKeysKey(accKey))
+	if retiredByte == nil {
+		return &RetiredKeys{}, nil
+	}
+	retired := new(RetiredKeys)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(retiredByte, retired); err != nil {
+		return nil, ErrFailedToUnmarshalRetiredKeys(err)
+	}
+	return retired, nil
+}
+
+func (vs ValidatorStorage) SetRetiredKeys(ctx sdk.Context, accKey types.AccountKey, retired *RetiredKeys) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	retiredByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*retired)
+	if err != nil {
+		return ErrFailedToMarshalRetiredKeys(err)
+	}
+	store.Set(GetRetiredKeysKey(accKey), retiredByte)
+	return nil
+}
+
+// GetAllRetiredKeys - get retired keys of all validators
+func (vs ValidatorStorage) GetAllRetiredKeys(ctx sdk.Context) ([]RetiredKeysRow, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, retiredKeysSubstore)
+	defer itr.Close()
+	rows := []RetiredKeysRow{}
+	for ; itr.Valid(); itr.Next() {
+		retired := new(RetiredKeys)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), retired); err != nil {
+			return nil, ErrFailedToUnmarshalRetiredKeys(err)
+		}
+		rows = append(rows, RetiredKeysRow{
+			Username:    types.AccountKey(itr.Key()[1:]),
+			RetiredKeys: *retired,
+		})
+	}
+	return rows, nil
+}
+
 // Export state of validators.
 func (vs ValidatorStorage) Export(ctx sdk.Context) *ValidatorTables {
 	tables := &ValidatorTables{}
@@ -127,6 +292,39 @@ func (vs ValidatorStorage) Export(ctx sdk.Context) *ValidatorTables {
 	tables.ValidatorList = ValidatorListRow{
 		List: *list,
 	}
+	// export table.signingInfos
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, signingInfoSubstore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			username := types.AccountKey(k[1:])
+			info, err := vs.GetSigningInfo(ctx, username)
+			if err != nil {
+				panic("failed to read signing info: " + err.Error())
+			}
+			tables.SigningInfos = append(tables.SigningInfos, SigningInfoRow{
+				Username:    username,
+				SigningInfo: *info,
+			})
+		}
+	}()
+	// export table.keyRotations
+	rotations, err := vs.GetAllKeyRotations(ctx)
+	if err != nil {
+		panic("failed to read key rotations: " + err.Error())
+	}
+	if len(rotations) > 0 {
+		tables.KeyRotations = rotations
+	}
+	// export table.retiredKeys
+	retired, err := vs.GetAllRetiredKeys(ctx)
+	if err != nil {
+		panic("failed to read retired keys: " + err.Error())
+	}
+	if len(retired) > 0 {
+		tables.RetiredKeys = retired
+	}
 	return tables
 }
 
@@ -156,12 +354,40 @@ func (vs ValidatorStorage) Import(ctx sdk.Context, tb *ValidatorTablesIR) {
 			ByzantineCommit: v.Validator.ByzantineCommit,
 			ProducedBlocks:  v.Validator.ProducedBlocks,
 			Link:            v.Validator.Link,
+			Jailed:          v.Validator.Jailed,
+			JailedUntil:     v.Validator.JailedUntil,
+			Tombstoned:      v.Validator.Tombstoned,
+			Description:     v.Validator.Description,
+			Contact:         v.Validator.Contact,
+			Identity:        v.Validator.Identity,
 		})
 		check(err)
 	}
 	// import ValidatorList
 	err := vs.SetValidatorList(ctx, &tb.ValidatorList.List)
 	check(err)
+	// import table.SigningInfos
+	for _, v := range tb.SigningInfos {
+		info := v.SigningInfo
+		err := vs.SetSigningInfo(ctx, v.Username, &info)
+		check(err)
+	}
+	// import table.KeyRotations
+	for _, v := range tb.KeyRotations {
+		pubkey, err := tmtypes.PB2TM.PubKey(abci.PubKey{
+			Type: v.NewPubKey.Type,
+			Data: v.NewPubKey.Data,
+		})
+		check(err)
+		err = vs.SetKeyRotation(ctx, v.Username, &KeyRotation{NewPubKey: pubkey})
+		check(err)
+	}
+	// import table.RetiredKeys
+	for _, v := range tb.RetiredKeys {
+		retired := v.RetiredKeys
+		err := vs.SetRetiredKeys(ctx, v.Username, &retired)
+		check(err)
+	}
 }
 
 func GetValidatorKey(accKey types.AccountKey) []byte {
@@ -171,3 +397,15 @@ func GetValidatorKey(accKey types.AccountKey) []byte {
 func GetValidatorListKey() []byte {
 	return validatorListSubstore
 }
+
+func GetSigningInfoKey(accKey types.AccountKey) []byte {
+	return append(signingInfoSubstore, accKey...)
+}
+
+func GetKeyRotationKey(accKey types.AccountKey) []byte {
+	return append(keyRotationSubstore, accKey...)
+}
+
+func GetRetiredKeysKey(accKey types.AccountKey) []byte {
+	return append(retiredKeysSubstore, accKey...)
+}
@@ -119,3 +119,78 @@ func TestValidatorList(t *testing.T) {
 		}
 	}
 }
+
+func TestSigningInfo(t *testing.T) {
+	ctx, vs := setup(t)
+
+	user := types.AccountKey("user")
+	assert.False(t, vs.DoesSigningInfoExist(ctx, user))
+	_, err := vs.GetSigningInfo(ctx, user)
+	assert.Equal(t, ErrSigningInfoNotFound(), err)
+
+	info := SigningInfo{
+		Window:              10,
+		StartHeight:         100,
+		IndexOffset:         12,
+		MissedBlocksCounter: 2,
+		MissedBlocks:        []byte{0x05, 0x00},
+	}
+	err = vs.SetSigningInfo(ctx, user, &info)
+	assert.Nil(t, err)
+	assert.True(t, vs.DoesSigningInfoExist(ctx, user))
+	infoPtr, err := vs.GetSigningInfo(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, info, *infoPtr)
+
+	assert.Equal(t, []SigningInfoRow{{Username: user, SigningInfo: info}}, vs.Export(ctx).SigningInfos)
+
+	vs.DeleteSigningInfo(ctx, user)
+	assert.False(t, vs.DoesSigningInfoExist(ctx, user))
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx, vs := setup(t)
+
+	user := types.AccountKey("user")
+	assert.False(t, vs.DoesKeyRotationExist(ctx, user))
+	_, err := vs.GetKeyRotation(ctx, user)
+	assert.Equal(t, ErrKeyRotationNotFound(), err)
+
+	rotation := KeyRotation{NewPubKey: secp256k1.GenPrivKey().PubKey()}
+	err = vs.SetKeyRotation(ctx, user, &rotation)
+	assert.Nil(t, err)
+	rotationPtr, err := vs.GetKeyRotation(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, rotation, *rotationPtr)
+
+	rows, err := vs.GetAllKeyRotations(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []KeyRotationRow{{Username: user, KeyRotation: rotation}}, rows)
+
+	vs.DeleteKeyRotation(ctx, user)
+	assert.False(t, vs.DoesKeyRotationExist(ctx, user))
+}
+
+func TestRetiredKeys(t *testing.T) {
+	ctx, vs := setup(t)
+
+	user := types.AccountKey("user")
+	retiredPtr, err := vs.GetRetiredKeys(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, RetiredKeys{}, *retiredPtr)
+
+	retired := RetiredKeys{Addresses: [][]byte{secp256k1.GenPrivKey().PubKey().Address().Bytes()}}
+	err = vs.SetRetiredKeys(ctx, user, &retired)
+	assert.Nil(t, err)
+	retiredPtr, err = vs.GetRetiredKeys(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, retired, *retiredPtr)
+
+	rows := []RetiredKeysRow{{Username: user, RetiredKeys: retired}}
+	assert.Equal(t, rows, vs.Export(ctx).RetiredKeys)
+	ctx2, vs2 := setup(t)
+	vs2.Import(ctx2, vs.Export(ctx).ToIR())
+	allRetired, err := vs2.GetAllRetiredKeys(ctx2)
+	assert.Nil(t, err)
+	assert.Equal(t, rows, allRetired)
+}
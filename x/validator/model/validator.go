@@ -18,6 +18,12 @@ type Validator struct {
 	ByzantineCommit int64            `json:"byzantine_commit"`
 	ProducedBlocks  int64            `json:"produced_blocks"`
 	Link            string           `json:"link"`
+	Jailed          bool             `json:"jailed"`
+	JailedUntil     int64            `json:"jailed_until"`
+	Tombstoned      bool             `json:"tombstoned"`
+	Description     string           `json:"description"`
+	Contact         string           `json:"contact"`
+	Identity        string           `json:"identity"`
 }
 
 // ToIR -
@@ -38,6 +44,12 @@ func (v Validator) ToIR() ValidatorIR {
 		ByzantineCommit: v.ByzantineCommit,
 		ProducedBlocks:  v.ProducedBlocks,
 		Link:            v.Link,
+		Jailed:          v.Jailed,
+		JailedUntil:     v.JailedUntil,
+		Tombstoned:      v.Tombstoned,
+		Description:     v.Description,
+		Contact:         v.Contact,
+		Identity:        v.Identity,
 	}
 }
 
@@ -49,3 +61,24 @@ type ValidatorList struct {
 	LowestPower        types.Coin         `json:"lowest_power"`
 	LowestValidator    types.AccountKey   `json:"lowest_validator"`
 }
+
+// SigningInfo - signing window of an oncall validator, MissedBlocks is a bitarray
+// of the last Window blocks, bit at (IndexOffset - 1) % Window is the latest block
+type SigningInfo struct {
+	Window              int64  `json:"window"`
+	StartHeight         int64  `json:"start_height"`
+	IndexOffset         int64  `json:"index_offset"`
+	MissedBlocksCounter int64  `json:"missed_blocks_counter"`
+	MissedBlocks        []byte `json:"missed_blocks"`
+}
+
+// KeyRotation - pending consensus key change of validator, applied when validator set is updated
+type KeyRotation struct {
+	NewPubKey crypto.PubKey `json:"new_pubkey"`
+}
+
+// RetiredKeys - addresses of consensus keys a validator has rotated away from,
+// evidence and signatures of these keys are still attributed to the validator
+type RetiredKeys struct {
+	Addresses [][]byte `json:"addresses"`
+}
@@ -3,6 +3,7 @@ package validator
 // nolint
 import (
 	"fmt"
+	"unicode/utf8"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/types"
@@ -12,6 +13,9 @@ import (
 var _ types.Msg = ValidatorDepositMsg{}
 var _ types.Msg = ValidatorWithdrawMsg{}
 var _ types.Msg = ValidatorRevokeMsg{}
+var _ types.Msg = ValidatorUnjailMsg{}
+var _ types.Msg = RotateValidatorKeyMsg{}
+var _ types.Msg = ValidatorUpdateMsg{}
 
 // ValidatorDepositMsg - deposit to become validator or add deposit
 type ValidatorDepositMsg struct {
@@ -32,6 +36,26 @@ type ValidatorRevokeMsg struct {
 	Username types.AccountKey `json:"username"`
 }
 
+// ValidatorUnjailMsg - unjail validator after jail period
+type ValidatorUnjailMsg struct {
+	Username types.AccountKey `json:"username"`
+}
+
+// RotateValidatorKeyMsg - change validator public key
+type RotateValidatorKeyMsg struct {
+	Username     types.AccountKey `json:"username"`
+	NewValPubKey crypto.PubKey    `json:"new_validator_public_key"`
+}
+
+// ValidatorUpdateMsg - update validator profile
+type ValidatorUpdateMsg struct {
+	Username    types.AccountKey `json:"username"`
+	Link        string           `json:"link"`
+	Description string           `json:"description"`
+	Contact     string           `json:"contact"`
+	Identity    string           `json:"identity"`
+}
+
 // ValidatorDepositMsg Msg Implementations
 func NewValidatorDepositMsg(validator string, deposit types.LNO, pubKey crypto.PubKey, link string) ValidatorDepositMsg {
 	return ValidatorDepositMsg{
@@ -199,3 +223,175 @@ func (msg ValidatorRevokeMsg) GetSigners() []sdk.AccAddress {
 func (msg ValidatorRevokeMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+// ValidatorUnjailMsg Msg Implementations
+func NewValidatorUnjailMsg(validator string) ValidatorUnjailMsg {
+	return ValidatorUnjailMsg{
+		Username: types.AccountKey(validator),
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg ValidatorUnjailMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg ValidatorUnjailMsg) Type() string { return "ValidatorUnjailMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ValidatorUnjailMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	return nil
+}
+
+func (msg ValidatorUnjailMsg) String() string {
+	return fmt.Sprintf("ValidatorUnjailMsg{Username:%v}", msg.Username)
+}
+
+// GetPermission - implement types.Msg
+func (msg ValidatorUnjailMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ValidatorUnjailMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ValidatorUnjailMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ValidatorUnjailMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// RotateValidatorKeyMsg Msg Implementations
+func NewRotateValidatorKeyMsg(validator string, pubKey crypto.PubKey) RotateValidatorKeyMsg {
+	return RotateValidatorKeyMsg{
+		Username:     types.AccountKey(validator),
+		NewValPubKey: pubKey,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg RotateValidatorKeyMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg RotateValidatorKeyMsg) Type() string { return "RotateValidatorKeyMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg RotateValidatorKeyMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if msg.NewValPubKey == nil {
+		return ErrInvalidValidatorPubKey()
+	}
+	return nil
+}
+
+func (msg RotateValidatorKeyMsg) String() string {
+	return fmt.Sprintf("RotateValidatorKeyMsg{Username:%v, NewPubKey:%v}", msg.Username, msg.NewValPubKey)
+}
+
+// GetPermission - implement types.Msg
+func (msg RotateValidatorKeyMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg RotateValidatorKeyMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg RotateValidatorKeyMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg RotateValidatorKeyMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// ValidatorUpdateMsg Msg Implementations
+func NewValidatorUpdateMsg(validator, link, description, contact, identity string) ValidatorUpdateMsg {
+	return ValidatorUpdateMsg{
+		Username:    types.AccountKey(validator),
+		Link:        link,
+		Description: description,
+		Contact:     contact,
+		Identity:    identity,
+	}
+}
+
+// Route - implement sdk.Msg
+func (msg ValidatorUpdateMsg) Route() string { return RouterKey }
+
+// Type - implement sdk.Msg
+func (msg ValidatorUpdateMsg) Type() string { return "ValidatorUpdateMsg" }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ValidatorUpdateMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if len(msg.Link) > types.MaximumLinkURL {
+		return ErrInvalidWebsite()
+	}
+	if utf8.RuneCountInString(msg.Description) > types.MaximumLengthOfValidatorDescription {
+		return ErrInvalidDescription()
+	}
+	if utf8.RuneCountInString(msg.Contact) > types.MaximumLengthOfValidatorContact {
+		return ErrInvalidContact()
+	}
+	if utf8.RuneCountInString(msg.Identity) > types.MaximumLengthOfValidatorIdentity {
+		return ErrInvalidIdentity()
+	}
+	return nil
+}
+
+func (msg ValidatorUpdateMsg) String() string {
+	return fmt.Sprintf(
+		"ValidatorUpdateMsg{Username:%v, Link:%v, Description:%v, Contact:%v, Identity:%v}",
+		msg.Username, msg.Link, msg.Description, msg.Contact, msg.Identity)
+}
+
+// GetPermission - implement types.Msg
+func (msg ValidatorUpdateMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ValidatorUpdateMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ValidatorUpdateMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ValidatorUpdateMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
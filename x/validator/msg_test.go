@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,6 +37,125 @@ func TestValidatorRevokeMsg(t *testing.T) {
 	}
 }
 
+func TestValidatorUnjailMsg(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		validatorUnjailMsg ValidatorUnjailMsg
+		expectedError      sdk.Error
+	}{
+		{
+			testName:           "normal case",
+			validatorUnjailMsg: NewValidatorUnjailMsg("user1"),
+			expectedError:      nil,
+		},
+		{
+			testName:           "invalid username",
+			validatorUnjailMsg: NewValidatorUnjailMsg(""),
+			expectedError:      ErrInvalidUsername(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.validatorUnjailMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestRotateValidatorKeyMsg(t *testing.T) {
+	testCases := []struct {
+		testName              string
+		rotateValidatorKeyMsg RotateValidatorKeyMsg
+		expectedError         sdk.Error
+	}{
+		{
+			testName:              "normal case",
+			rotateValidatorKeyMsg: NewRotateValidatorKeyMsg("user1", secp256k1.GenPrivKey().PubKey()),
+			expectedError:         nil,
+		},
+		{
+			testName:              "invalid username",
+			rotateValidatorKeyMsg: NewRotateValidatorKeyMsg("", secp256k1.GenPrivKey().PubKey()),
+			expectedError:         ErrInvalidUsername(),
+		},
+		{
+			testName:              "empty public key",
+			rotateValidatorKeyMsg: NewRotateValidatorKeyMsg("user1", nil),
+			expectedError:         ErrInvalidValidatorPubKey(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.rotateValidatorKeyMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestValidatorUpdateMsg(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		validatorUpdateMsg ValidatorUpdateMsg
+		expectedError      sdk.Error
+	}{
+		{
+			testName: "normal case",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", "https://lino.network", "description", "contact@lino.network", "0xABCD"),
+			expectedError: nil,
+		},
+		{
+			testName:           "empty profile",
+			validatorUpdateMsg: NewValidatorUpdateMsg("user1", "", "", "", ""),
+			expectedError:      nil,
+		},
+		{
+			testName:           "invalid username",
+			validatorUpdateMsg: NewValidatorUpdateMsg("", "", "", "", ""),
+			expectedError:      ErrInvalidUsername(),
+		},
+		{
+			testName: "link is too long",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", strings.Repeat("l", types.MaximumLinkURL+1), "", "", ""),
+			expectedError: ErrInvalidWebsite(),
+		},
+		{
+			testName: "description is too long",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", "", strings.Repeat("d", types.MaximumLengthOfValidatorDescription+1), "", ""),
+			expectedError: ErrInvalidDescription(),
+		},
+		{
+			testName: "utf8 description is not too long",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", "", strings.Repeat("测", types.MaximumLengthOfValidatorDescription), "", ""),
+			expectedError: nil,
+		},
+		{
+			testName: "contact is too long",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", "", "", strings.Repeat("c", types.MaximumLengthOfValidatorContact+1), ""),
+			expectedError: ErrInvalidContact(),
+		},
+		{
+			testName: "identity is too long",
+			validatorUpdateMsg: NewValidatorUpdateMsg(
+				"user1", "", "", "", strings.Repeat("i", types.MaximumLengthOfValidatorIdentity+1)),
+			expectedError: ErrInvalidIdentity(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.validatorUpdateMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestValidatorWithdrawMsg(t *testing.T) {
 	testCases := []struct {
 		testName             string
@@ -121,6 +241,21 @@ func TestMsgPermission(t *testing.T) {
 			msg:                NewValidatorRevokeMsg("test"),
 			expectedPermission: types.TransactionPermission,
 		},
+		{
+			testName:           "validator unjail msg",
+			msg:                NewValidatorUnjailMsg("test"),
+			expectedPermission: types.TransactionPermission,
+		},
+		{
+			testName:           "rotate validator key msg",
+			msg:                NewRotateValidatorKeyMsg("test", secp256k1.GenPrivKey().PubKey()),
+			expectedPermission: types.TransactionPermission,
+		},
+		{
+			testName:           "validator update msg",
+			msg:                NewValidatorUpdateMsg("test", "https://lino.network", "", "", ""),
+			expectedPermission: types.TransactionPermission,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -150,6 +285,18 @@ func TestGetSignBytes(t *testing.T) {
 			testName: "validator revoke msg",
 			msg:      NewValidatorRevokeMsg("test"),
 		},
+		{
+			testName: "validator unjail msg",
+			msg:      NewValidatorUnjailMsg("test"),
+		},
+		{
+			testName: "rotate validator key msg",
+			msg:      NewRotateValidatorKeyMsg("test", secp256k1.GenPrivKey().PubKey()),
+		},
+		{
+			testName: "validator update msg",
+			msg:      NewValidatorUpdateMsg("test", "https://lino.network", "", "", ""),
+		},
 	}
 
 	for testName, tc := range testCases {
@@ -179,6 +326,21 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewValidatorRevokeMsg("test"),
 			expectSigners: []types.AccountKey{"test"},
 		},
+		{
+			testName:      "validator unjail msg",
+			msg:           NewValidatorUnjailMsg("test"),
+			expectSigners: []types.AccountKey{"test"},
+		},
+		{
+			testName:      "rotate validator key msg",
+			msg:           NewRotateValidatorKeyMsg("test", secp256k1.GenPrivKey().PubKey()),
+			expectSigners: []types.AccountKey{"test"},
+		},
+		{
+			testName:      "validator update msg",
+			msg:           NewValidatorUpdateMsg("test", "https://lino.network", "", "", ""),
+			expectSigners: []types.AccountKey{"test"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -19,6 +19,7 @@ const (
 
 	QueryValidator     = "validator"
 	QueryValidatorList = "valList"
+	QueryUptime        = "uptime"
 )
 
 // creates a querier for validator REST endpoints
@@ -31,6 +32,8 @@ func NewQuerier(vm ValidatorManager) sdk.Querier {
 			return queryValidator(ctx, cdc, path[1:], req, vm)
 		case QueryValidatorList:
 			return queryValidatorList(ctx, cdc, path[1:], req, vm)
+		case QueryUptime:
+			return queryUptime(ctx, cdc, path[1:], req, vm)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown validator query endpoint")
 		}
@@ -63,3 +66,18 @@ func queryValidatorList(ctx sdk.Context, cdc *wire.Codec, path []string, req abc
 	}
 	return res, nil
 }
+
+func queryUptime(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, vm ValidatorManager) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	uptime, err := vm.GetValidatorUptime(ctx, types.AccountKey(path[0]))
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(uptime)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
@@ -9,6 +9,9 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(ValidatorDepositMsg{}, "lino/valDeposit", nil)
 	cdc.RegisterConcrete(ValidatorWithdrawMsg{}, "lino/valWithdraw", nil)
 	cdc.RegisterConcrete(ValidatorRevokeMsg{}, "lino/valRevoke", nil)
+	cdc.RegisterConcrete(ValidatorUnjailMsg{}, "lino/valUnjail", nil)
+	cdc.RegisterConcrete(RotateValidatorKeyMsg{}, "lino/valRotateKey", nil)
+	cdc.RegisterConcrete(ValidatorUpdateMsg{}, "lino/valUpdate", nil)
 }
 
 var msgCdc = wire.New()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/vote"
 	"github.com/lino-network/lino/x/vote/model"
 
 	wire "github.com/cosmos/cosmos-sdk/codec"
@@ -27,6 +28,15 @@ func GetDelegationCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetPendingRewardCmd returns delegator reward not yet settled from each voter
+func GetPendingRewardCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pending-reward <delegator>",
+		Short: "Query delegator reward not yet settled from each voter",
+		RunE:  getPendingRewardCmd(cdc),
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -58,3 +68,27 @@ func (c commander) getDelegationCmd(cmd *cobra.Command, args []string) error {
 	fmt.Println(string(output))
 	return nil
 }
+
+func getPendingRewardCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 1 || len(args[0]) == 0 {
+			return errors.New("You must provide a delegator name")
+		}
+
+		res, err := ctx.QueryCustom(vote.QuerierRoute, vote.QueryPendingReward, args[0])
+		if err != nil {
+			return err
+		}
+		rewards := []vote.PendingReward{}
+		if err := cdc.UnmarshalJSON(res, &rewards); err != nil {
+			return err
+		}
+		output, err := wire.MarshalJSONIndent(cdc, rewards)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+}
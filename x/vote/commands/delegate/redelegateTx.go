@@ -0,0 +1,49 @@
+package delegate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/vote"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RedelegateTxCmd will create a redelegate tx and sign it with the given key
+func RedelegateTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegate",
+		Short: "move delegation from one voter to another",
+		RunE:  sendRedelegateTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "delegator")
+	cmd.Flags().String(client.FlagFromVoter, "", "voter to move delegation from")
+	cmd.Flags().String(client.FlagToVoter, "", "voter to move delegation to")
+	cmd.Flags().String(client.FlagAmount, "", "amount to redelegate")
+	return cmd
+}
+
+func sendRedelegateTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		user := viper.GetString(client.FlagUser)
+		fromVoter := viper.GetString(client.FlagFromVoter)
+		toVoter := viper.GetString(client.FlagToVoter)
+		// create the message
+		msg := vote.NewRedelegateMsg(user, fromVoter, toVoter, viper.GetString(client.FlagAmount))
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if signErr != nil {
+			return signErr
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
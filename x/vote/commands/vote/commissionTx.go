@@ -0,0 +1,45 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/x/vote"
+
+	wire "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetCommissionTxCmd will create a set commission tx and sign it with the given key
+func SetCommissionTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-commission",
+		Short: "set the share of delegators' validator inflation voter keeps",
+		RunE:  sendSetCommissionTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "voter")
+	cmd.Flags().String(client.FlagCommissionRate, "", "commission rate in [0, 1]")
+	return cmd
+}
+
+func sendSetCommissionTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		user := viper.GetString(client.FlagUser)
+		// create the message
+		msg := vote.NewSetCommissionMsg(user, viper.GetString(client.FlagCommissionRate))
+
+		// build and sign the transaction, then broadcast to Tendermint
+		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
+
+		if signErr != nil {
+			return signErr
+		}
+
+		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+		return nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	wire "github.com/cosmos/cosmos-sdk/codec"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/vote"
 	"github.com/lino-network/lino/x/vote/model"
 )
 
@@ -39,6 +40,15 @@ func GetVoteCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetSnapshotPowerCmd returns a voter's power at the creation of a proposal
+func GetSnapshotPowerCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshot-power <proposal-id> <voter>",
+		Short: "Query voting power of a voter at the creation of an ongoing proposal",
+		RunE:  getSnapshotPowerCmd(cdc),
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -97,3 +107,23 @@ func (c commander) getVoteCmd(cmd *cobra.Command, args []string) error {
 	fmt.Println(string(output))
 	return nil
 }
+
+func getSnapshotPowerCmd(cdc *wire.Codec) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		if len(args) != 2 {
+			return errors.New("You must provide proposal ID and voter name")
+		}
+
+		res, err := ctx.QueryCustom(vote.QuerierRoute, vote.QuerySnapshotPower, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		power := types.Coin{}
+		if err := cdc.UnmarshalJSON(res, &power); err != nil {
+			return err
+		}
+		fmt.Println(power.String())
+		return nil
+	}
+}
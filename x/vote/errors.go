@@ -47,7 +47,27 @@ func ErrInvalidUsername() sdk.Error {
 	return types.NewError(types.CodeInvalidUsername, fmt.Sprintf("invalid username"))
 }
 
+// ErrRedelegateTooOften - error if delegator redelegates within the redelegate interval
+func ErrRedelegateTooOften() sdk.Error {
+	return types.NewError(types.CodeRedelegateTooOften, fmt.Sprintf("redelegate too often"))
+}
+
+// ErrInvalidRedelegate - error if delegator redelegates to the same voter
+func ErrInvalidRedelegate() sdk.Error {
+	return types.NewError(types.CodeInvalidRedelegate, fmt.Sprintf("can't redelegate to the same voter"))
+}
+
 // ErrQueryFailed - error when query vote store failed
 func ErrQueryFailed() sdk.Error {
 	return types.NewError(types.CodeVoteQueryFailed, fmt.Sprintf("query vote store failed"))
 }
+
+// ErrNoDelegatedPower - error if reward is distributed to delegators of a voter without delegation
+func ErrNoDelegatedPower() sdk.Error {
+	return types.NewError(types.CodeNoDelegatedPower, fmt.Sprintf("voter has no delegated power"))
+}
+
+// ErrInvalidCommissionRate - error if commission rate is not in [0, 1]
+func ErrInvalidCommissionRate() sdk.Error {
+	return types.NewError(types.CodeInvalidCommissionRate, fmt.Sprintf("commission rate must be in [0, 1]"))
+}
@@ -25,6 +25,10 @@ func NewHandler(vm VoteManager, am acc.AccountManager, gm *global.GlobalManager)
 			return handleDelegatorWithdrawMsg(ctx, vm, gm, am, msg)
 		case ClaimInterestMsg:
 			return handleClaimInterestMsg(ctx, vm, gm, am, msg)
+		case RedelegateMsg:
+			return handleRedelegateMsg(ctx, vm, gm, am, msg)
+		case SetCommissionMsg:
+			return handleSetCommissionMsg(ctx, vm, gm, am, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized vote msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -63,7 +67,7 @@ func handleStakeInMsg(
 		return err.Result()
 	}
 
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.VoterDeposit)}
 }
 
 func handleStakeOutMsg(
@@ -92,7 +96,7 @@ func handleStakeOutMsg(
 		param.VoterCoinReturnIntervalSec, coin, types.VoteReturnCoin); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, coin, types.VoteReturnCoin)}
 }
 
 func handleDelegateMsg(
@@ -130,7 +134,7 @@ func handleDelegateMsg(
 	if addErr := vm.AddDelegation(ctx, msg.Voter, msg.Delegator, coin); addErr != nil {
 		return addErr.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.TransferTags(msg.Delegator, msg.Voter, coin, types.Delegate)}
 }
 
 func handleDelegatorWithdrawMsg(
@@ -160,13 +164,54 @@ func handleDelegatorWithdrawMsg(
 		param.DelegatorCoinReturnIntervalSec, coin, types.DelegationReturnCoin); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.TransferTags(msg.Voter, msg.Delegator, coin, types.DelegationReturnCoin)}
+}
+
+func handleRedelegateMsg(
+	ctx sdk.Context, vm VoteManager, gm *global.GlobalManager,
+	am acc.AccountManager, msg RedelegateMsg) sdk.Result {
+	// Must have an normal acount
+	if !am.DoesAccountExist(ctx, msg.ToVoter) {
+		return ErrAccountNotFound().Result()
+	}
+	coin, err := types.LinoToCoin(msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	param, err := vm.paramHolder.GetVoteParam(ctx)
+	if err != nil {
+		return err.Result()
+	}
+	// moved delegation must meet the minimum of a new delegation
+	if param.MinStakeIn.IsGT(coin) {
+		return ErrInsufficientDeposit().Result()
+	}
+	if !vm.IsLegalDelegatorWithdraw(ctx, msg.FromVoter, msg.Delegator, coin) {
+		return ErrIllegalWithdraw().Result()
+	}
+	if vm.IsRedelegateTooOften(ctx, msg.Delegator) {
+		return ErrRedelegateTooOften().Result()
+	}
+	if err := vm.Redelegate(ctx, msg.Delegator, msg.FromVoter, msg.ToVoter, coin); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{Tags: types.TransferTags(msg.FromVoter, msg.ToVoter, coin, types.Redelegate)}
 }
 
 func handleClaimInterestMsg(ctx sdk.Context, vm VoteManager, gm *global.GlobalManager, am acc.AccountManager, msg ClaimInterestMsg) sdk.Result {
 	if err := calculateAndAddInterest(ctx, vm, gm, am, msg.Username); err != nil {
 		return err.Result()
 	}
+	// settle delegator reward from all voters user delegates to
+	voters, err := vm.GetAllDelegatees(ctx, msg.Username)
+	if err != nil {
+		return err.Result()
+	}
+	for _, voter := range voters {
+		if err := vm.SettleDelegatorReward(ctx, voter, msg.Username); err != nil {
+			return err.Result()
+		}
+	}
 	// claim interest
 	interest, err := vm.ClaimInterest(ctx, msg.Username)
 	if err != nil {
@@ -176,7 +221,23 @@ func handleClaimInterestMsg(ctx sdk.Context, vm VoteManager, gm *global.GlobalMa
 		ctx, msg.Username, interest, "", "", types.ClaimInterest); err != nil {
 		return err.Result()
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: types.CoinTags(msg.Username, interest, types.ClaimInterest)}
+}
+
+func handleSetCommissionMsg(
+	ctx sdk.Context, vm VoteManager, gm *global.GlobalManager,
+	am acc.AccountManager, msg SetCommissionMsg) sdk.Result {
+	rate, err := msg.GetCommissionRate()
+	if err != nil {
+		return err.Result()
+	}
+	if !vm.DoesVoterExist(ctx, msg.Username) {
+		return ErrVoterNotFound().Result()
+	}
+	if err := vm.SetCommissionRate(ctx, msg.Username, rate); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{Tags: types.UsernameTags(msg.Username)}
 }
 
 func AddStake(
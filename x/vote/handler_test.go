@@ -2,6 +2,7 @@ package vote
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lino-network/lino/types"
 	globalModel "github.com/lino-network/lino/x/global/model"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func TestVoterDepositBasic(t *testing.T) {
@@ -22,7 +24,7 @@ func TestVoterDepositBasic(t *testing.T) {
 	// let user1 register as voter
 	msg := NewStakeInMsg("user1", coinToString(voteParam.MinStakeIn))
 	result := handler(ctx, msg)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, sdk.Result{Tags: types.CoinTags(user1, voteParam.MinStakeIn, types.VoterDeposit)}, result)
 
 	// check acc1's money has been withdrawn
 	acc1saving, _ := am.GetSavingFromBank(ctx, user1)
@@ -61,7 +63,7 @@ func TestDelegateBasic(t *testing.T) {
 	msg2 := NewDelegateMsg("user2", "user1", coinToString(delegatedCoin))
 	handler(ctx, msg2)
 	result2 := handler(ctx, msg2)
-	assert.Equal(t, sdk.Result{}, result2)
+	assert.Equal(t, sdk.Result{Tags: types.TransferTags(user2, user1, delegatedCoin, types.Delegate)}, result2)
 
 	// make sure the voter's voting power is correct
 	voter, _ := vm.storage.GetVoter(ctx, user1)
@@ -77,7 +79,7 @@ func TestDelegateBasic(t *testing.T) {
 	// let user3 delegate power to user1
 	msg3 := NewDelegateMsg("user3", "user1", coinToString(delegatedCoin))
 	result3 := handler(ctx, msg3)
-	assert.Equal(t, sdk.Result{}, result3)
+	assert.Equal(t, sdk.Result{Tags: types.TransferTags(user3, user1, delegatedCoin, types.Delegate)}, result3)
 
 	// check delegator list is correct
 	delegators, _ := vm.storage.GetAllDelegators(ctx, "user1")
@@ -154,7 +156,7 @@ func TestRevokeBasic(t *testing.T) {
 	// let user3 reovke delegation
 	msg4 := NewDelegatorWithdrawMsg("user3", "user1", coinToString(delegatedCoin))
 	result := handler(ctx, msg4)
-	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, sdk.Result{Tags: types.TransferTags(user1, user3, delegatedCoin, types.DelegationReturnCoin)}, result)
 
 	// make sure user3 won't get coins immediately, but user1 power down immediately
 	voter, _ := vm.storage.GetVoter(ctx, "user1")
@@ -173,7 +175,7 @@ func TestRevokeBasic(t *testing.T) {
 
 	vm.storage.SetReferenceList(ctx, referenceList)
 	result2 := handler(ctx, msg5)
-	assert.Equal(t, sdk.Result{}, result2)
+	assert.Equal(t, sdk.Result{Tags: types.CoinTags(user1, voteParam.MinStakeIn, types.VoteReturnCoin)}, result2)
 
 	// make sure user2 wont get coins immediately, and delegatin was deleted
 	acc1Balance, _ := am.GetSavingFromBank(ctx, user1)
@@ -228,7 +230,7 @@ func TestVoterWithdraw(t *testing.T) {
 
 	msg3 := NewStakeOutMsg("user1", coinToString(withdraw))
 	result3 := handler(ctx, msg3)
-	assert.Equal(t, sdk.Result{}, result3)
+	assert.Equal(t, sdk.Result{Tags: types.CoinTags("user1", withdraw, types.VoteReturnCoin)}, result3)
 
 	linoStat, _ = gs.GetLinoStakeStat(ctx, day)
 
@@ -284,7 +286,7 @@ func TestDelegatorWithdraw(t *testing.T) {
 			delegator:      user2,
 			voter:          user1,
 			withdraw:       delegatedCoin.Minus(delta),
-			expectedResult: sdk.Result{},
+			expectedResult: sdk.Result{Tags: types.TransferTags(user1, user2, delegatedCoin.Minus(delta), types.DelegationReturnCoin)},
 		},
 	}
 
@@ -292,7 +294,7 @@ func TestDelegatorWithdraw(t *testing.T) {
 		if tc.addDelegation {
 			msg := NewDelegateMsg(string(tc.delegator), string(tc.voter), coinToString(tc.delegatedCoin))
 			res := handler(ctx, msg)
-			if !assert.Equal(t, sdk.Result{}, res) {
+			if !assert.Equal(t, sdk.Result{Tags: types.TransferTags(tc.delegator, tc.voter, tc.delegatedCoin, types.Delegate)}, res) {
 				t.Errorf("failed to add delegation")
 			}
 		}
@@ -304,6 +306,149 @@ func TestDelegatorWithdraw(t *testing.T) {
 	}
 }
 
+func TestRedelegate(t *testing.T) {
+	ctx, am, vm, gm := setupTest(t, 0)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(1000, 0)})
+	minBalance := types.NewCoinFromInt64(5000 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance)
+	user2 := createTestAccount(ctx, am, "user2", minBalance)
+	user3 := createTestAccount(ctx, am, "user3", minBalance)
+	handler := NewHandler(vm, am, &gm)
+	param, _ := vm.paramHolder.GetVoteParam(ctx)
+	minStakeIn := param.MinStakeIn
+	delegatedCoin := minStakeIn.Plus(minStakeIn).Plus(minStakeIn)
+	delta := types.NewCoinFromInt64(1 * types.Decimals)
+
+	vm.AddVoter(ctx, user1, param.MinStakeIn)
+	vm.AddVoter(ctx, user3, param.MinStakeIn)
+	msg := NewDelegateMsg(string(user2), string(user1), coinToString(delegatedCoin))
+	res := handler(ctx, msg)
+	assert.Equal(t, sdk.Result{Tags: types.TransferTags(user2, user1, delegatedCoin, types.Delegate)}, res)
+
+	testCases := []struct {
+		testName       string
+		fromVoter      types.AccountKey
+		toVoter        types.AccountKey
+		amount         types.Coin
+		blockTime      int64
+		expectedResult sdk.Result
+	}{
+		{
+			testName:       "can't redelegate delegatedCoin+delta",
+			fromVoter:      user1,
+			toVoter:        user3,
+			amount:         delegatedCoin.Plus(delta),
+			blockTime:      1000,
+			expectedResult: ErrIllegalWithdraw().Result(),
+		},
+		{
+			testName:       "can't redelegate to account not exist",
+			fromVoter:      user1,
+			toVoter:        types.AccountKey("user4"),
+			amount:         minStakeIn,
+			blockTime:      1000,
+			expectedResult: ErrAccountNotFound().Result(),
+		},
+		{
+			testName:       "can't redelegate less than min stake in",
+			fromVoter:      user1,
+			toVoter:        user3,
+			amount:         minStakeIn.Minus(delta),
+			blockTime:      1000,
+			expectedResult: ErrInsufficientDeposit().Result(),
+		},
+		{
+			testName:       "normal redelegate",
+			fromVoter:      user1,
+			toVoter:        user3,
+			amount:         minStakeIn.Plus(minStakeIn),
+			blockTime:      1000,
+			expectedResult: sdk.Result{Tags: types.TransferTags(user1, user3, minStakeIn.Plus(minStakeIn), types.Redelegate)},
+		},
+		{
+			testName:       "can't redelegate within interval",
+			fromVoter:      user3,
+			toVoter:        user1,
+			amount:         minStakeIn,
+			blockTime:      1000 + param.RedelegateIntervalSec - 1,
+			expectedResult: ErrRedelegateTooOften().Result(),
+		},
+		{
+			testName:       "redelegate after interval",
+			fromVoter:      user3,
+			toVoter:        user1,
+			amount:         minStakeIn,
+			blockTime:      1000 + param.RedelegateIntervalSec,
+			expectedResult: sdk.Result{Tags: types.TransferTags(user3, user1, minStakeIn, types.Redelegate)},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(tc.blockTime, 0)})
+		msg := NewRedelegateMsg(string(user2), string(tc.fromVoter), string(tc.toVoter), coinToString(tc.amount))
+		res := handler(ctx, msg)
+		if !assert.Equal(t, tc.expectedResult, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.expectedResult)
+		}
+	}
+
+	// delegator's stake is unchanged, delegation is moved between voters.
+	delegator, _ := vm.storage.GetVoter(ctx, user2)
+	assert.Equal(t, delegatedCoin, delegator.LinoStake)
+	assert.Equal(t, delegatedCoin, delegator.DelegateToOthers)
+	assert.Equal(t, 1000+param.RedelegateIntervalSec, delegator.LastRedelegateAt)
+	voter1, _ := vm.storage.GetVoter(ctx, user1)
+	assert.Equal(t, minStakeIn.Plus(minStakeIn), voter1.DelegatedPower)
+	voter3, _ := vm.storage.GetVoter(ctx, user3)
+	assert.Equal(t, minStakeIn, voter3.DelegatedPower)
+	delegation, _ := vm.storage.GetDelegation(ctx, user1, user2)
+	assert.Equal(t, minStakeIn.Plus(minStakeIn), delegation.Amount)
+	delegation, _ = vm.storage.GetDelegation(ctx, user3, user2)
+	assert.Equal(t, minStakeIn, delegation.Amount)
+}
+
+func TestSetCommissionAndClaimDelegatorReward(t *testing.T) {
+	ctx, am, vm, gm := setupTest(t, 0)
+	minBalance := types.NewCoinFromInt64(2000 * types.Decimals)
+	user1 := createTestAccount(ctx, am, "user1", minBalance)
+	user2 := createTestAccount(ctx, am, "user2", minBalance)
+	handler := NewHandler(vm, am, &gm)
+	param, _ := vm.paramHolder.GetVoteParam(ctx)
+	delegatedCoin := param.MinStakeIn
+
+	res := handler(ctx, NewSetCommissionMsg(string(user1), "0.2"))
+	assert.Equal(t, ErrVoterNotFound().Result(), res)
+
+	vm.AddVoter(ctx, user1, param.MinStakeIn)
+	msg := NewDelegateMsg(string(user2), string(user1), coinToString(delegatedCoin))
+	res = handler(ctx, msg)
+	assert.Equal(t, sdk.Result{Tags: types.TransferTags(user2, user1, delegatedCoin, types.Delegate)}, res)
+
+	res = handler(ctx, NewSetCommissionMsg(string(user1), "0.2"))
+	assert.Equal(t, sdk.Result{Tags: types.UsernameTags(user1)}, res)
+	rate, err := vm.GetCommissionRate(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, sdk.NewDecWithPrec(2, 1), rate)
+
+	// user2 is the only delegator and gets all delegator reward
+	err = vm.AddDelegatorReward(ctx, user1, c500)
+	assert.Nil(t, err)
+	reward := c500
+	rewards, err := vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: user1, Amount: reward}}, rewards)
+
+	saving, _ := am.GetSavingFromBank(ctx, user2)
+	res = handler(ctx, NewClaimInterestMsg(string(user2)))
+	assert.Equal(t, sdk.Result{Tags: types.CoinTags(user2, reward, types.ClaimInterest)}, res)
+	newSaving, _ := am.GetSavingFromBank(ctx, user2)
+	assert.Equal(t, saving.Plus(reward), newSaving)
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rewards))
+	assert.True(t, rewards[0].Amount.IsZero())
+}
+
 func TestAddFrozenMoney(t *testing.T) {
 	ctx, am, vm, gm := setupTest(t, 0)
 	vm.InitGenesis(ctx)
@@ -383,7 +528,7 @@ func TestDeleteVoteBasic(t *testing.T) {
 	handler(ctx, depositMsg)
 
 	// add vote
-	_ = vm.AddVote(ctx, proposalID1, user2, true)
+	_, _ = vm.AddVote(ctx, proposalID1, user2, types.VoteOptionYes)
 
 	voteList, _ := vm.storage.GetAllVotes(ctx, proposalID1)
 	assert.Equal(t, user2, voteList[0].Voter)
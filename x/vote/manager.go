@@ -9,6 +9,20 @@ import (
 	"github.com/lino-network/lino/x/vote/types"
 )
 
+// PendingReward - delegator reward from a voter not yet settled to delegator's interest
+type PendingReward struct {
+	Voter  linotypes.AccountKey `json:"voter"`
+	Amount linotypes.Coin       `json:"amount"`
+}
+
+// PowerOverride - delegated power taken back from the vote of a voter
+// because the delegator voted on its own
+type PowerOverride struct {
+	Voter  linotypes.AccountKey `json:"voter"`
+	Option linotypes.VoteOption `json:"option"`
+	Amount linotypes.Coin       `json:"amount"`
+}
+
 // VoteManager - vote manager
 type VoteManager struct {
 	storage     model.VoteStorage
@@ -88,6 +102,22 @@ func (vm VoteManager) IsLegalDelegatorWithdraw(
 	return delegation.Amount.IsGTE(coin) && coin.IsPositive()
 }
 
+// IsRedelegateTooOften - check if delegator redelegated within the redelegate interval
+func (vm VoteManager) IsRedelegateTooOften(ctx sdk.Context, delegatorName linotypes.AccountKey) bool {
+	delegator, err := vm.storage.GetVoter(ctx, delegatorName)
+	if err != nil {
+		return false
+	}
+	param, err := vm.paramHolder.GetVoteParam(ctx)
+	if err != nil {
+		return false
+	}
+	if delegator.LastRedelegateAt == 0 {
+		return false
+	}
+	return ctx.BlockHeader().Time.Unix()-delegator.LastRedelegateAt < param.RedelegateIntervalSec
+}
+
 // CanBecomeValidator - check if vote deposit meet requirement or not
 func (vm VoteManager) CanBecomeValidator(ctx sdk.Context, username linotypes.AccountKey) bool {
 	voter, err := vm.storage.GetVoter(ctx, username)
@@ -102,28 +132,103 @@ func (vm VoteManager) CanBecomeValidator(ctx sdk.Context, username linotypes.Acc
 	return voter.LinoStake.IsGTE(param.ValidatorMinVotingDeposit)
 }
 
-// AddVote - voter vote for a proposal
-func (vm VoteManager) AddVote(ctx sdk.Context, proposalID linotypes.ProposalKey, voter linotypes.AccountKey, res bool) sdk.Error {
+// AddVote - voter vote for a proposal. Since BlockchainUpgrade1Update13Height, a delegator
+// voting on its own overrides the voters it delegates to, its delegations are counted with
+// its own vote instead. Power taken back from votes already cast is returned as overrides.
+func (vm VoteManager) AddVote(
+	ctx sdk.Context, proposalID linotypes.ProposalKey, voter linotypes.AccountKey,
+	option linotypes.VoteOption) ([]PowerOverride, sdk.Error) {
 	// check if the vote exist
 	if vm.DoesVoteExist(ctx, proposalID, voter) {
-		return ErrVoteAlreadyExist()
+		return nil, ErrVoteAlreadyExist()
 	}
 
-	votingPower, err := vm.GetVotingPower(ctx, voter)
+	if ctx.BlockHeader().Height < linotypes.BlockchainUpgrade1Update13Height {
+		votingPower, err := vm.GetVotingPower(ctx, voter)
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.setVote(ctx, proposalID, voter, option, votingPower); err != nil {
+			return nil, err
+		}
+		return []PowerOverride{}, nil
+	}
+
+	votingPower, err := vm.GetSnapshotVotingPower(ctx, proposalID, voter)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	// delegators who voted already have taken back their delegations
+	votes, err := vm.storage.GetAllVotes(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range votes {
+		amount, err := vm.getSnapshotDelegation(ctx, proposalID, voter, v.Voter)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsGT(votingPower) {
+			amount = votingPower
+		}
+		votingPower = votingPower.Minus(amount)
+	}
+
+	// take back delegations from voters
+	overrides := []PowerOverride{}
+	delegatees, err := vm.getSnapshotDelegatees(ctx, proposalID, voter)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegatee := range delegatees {
+		amount, err := vm.getSnapshotDelegation(ctx, proposalID, delegatee, voter)
+		if err != nil {
+			return nil, err
+		}
+		if !amount.IsPositive() {
+			continue
+		}
+		votingPower = votingPower.Plus(amount)
+		if !vm.DoesVoteExist(ctx, proposalID, delegatee) {
+			continue
+		}
+		delegateeVote, err := vm.storage.GetVote(ctx, proposalID, delegatee)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsGT(delegateeVote.VotingPower) {
+			amount = delegateeVote.VotingPower
+		}
+		delegateeVote.VotingPower = delegateeVote.VotingPower.Minus(amount)
+		if err := vm.storage.SetVote(ctx, proposalID, delegatee, delegateeVote); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, PowerOverride{
+			Voter:  delegatee,
+			Option: delegateeVote.GetOption(),
+			Amount: amount,
+		})
 	}
 
+	if err := vm.setVote(ctx, proposalID, voter, option, votingPower); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func (vm VoteManager) setVote(
+	ctx sdk.Context, proposalID linotypes.ProposalKey, voter linotypes.AccountKey,
+	option linotypes.VoteOption, votingPower linotypes.Coin) sdk.Error {
 	vote := model.Vote{
 		Voter:       voter,
-		Result:      res,
+		Result:      option == linotypes.VoteOptionYes,
 		VotingPower: votingPower,
 	}
-
-	if err := vm.storage.SetVote(ctx, proposalID, voter, &vote); err != nil {
-		return err
+	// yes and no are kept in result only, votes are stored as before abstain and veto.
+	if option == linotypes.VoteOptionAbstain || option == linotypes.VoteOptionVeto {
+		vote.Option = option
 	}
-	return nil
+	return vm.storage.SetVote(ctx, proposalID, voter, &vote)
 }
 
 // GetVote - get vote detail based on voter and proposal ID
@@ -136,6 +241,17 @@ func (vm VoteManager) AddDelegation(ctx sdk.Context, voterName linotypes.Account
 	var delegation *model.Delegation
 	var err sdk.Error
 
+	// settle reward of previous delegation before the amount changes
+	if err := vm.SettleDelegatorReward(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
+	if err := vm.snapshotVotingPower(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
+	if err := vm.snapshotDelegation(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
+
 	if !vm.DoesDelegationExist(ctx, voterName, delegatorName) {
 		delegation = &model.Delegation{
 			Delegator: delegatorName,
@@ -184,6 +300,9 @@ func (vm VoteManager) AddDelegation(ctx sdk.Context, voterName linotypes.Account
 
 // AddVoter - add voter
 func (vm VoteManager) AddVoter(ctx sdk.Context, username linotypes.AccountKey, coin linotypes.Coin) sdk.Error {
+	if err := vm.snapshotVotingPower(ctx, username); err != nil {
+		return err
+	}
 	voter := &model.Voter{
 		Username:          username,
 		LinoStake:         coin,
@@ -198,6 +317,9 @@ func (vm VoteManager) AddVoter(ctx sdk.Context, username linotypes.AccountKey, c
 
 // AddLinoStake - add lino power
 func (vm VoteManager) AddLinoStake(ctx sdk.Context, username linotypes.AccountKey, coin linotypes.Coin) sdk.Error {
+	if err := vm.snapshotVotingPower(ctx, username); err != nil {
+		return err
+	}
 	voter, err := vm.storage.GetVoter(ctx, username)
 	if err != nil {
 		return err
@@ -215,6 +337,9 @@ func (vm VoteManager) MinusLinoStake(ctx sdk.Context, username linotypes.Account
 	if coin.IsZero() {
 		return ErrInvalidCoin()
 	}
+	if err := vm.snapshotVotingPower(ctx, username); err != nil {
+		return err
+	}
 	voter, err := vm.storage.GetVoter(ctx, username)
 	if err != nil {
 		return err
@@ -233,6 +358,16 @@ func (vm VoteManager) DelegatorWithdraw(
 	if coin.IsZero() {
 		return ErrInvalidCoin()
 	}
+	// settle reward of delegation before the amount changes
+	if err := vm.SettleDelegatorReward(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
+	if err := vm.snapshotVotingPower(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
+	if err := vm.snapshotDelegation(ctx, voterName, delegatorName); err != nil {
+		return err
+	}
 	// change voter's delegated power
 	voter, err := vm.storage.GetVoter(ctx, voterName)
 	if err != nil {
@@ -264,6 +399,9 @@ func (vm VoteManager) DelegatorWithdraw(
 		if err := vm.storage.DeleteDelegation(ctx, voterName, delegatorName); err != nil {
 			return err
 		}
+		if err := vm.storage.DeleteRewardSnapshot(ctx, voterName, delegatorName); err != nil {
+			return err
+		}
 	} else {
 		vm.storage.SetDelegation(ctx, voterName, delegatorName, delegation)
 	}
@@ -271,6 +409,27 @@ func (vm VoteManager) DelegatorWithdraw(
 	return nil
 }
 
+// Redelegate - move delegation from one voter to another, delegator's stake is unchanged.
+// caller should check if the redelegation is legal.
+func (vm VoteManager) Redelegate(
+	ctx sdk.Context, delegatorName, fromVoter, toVoter linotypes.AccountKey, coin linotypes.Coin) sdk.Error {
+	if err := vm.DelegatorWithdraw(ctx, fromVoter, delegatorName, coin); err != nil {
+		return err
+	}
+	if err := vm.AddDelegation(ctx, toVoter, delegatorName, coin); err != nil {
+		return err
+	}
+	delegator, err := vm.storage.GetVoter(ctx, delegatorName)
+	if err != nil {
+		return err
+	}
+	delegator.LastRedelegateAt = ctx.BlockHeader().Time.Unix()
+	if err := vm.storage.SetVoter(ctx, delegatorName, delegator); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ClaimInterest - add lino power interst to user balance
 func (vm VoteManager) ClaimInterest(
 	ctx sdk.Context, username linotypes.AccountKey) (linotypes.Coin, sdk.Error) {
@@ -286,7 +445,8 @@ func (vm VoteManager) ClaimInterest(
 	return claimedInterest, nil
 }
 
-// AddInterest - add interst
+// AddInterest - add interst, interest is computed on voter's own LinoStake only, delegators
+// earn interest on their own LinoStake which includes the coins they delegate.
 func (vm VoteManager) AddInterest(
 	ctx sdk.Context, username linotypes.AccountKey, interest linotypes.Coin) sdk.Error {
 	voter, err := vm.storage.GetVoter(ctx, username)
@@ -300,6 +460,283 @@ func (vm VoteManager) AddInterest(
 	return nil
 }
 
+// AddDelegatorReward - distribute reward to delegators of a voter in proportion to delegations,
+// the reward is settled to delegators' interest.
+func (vm VoteManager) AddDelegatorReward(
+	ctx sdk.Context, username linotypes.AccountKey, reward linotypes.Coin) sdk.Error {
+	voter, err := vm.storage.GetVoter(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !voter.DelegatedPower.IsPositive() {
+		return ErrNoDelegatedPower()
+	}
+	distribution, err := vm.getDistribution(ctx, username)
+	if err != nil {
+		return err
+	}
+	distribution.RewardPerPower = distribution.RewardPerPower.Add(
+		reward.ToDec().Quo(voter.DelegatedPower.ToDec()))
+	distribution.RewardPool = distribution.RewardPool.Plus(reward)
+	return vm.storage.SetDistribution(ctx, username, distribution)
+}
+
+// SetCommissionRate - set the share of delegators' validator inflation voter keeps.
+func (vm VoteManager) SetCommissionRate(
+	ctx sdk.Context, username linotypes.AccountKey, rate sdk.Dec) sdk.Error {
+	if !vm.DoesVoterExist(ctx, username) {
+		return ErrVoterNotFound()
+	}
+	distribution, err := vm.getDistribution(ctx, username)
+	if err != nil {
+		return err
+	}
+	distribution.CommissionRate = rate
+	return vm.storage.SetDistribution(ctx, username, distribution)
+}
+
+// GetCommissionRate - get the share of delegators' validator inflation voter keeps
+func (vm VoteManager) GetCommissionRate(ctx sdk.Context, username linotypes.AccountKey) (sdk.Dec, sdk.Error) {
+	distribution, err := vm.getDistribution(ctx, username)
+	if err != nil {
+		return sdk.ZeroDec(), err
+	}
+	return distribution.CommissionRate, nil
+}
+
+// SettleDelegatorReward - move pending reward of a delegation to delegator's interest.
+func (vm VoteManager) SettleDelegatorReward(
+	ctx sdk.Context, voterName, delegatorName linotypes.AccountKey) sdk.Error {
+	distribution, err := vm.getDistribution(ctx, voterName)
+	if err != nil {
+		return err
+	}
+	reward, err := vm.pendingDelegatorReward(ctx, voterName, delegatorName, distribution)
+	if err != nil {
+		return err
+	}
+	if reward.IsPositive() {
+		delegator, err := vm.storage.GetVoter(ctx, delegatorName)
+		if err != nil {
+			return err
+		}
+		delegator.Interest = delegator.Interest.Plus(reward)
+		if err := vm.storage.SetVoter(ctx, delegatorName, delegator); err != nil {
+			return err
+		}
+		distribution.RewardPool = distribution.RewardPool.Minus(reward)
+		if err := vm.storage.SetDistribution(ctx, voterName, distribution); err != nil {
+			return err
+		}
+	}
+	return vm.storage.SetRewardSnapshot(ctx, voterName, delegatorName, &model.RewardSnapshot{
+		RewardPerPower: distribution.RewardPerPower,
+	})
+}
+
+// GetPendingDelegatorRewards - get reward not yet settled from all voters delegator delegates to
+func (vm VoteManager) GetPendingDelegatorRewards(
+	ctx sdk.Context, delegatorName linotypes.AccountKey) ([]PendingReward, sdk.Error) {
+	voters, err := vm.storage.GetAllDelegatees(ctx, delegatorName)
+	if err != nil {
+		return nil, err
+	}
+	rewards := []PendingReward{}
+	for _, voter := range voters {
+		distribution, err := vm.getDistribution(ctx, voter)
+		if err != nil {
+			return nil, err
+		}
+		reward, err := vm.pendingDelegatorReward(ctx, voter, delegatorName, distribution)
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, PendingReward{Voter: voter, Amount: reward})
+	}
+	return rewards, nil
+}
+
+// GetAllDelegatees - get all voters a delegator delegates to
+func (vm VoteManager) GetAllDelegatees(ctx sdk.Context, delegatorName linotypes.AccountKey) ([]linotypes.AccountKey, sdk.Error) {
+	return vm.storage.GetAllDelegatees(ctx, delegatorName)
+}
+
+// voter keeps all validator inflation if commission rate is never set.
+func (vm VoteManager) getDistribution(ctx sdk.Context, voterName linotypes.AccountKey) (*model.Distribution, sdk.Error) {
+	if !vm.storage.DoesDistributionExist(ctx, voterName) {
+		return &model.Distribution{
+			CommissionRate: sdk.OneDec(),
+			RewardPerPower: sdk.ZeroDec(),
+			RewardPool:     linotypes.NewCoinFromInt64(0),
+		}, nil
+	}
+	return vm.storage.GetDistribution(ctx, voterName)
+}
+
+// pendingDelegatorReward - delegation amount * reward per power accumulated since last settlement,
+// never more than the reward pool.
+func (vm VoteManager) pendingDelegatorReward(
+	ctx sdk.Context, voterName, delegatorName linotypes.AccountKey,
+	distribution *model.Distribution) (linotypes.Coin, sdk.Error) {
+	if !vm.DoesDelegationExist(ctx, voterName, delegatorName) {
+		return linotypes.NewCoinFromInt64(0), nil
+	}
+	delegation, err := vm.storage.GetDelegation(ctx, voterName, delegatorName)
+	if err != nil {
+		return linotypes.NewCoinFromInt64(0), err
+	}
+	snapshot := sdk.ZeroDec()
+	if vm.storage.DoesRewardSnapshotExist(ctx, voterName, delegatorName) {
+		rs, err := vm.storage.GetRewardSnapshot(ctx, voterName, delegatorName)
+		if err != nil {
+			return linotypes.NewCoinFromInt64(0), err
+		}
+		snapshot = rs.RewardPerPower
+	}
+	reward := truncateDecToCoin(delegation.Amount.ToDec().Mul(distribution.RewardPerPower.Sub(snapshot)))
+	if reward.IsGT(distribution.RewardPool) {
+		reward = distribution.RewardPool
+	}
+	return reward, nil
+}
+
+func truncateDecToCoin(dec sdk.Dec) linotypes.Coin {
+	return linotypes.NewCoinFromBigInt(dec.TruncateInt().BigInt())
+}
+
+// StartVotingPowerSnapshot - fix voting power of all voters for a newly created proposal,
+// power of a voter is recorded lazily before it changes for the first time.
+func (vm VoteManager) StartVotingPowerSnapshot(ctx sdk.Context, proposalID linotypes.ProposalKey) sdk.Error {
+	if ctx.BlockHeader().Height < linotypes.BlockchainUpgrade1Update13Height {
+		return nil
+	}
+	return vm.storage.SetProposalSnapshot(ctx, proposalID, &model.ProposalSnapshot{
+		CreatedHeight: ctx.BlockHeight(),
+	})
+}
+
+// EndVotingPowerSnapshot - remove voting power snapshot of a decided proposal
+func (vm VoteManager) EndVotingPowerSnapshot(ctx sdk.Context, proposalID linotypes.ProposalKey) sdk.Error {
+	return vm.storage.DeleteProposalSnapshot(ctx, proposalID)
+}
+
+// GetSnapshotVotingPower - get voter's power at the creation of proposal,
+// current voting power if proposal was created without snapshot.
+func (vm VoteManager) GetSnapshotVotingPower(
+	ctx sdk.Context, proposalID linotypes.ProposalKey, voterName linotypes.AccountKey) (linotypes.Coin, sdk.Error) {
+	if vm.storage.DoesProposalSnapshotExist(ctx, proposalID) &&
+		vm.storage.DoesVotingPowerSnapshotExist(ctx, proposalID, voterName) {
+		snapshot, err := vm.storage.GetVotingPowerSnapshot(ctx, proposalID, voterName)
+		if err != nil {
+			return linotypes.Coin{}, err
+		}
+		return snapshot.VotingPower, nil
+	}
+	return vm.GetVotingPower(ctx, voterName)
+}
+
+// snapshotVotingPower - record current power of users in snapshots of all ongoing proposals
+// that have not recorded it, must be called before users' power changes.
+func (vm VoteManager) snapshotVotingPower(ctx sdk.Context, usernames ...linotypes.AccountKey) sdk.Error {
+	proposalIDs := vm.storage.GetAllProposalSnapshots(ctx)
+	if len(proposalIDs) == 0 {
+		return nil
+	}
+	for _, username := range usernames {
+		power := linotypes.NewCoinFromInt64(0)
+		if vm.DoesVoterExist(ctx, username) {
+			var err sdk.Error
+			power, err = vm.GetVotingPower(ctx, username)
+			if err != nil {
+				return err
+			}
+		}
+		for _, proposalID := range proposalIDs {
+			if vm.storage.DoesVotingPowerSnapshotExist(ctx, proposalID, username) {
+				continue
+			}
+			if err := vm.storage.SetVotingPowerSnapshot(
+				ctx, proposalID, username, &model.VotingPowerSnapshot{VotingPower: power}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotDelegation - record current amount of delegation in snapshots of all ongoing proposals
+// that have not recorded it, must be called before the delegation changes.
+func (vm VoteManager) snapshotDelegation(ctx sdk.Context, voterName, delegatorName linotypes.AccountKey) sdk.Error {
+	proposalIDs := vm.storage.GetAllProposalSnapshots(ctx)
+	if len(proposalIDs) == 0 {
+		return nil
+	}
+	amount := linotypes.NewCoinFromInt64(0)
+	if vm.DoesDelegationExist(ctx, voterName, delegatorName) {
+		delegation, err := vm.storage.GetDelegation(ctx, voterName, delegatorName)
+		if err != nil {
+			return err
+		}
+		amount = delegation.Amount
+	}
+	for _, proposalID := range proposalIDs {
+		if vm.storage.DoesDelegationSnapshotExist(ctx, proposalID, voterName, delegatorName) {
+			continue
+		}
+		if err := vm.storage.SetDelegationSnapshot(
+			ctx, proposalID, voterName, delegatorName, &model.DelegationSnapshot{Amount: amount}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSnapshotDelegation - amount of delegation at the creation of proposal,
+// current amount if proposal was created without snapshot.
+func (vm VoteManager) getSnapshotDelegation(
+	ctx sdk.Context, proposalID linotypes.ProposalKey,
+	voterName, delegatorName linotypes.AccountKey) (linotypes.Coin, sdk.Error) {
+	if vm.storage.DoesProposalSnapshotExist(ctx, proposalID) &&
+		vm.storage.DoesDelegationSnapshotExist(ctx, proposalID, voterName, delegatorName) {
+		snapshot, err := vm.storage.GetDelegationSnapshot(ctx, proposalID, voterName, delegatorName)
+		if err != nil {
+			return linotypes.NewCoinFromInt64(0), err
+		}
+		return snapshot.Amount, nil
+	}
+	if !vm.DoesDelegationExist(ctx, voterName, delegatorName) {
+		return linotypes.NewCoinFromInt64(0), nil
+	}
+	delegation, err := vm.storage.GetDelegation(ctx, voterName, delegatorName)
+	if err != nil {
+		return linotypes.NewCoinFromInt64(0), err
+	}
+	return delegation.Amount, nil
+}
+
+// getSnapshotDelegatees - voters delegator delegates to now or at the creation of proposal
+func (vm VoteManager) getSnapshotDelegatees(
+	ctx sdk.Context, proposalID linotypes.ProposalKey, delegatorName linotypes.AccountKey) ([]linotypes.AccountKey, sdk.Error) {
+	delegatees, err := vm.storage.GetAllDelegatees(ctx, delegatorName)
+	if err != nil {
+		return nil, err
+	}
+	if !vm.storage.DoesProposalSnapshotExist(ctx, proposalID) {
+		return delegatees, nil
+	}
+	seen := make(map[linotypes.AccountKey]bool)
+	for _, delegatee := range delegatees {
+		seen[delegatee] = true
+	}
+	for _, delegatee := range vm.storage.GetSnapshotDelegatees(ctx, proposalID, delegatorName) {
+		if !seen[delegatee] {
+			seen[delegatee] = true
+			delegatees = append(delegatees, delegatee)
+		}
+	}
+	return delegatees, nil
+}
+
 // GetVotingPower - get voter voting power
 func (vm VoteManager) GetVotingPower(ctx sdk.Context, voterName linotypes.AccountKey) (linotypes.Coin, sdk.Error) {
 	voter, err := vm.storage.GetVoter(ctx, voterName)
@@ -310,6 +747,15 @@ func (vm VoteManager) GetVotingPower(ctx sdk.Context, voterName linotypes.Accoun
 	return res, nil
 }
 
+// GetDelegatedPower - get power delegated to voter by delegators
+func (vm VoteManager) GetDelegatedPower(ctx sdk.Context, voterName linotypes.AccountKey) (linotypes.Coin, sdk.Error) {
+	voter, err := vm.storage.GetVoter(ctx, voterName)
+	if err != nil {
+		return linotypes.Coin{}, err
+	}
+	return voter.DelegatedPower, nil
+}
+
 // GetPenaltyList - get penalty list if voter is also validator doesn't vote
 func (vm VoteManager) GetPenaltyList(
 	ctx sdk.Context, proposalID linotypes.ProposalKey, proposalType linotypes.ProposalType,
@@ -393,6 +839,29 @@ func (vm VoteManager) GetVoterDuty(ctx sdk.Context, accKey linotypes.AccountKey)
 	panic(linotypes.NewError(linotypes.CodeUnimplementedError, "voter duty unimplemented"))
 }
 
+// GetTotalLinoStake - get sum of lino stake and unclaimed interest of all voters,
+// delegations are included in delegators' lino stake, unsettled delegator reward
+// is included in voters' reward pool.
+func (vm VoteManager) GetTotalLinoStake(ctx sdk.Context) (linotypes.Coin, sdk.Error) {
+	total := linotypes.NewCoinFromInt64(0)
+	if err := vm.storage.IterateVoters(ctx, func(voter *model.Voter) {
+		total = total.Plus(voter.LinoStake).Plus(voter.Interest)
+	}); err != nil {
+		return linotypes.NewCoinFromInt64(0), err
+	}
+	if err := vm.storage.IterateDistributions(ctx, func(distribution *model.Distribution) {
+		total = total.Plus(distribution.RewardPool)
+	}); err != nil {
+		return linotypes.NewCoinFromInt64(0), err
+	}
+	return total, nil
+}
+
+// RegisterInvariants - register coins held by voters
+func (vm VoteManager) RegisterInvariants(ir *linotypes.InvariantRegistry) {
+	ir.RegisterSupplyHolding(ModuleName, "stake", vm.GetTotalLinoStake)
+}
+
 // Export storage state.
 func (vm VoteManager) Export(ctx sdk.Context) *model.VoterTables {
 	return vm.storage.Export(ctx)
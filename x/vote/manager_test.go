@@ -122,6 +122,273 @@ func TestAddAndClaimInterest(t *testing.T) {
 
 }
 
+func TestDelegatorReward(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, 0)
+	voter := types.AccountKey("voter")
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	interest := types.NewCoinFromInt64(1000)
+
+	assert.Equal(t, ErrVoterNotFound(), vm.SetCommissionRate(ctx, voter, sdk.NewDecWithPrec(2, 1)))
+
+	assert.Nil(t, vm.AddVoter(ctx, voter, types.NewCoinFromInt64(600)))
+	assert.Nil(t, vm.AddVoter(ctx, user1, types.NewCoinFromInt64(0)))
+	assert.Nil(t, vm.AddVoter(ctx, user2, types.NewCoinFromInt64(0)))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user1, types.NewCoinFromInt64(100)))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user2, types.NewCoinFromInt64(300)))
+
+	// voter keeps all validator inflation by default
+	rate, err := vm.GetCommissionRate(ctx, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, sdk.OneDec(), rate)
+
+	// interest is earned on voter's own stake, not shared with delegators
+	assert.Nil(t, vm.SetCommissionRate(ctx, voter, sdk.NewDecWithPrec(2, 1)))
+	rate, err = vm.GetCommissionRate(ctx, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, sdk.NewDecWithPrec(2, 1), rate)
+	assert.Nil(t, vm.AddInterest(ctx, voter, interest))
+	v, err := vm.storage.GetVoter(ctx, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, interest, v.Interest)
+	rewards, err := vm.GetPendingDelegatorRewards(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rewards))
+	assert.Equal(t, voter, rewards[0].Voter)
+	assert.True(t, rewards[0].Amount.IsZero())
+
+	// 1 per delegated power
+	assert.Nil(t, vm.AddDelegatorReward(ctx, voter, types.NewCoinFromInt64(400)))
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(100)}}, rewards)
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(300)}}, rewards)
+
+	// delegation change settles pending reward to delegator's interest
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user1, types.NewCoinFromInt64(600)))
+	d1, err := vm.storage.GetVoter(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(100), d1.Interest)
+
+	// 0.5 per delegated power
+	assert.Nil(t, vm.AddDelegatorReward(ctx, voter, types.NewCoinFromInt64(500)))
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(350)}}, rewards)
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(450)}}, rewards)
+
+	assert.Nil(t, vm.DelegatorWithdraw(ctx, voter, user2, types.NewCoinFromInt64(300)))
+	d2, err := vm.storage.GetVoter(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(450), d2.Interest)
+	assert.False(t, vm.storage.DoesRewardSnapshotExist(ctx, voter, user2))
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{}, rewards)
+
+	// unsettled reward stays in reward pool
+	distribution, err := vm.storage.GetDistribution(ctx, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(350), distribution.RewardPool)
+	total, err := vm.GetTotalLinoStake(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(600+1000+100+450+350), total)
+
+	assert.Nil(t, vm.SettleDelegatorReward(ctx, voter, user1))
+	d1, err = vm.storage.GetVoter(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(450), d1.Interest)
+	distribution, err = vm.storage.GetDistribution(ctx, voter)
+	assert.Nil(t, err)
+	assert.True(t, distribution.RewardPool.IsZero())
+}
+
+func TestAddDelegatorReward(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, 0)
+	voter := types.AccountKey("voter")
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	reward := types.NewCoinFromInt64(1000)
+
+	assert.Equal(t, ErrVoterNotFound(), vm.AddDelegatorReward(ctx, voter, reward))
+	assert.Nil(t, vm.AddVoter(ctx, voter, c100))
+	assert.Equal(t, ErrNoDelegatedPower(), vm.AddDelegatorReward(ctx, voter, reward))
+
+	assert.Nil(t, vm.AddVoter(ctx, user1, types.NewCoinFromInt64(0)))
+	assert.Nil(t, vm.AddVoter(ctx, user2, types.NewCoinFromInt64(0)))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user1, types.NewCoinFromInt64(100)))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user2, types.NewCoinFromInt64(400)))
+	delegatedPower, err := vm.GetDelegatedPower(ctx, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(500), delegatedPower)
+
+	// reward goes to delegators only, regardless of voter's commission rate
+	assert.Nil(t, vm.AddDelegatorReward(ctx, voter, reward))
+	v, err := vm.storage.GetVoter(ctx, voter)
+	assert.Nil(t, err)
+	assert.True(t, v.Interest.IsZero())
+	rewards, err := vm.GetPendingDelegatorRewards(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(200)}}, rewards)
+	rewards, err = vm.GetPendingDelegatorRewards(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []PendingReward{{Voter: voter, Amount: types.NewCoinFromInt64(800)}}, rewards)
+	total, err := vm.GetTotalLinoStake(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, c100.Plus(reward), total)
+}
+
+func TestSnapshotVotingPower(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, types.BlockchainUpgrade1Update13Height)
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	proposalID := types.ProposalKey("1")
+
+	assert.Nil(t, vm.AddVoter(ctx, user1, c500))
+	assert.Nil(t, vm.AddVoter(ctx, user2, c500))
+	assert.Nil(t, vm.AddDelegation(ctx, user1, user2, c100))
+	assert.Nil(t, vm.StartVotingPowerSnapshot(ctx, proposalID))
+
+	// power without change since proposal creation is read from voter
+	assert.False(t, vm.storage.DoesVotingPowerSnapshotExist(ctx, proposalID, user1))
+	power, err := vm.GetSnapshotVotingPower(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Plus(c100), power)
+
+	assert.Nil(t, vm.DelegatorWithdraw(ctx, user1, user2, c100))
+	assert.Nil(t, vm.MinusLinoStake(ctx, user2, c100))
+	assert.Nil(t, vm.AddDelegation(ctx, user1, user2, c100))
+	power, err = vm.GetSnapshotVotingPower(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Plus(c100), power)
+	power, err = vm.GetSnapshotVotingPower(ctx, proposalID, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Minus(c100), power)
+	power, err = vm.GetVotingPower(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Minus(c100).Minus(c100), power)
+
+	overrides, err := vm.AddVote(ctx, proposalID, user1, types.VoteOptionYes)
+	assert.Nil(t, err)
+	assert.Equal(t, []PowerOverride{}, overrides)
+	vote, err := vm.GetVote(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Plus(c100), vote.VotingPower)
+
+	assert.Nil(t, vm.EndVotingPowerSnapshot(ctx, proposalID))
+	assert.False(t, vm.storage.DoesProposalSnapshotExist(ctx, proposalID))
+	assert.False(t, vm.storage.DoesVotingPowerSnapshotExist(ctx, proposalID, user1))
+}
+
+func TestSnapshotVotingPowerBeforeUpgrade(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, types.BlockchainUpgrade1Update13Height-1)
+	user1 := types.AccountKey("user1")
+	proposalID := types.ProposalKey("1")
+
+	assert.Nil(t, vm.AddVoter(ctx, user1, c500))
+	assert.Nil(t, vm.StartVotingPowerSnapshot(ctx, proposalID))
+	assert.False(t, vm.storage.DoesProposalSnapshotExist(ctx, proposalID))
+
+	// vote is cast with current power
+	assert.Nil(t, vm.AddLinoStake(ctx, user1, c100))
+	assert.False(t, vm.storage.DoesVotingPowerSnapshotExist(ctx, proposalID, user1))
+	_, err := vm.AddVote(ctx, proposalID, user1, types.VoteOptionYes)
+	assert.Nil(t, err)
+	vote, err := vm.GetVote(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Plus(c100), vote.VotingPower)
+}
+
+func TestDelegatorVoteOverride(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, types.BlockchainUpgrade1Update13Height)
+	voter := types.AccountKey("voter")
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	proposalID := types.ProposalKey("1")
+
+	assert.Nil(t, vm.AddVoter(ctx, voter, c500))
+	assert.Nil(t, vm.AddVoter(ctx, user1, c100))
+	assert.Nil(t, vm.AddVoter(ctx, user2, c100))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user1, c100))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user2, c100))
+	assert.Nil(t, vm.StartVotingPowerSnapshot(ctx, proposalID))
+
+	testCases := []struct {
+		testName        string
+		voter           types.AccountKey
+		option          types.VoteOption
+		expectPower     types.Coin
+		expectOverrides []PowerOverride
+	}{
+		{
+			testName:        "delegator votes before voter",
+			voter:           user1,
+			option:          types.VoteOptionNo,
+			expectPower:     c100,
+			expectOverrides: []PowerOverride{},
+		},
+		{
+			testName:        "voter's power excludes delegation of delegator voted",
+			voter:           voter,
+			option:          types.VoteOptionYes,
+			expectPower:     c500.Plus(c100),
+			expectOverrides: []PowerOverride{},
+		},
+		{
+			testName:    "delegator abstains after voter",
+			voter:       user2,
+			option:      types.VoteOptionAbstain,
+			expectPower: c100,
+			expectOverrides: []PowerOverride{
+				{Voter: voter, Option: types.VoteOptionYes, Amount: c100},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		overrides, err := vm.AddVote(ctx, proposalID, tc.voter, tc.option)
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, tc.expectOverrides, overrides, tc.testName)
+		vote, err := vm.GetVote(ctx, proposalID, tc.voter)
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, tc.expectPower, vote.VotingPower, tc.testName)
+		assert.Equal(t, tc.option, vote.GetOption(), tc.testName)
+		assert.Equal(t, tc.option == types.VoteOptionYes, vote.Result, tc.testName)
+	}
+
+	vote, err := vm.GetVote(ctx, proposalID, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, c500, vote.VotingPower)
+}
+
+func TestDelegatorVoteOverrideBeforeUpgrade(t *testing.T) {
+	ctx, _, vm, _ := setupTest(t, types.BlockchainUpgrade1Update13Height-1)
+	voter := types.AccountKey("voter")
+	user1 := types.AccountKey("user1")
+	proposalID := types.ProposalKey("1")
+
+	assert.Nil(t, vm.AddVoter(ctx, voter, c500))
+	assert.Nil(t, vm.AddVoter(ctx, user1, c100))
+	assert.Nil(t, vm.AddDelegation(ctx, voter, user1, c100))
+
+	// delegation stays counted in voter's vote
+	overrides, err := vm.AddVote(ctx, proposalID, user1, types.VoteOptionNo)
+	assert.Nil(t, err)
+	assert.Equal(t, []PowerOverride{}, overrides)
+	overrides, err = vm.AddVote(ctx, proposalID, voter, types.VoteOptionYes)
+	assert.Nil(t, err)
+	assert.Equal(t, []PowerOverride{}, overrides)
+	vote, err := vm.GetVote(ctx, proposalID, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, c100, vote.VotingPower)
+	vote, err = vm.GetVote(ctx, proposalID, voter)
+	assert.Nil(t, err)
+	assert.Equal(t, c500.Plus(c100), vote.VotingPower)
+}
+
 func TestIsInValidatorList(t *testing.T) {
 	ctx, am, vm, _ := setupTest(t, 0)
 	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
@@ -66,3 +66,38 @@ func ErrFailedToUnmarshalDelegation(err error) sdk.Error {
 func ErrFailedToUnmarshalReferenceList(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalReferenceList, fmt.Sprintf("failed to unmarshal reference list: %s", err.Error()))
 }
+
+// ErrFailedToMarshalDistribution - error if marshal voter distribution failed
+func ErrFailedToMarshalDistribution(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalDistribution, fmt.Sprintf("failed to marshal distribution: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalDistribution - error if unmarshal voter distribution failed
+func ErrFailedToUnmarshalDistribution(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalDistribution, fmt.Sprintf("failed to unmarshal distribution: %s", err.Error()))
+}
+
+// ErrFailedToMarshalRewardSnapshot - error if marshal delegator reward snapshot failed
+func ErrFailedToMarshalRewardSnapshot(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalRewardSnapshot, fmt.Sprintf("failed to marshal reward snapshot: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalRewardSnapshot - error if unmarshal delegator reward snapshot failed
+func ErrFailedToUnmarshalRewardSnapshot(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalRewardSnapshot, fmt.Sprintf("failed to unmarshal reward snapshot: %s", err.Error()))
+}
+
+// ErrSnapshotNotFound - error if voting power snapshot of proposal is not found in KVStore
+func ErrSnapshotNotFound() sdk.Error {
+	return types.NewError(types.CodeSnapshotNotFound, fmt.Sprintf("voting power snapshot is not found"))
+}
+
+// ErrFailedToMarshalSnapshot - error if marshal voting power snapshot failed
+func ErrFailedToMarshalSnapshot(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalSnapshot, fmt.Sprintf("failed to marshal snapshot: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalSnapshot - error if unmarshal voting power snapshot failed
+func ErrFailedToUnmarshalSnapshot(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalSnapshot, fmt.Sprintf("failed to unmarshal snapshot: %s", err.Error()))
+}
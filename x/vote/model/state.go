@@ -22,11 +22,50 @@ type ReferenceListTable struct {
 	List ReferenceList `json:"list"`
 }
 
+// DistributionRow - pk: username
+type DistributionRow struct {
+	Username     types.AccountKey `json:"username"`
+	Distribution Distribution     `json:"distribution"`
+}
+
+// RewardSnapshotRow - pk: (voter, delegator)
+type RewardSnapshotRow struct {
+	Voter     types.AccountKey `json:"username"`
+	Delegator types.AccountKey `json:"delegator"`
+	Snapshot  RewardSnapshot   `json:"snapshot"`
+}
+
+// ProposalSnapshotRow - pk: proposalID
+type ProposalSnapshotRow struct {
+	ProposalID types.ProposalKey `json:"proposal_id"`
+	Snapshot   ProposalSnapshot  `json:"snapshot"`
+}
+
+// VotingPowerSnapshotRow - pk: (proposalID, voter)
+type VotingPowerSnapshotRow struct {
+	ProposalID types.ProposalKey   `json:"proposal_id"`
+	Voter      types.AccountKey    `json:"voter"`
+	Snapshot   VotingPowerSnapshot `json:"snapshot"`
+}
+
+// DelegationSnapshotRow - pk: (proposalID, delegator, voter)
+type DelegationSnapshotRow struct {
+	ProposalID types.ProposalKey  `json:"proposal_id"`
+	Delegator  types.AccountKey   `json:"delegator"`
+	Voter      types.AccountKey   `json:"voter"`
+	Snapshot   DelegationSnapshot `json:"snapshot"`
+}
+
 // VoterTables - state of voter
 type VoterTables struct {
-	Voters        []VoterRow         `json:"voters"`
-	Delegations   []DelegationRow    `json:"delegations"`
-	ReferenceList ReferenceListTable `json:"reference_list"`
+	Voters               []VoterRow               `json:"voters"`
+	Delegations          []DelegationRow          `json:"delegations"`
+	ReferenceList        ReferenceListTable       `json:"reference_list"`
+	Distributions        []DistributionRow        `json:"distributions"`
+	RewardSnapshots      []RewardSnapshotRow      `json:"reward_snapshots"`
+	ProposalSnapshots    []ProposalSnapshotRow    `json:"proposal_snapshots"`
+	VotingPowerSnapshots []VotingPowerSnapshotRow `json:"voting_power_snapshots"`
+	DelegationSnapshots  []DelegationSnapshotRow  `json:"delegation_snapshots"`
 }
 
 // ToIR - same
@@ -10,11 +10,16 @@ import (
 )
 
 var (
-	delegationSubstore    = []byte{0x00}
-	voterSubstore         = []byte{0x01}
-	voteSubstore          = []byte{0x02}
-	referenceListSubStore = []byte{0x03}
-	delegateeSubStore     = []byte{0x04}
+	delegationSubstore          = []byte{0x00}
+	voterSubstore               = []byte{0x01}
+	voteSubstore                = []byte{0x02}
+	referenceListSubStore       = []byte{0x03}
+	delegateeSubStore           = []byte{0x04}
+	distributionSubStore        = []byte{0x05}
+	rewardSnapshotSubStore      = []byte{0x06}
+	proposalSnapshotSubStore    = []byte{0x07}
+	votingPowerSnapshotSubStore = []byte{0x08}
+	delegationSnapshotSubStore  = []byte{0x09}
 )
 
 // VoteStorage - vote storage
@@ -76,6 +81,21 @@ func (vs VoteStorage) GetVoter(ctx sdk.Context, accKey types.AccountKey) (*Voter
 	return voter, nil
 }
 
+// IterateVoters - call fn on every voter
+func (vs VoteStorage) IterateVoters(ctx sdk.Context, fn func(voter *Voter)) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, voterSubstore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		voter := new(Voter)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), voter); err != nil {
+			return ErrFailedToUnmarshalVoter(err)
+		}
+		fn(voter)
+	}
+	return nil
+}
+
 // SetVoter - set voter to KVStore
 func (vs VoteStorage) SetVoter(ctx sdk.Context, accKey types.AccountKey, voter *Voter) sdk.Error {
 	store := ctx.KVStore(vs.key)
@@ -160,6 +180,246 @@ func (vs VoteStorage) DeleteDelegation(ctx sdk.Context, voter types.AccountKey,
 	return nil
 }
 
+// DoesDistributionExist - check if voter has set distribution in KVStore or not
+func (vs VoteStorage) DoesDistributionExist(ctx sdk.Context, voter types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(getDistributionKey(voter))
+}
+
+// GetDistribution - get voter distribution from KVStore
+func (vs VoteStorage) GetDistribution(ctx sdk.Context, voter types.AccountKey) (*Distribution, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	distributionByte := store.Get(getDistributionKey(voter))
+	if distributionByte == nil {
+		return nil, ErrVoterNotFound()
+	}
+	distribution := new(Distribution)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(distributionByte, distribution); err != nil {
+		return nil, ErrFailedToUnmarshalDistribution(err)
+	}
+	return distribution, nil
+}
+
+// IterateDistributions - call fn on distribution of every voter
+func (vs VoteStorage) IterateDistributions(ctx sdk.Context, fn func(distribution *Distribution)) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	itr := sdk.KVStorePrefixIterator(store, distributionSubStore)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		distribution := new(Distribution)
+		if err := vs.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), distribution); err != nil {
+			return ErrFailedToUnmarshalDistribution(err)
+		}
+		fn(distribution)
+	}
+	return nil
+}
+
+// SetDistribution - set voter distribution to KVStore
+func (vs VoteStorage) SetDistribution(ctx sdk.Context, voter types.AccountKey, distribution *Distribution) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	distributionByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*distribution)
+	if err != nil {
+		return ErrFailedToMarshalDistribution(err)
+	}
+	store.Set(getDistributionKey(voter), distributionByte)
+	return nil
+}
+
+// DoesRewardSnapshotExist - check if delegation reward snapshot exist in KVStore or not
+func (vs VoteStorage) DoesRewardSnapshotExist(ctx sdk.Context, voter, delegator types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(getRewardSnapshotKey(voter, delegator))
+}
+
+// GetRewardSnapshot - get delegation reward snapshot from KVStore
+func (vs VoteStorage) GetRewardSnapshot(ctx sdk.Context, voter, delegator types.AccountKey) (*RewardSnapshot, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	snapshotByte := store.Get(getRewardSnapshotKey(voter, delegator))
+	if snapshotByte == nil {
+		return nil, ErrDelegationNotFound()
+	}
+	snapshot := new(RewardSnapshot)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(snapshotByte, snapshot); err != nil {
+		return nil, ErrFailedToUnmarshalRewardSnapshot(err)
+	}
+	return snapshot, nil
+}
+
+// SetRewardSnapshot - set delegation reward snapshot to KVStore
+func (vs VoteStorage) SetRewardSnapshot(ctx sdk.Context, voter, delegator types.AccountKey, snapshot *RewardSnapshot) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	snapshotByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*snapshot)
+	if err != nil {
+		return ErrFailedToMarshalRewardSnapshot(err)
+	}
+	store.Set(getRewardSnapshotKey(voter, delegator), snapshotByte)
+	return nil
+}
+
+// DeleteRewardSnapshot - delete delegation reward snapshot from KVStore
+func (vs VoteStorage) DeleteRewardSnapshot(ctx sdk.Context, voter, delegator types.AccountKey) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	store.Delete(getRewardSnapshotKey(voter, delegator))
+	return nil
+}
+
+// DoesProposalSnapshotExist - check if voting power snapshot of proposal exist in KVStore or not
+func (vs VoteStorage) DoesProposalSnapshotExist(ctx sdk.Context, proposalID types.ProposalKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(getProposalSnapshotKey(proposalID))
+}
+
+// GetProposalSnapshot - get voting power snapshot of proposal from KVStore
+func (vs VoteStorage) GetProposalSnapshot(ctx sdk.Context, proposalID types.ProposalKey) (*ProposalSnapshot, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	snapshotByte := store.Get(getProposalSnapshotKey(proposalID))
+	if snapshotByte == nil {
+		return nil, ErrSnapshotNotFound()
+	}
+	snapshot := new(ProposalSnapshot)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(snapshotByte, snapshot); err != nil {
+		return nil, ErrFailedToUnmarshalSnapshot(err)
+	}
+	return snapshot, nil
+}
+
+// SetProposalSnapshot - set voting power snapshot of proposal to KVStore
+func (vs VoteStorage) SetProposalSnapshot(ctx sdk.Context, proposalID types.ProposalKey, snapshot *ProposalSnapshot) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	snapshotByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*snapshot)
+	if err != nil {
+		return ErrFailedToMarshalSnapshot(err)
+	}
+	store.Set(getProposalSnapshotKey(proposalID), snapshotByte)
+	return nil
+}
+
+// DeleteProposalSnapshot - delete voting power snapshot of proposal and
+// all voting power recorded in the snapshot from KVStore
+func (vs VoteStorage) DeleteProposalSnapshot(ctx sdk.Context, proposalID types.ProposalKey) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	var keys [][]byte
+	for _, prefix := range [][]byte{
+		getVotingPowerSnapshotPrefix(proposalID), getDelegationSnapshotPrefix(proposalID)} {
+		func() {
+			iterator := store.Iterator(subspace(prefix))
+			defer iterator.Close()
+			for ; iterator.Valid(); iterator.Next() {
+				keys = append(keys, iterator.Key())
+			}
+		}()
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	store.Delete(getProposalSnapshotKey(proposalID))
+	return nil
+}
+
+// GetAllProposalSnapshots - get ID of all proposals with voting power snapshot from KVStore
+func (vs VoteStorage) GetAllProposalSnapshots(ctx sdk.Context) []types.ProposalKey {
+	store := ctx.KVStore(vs.key)
+	iterator := sdk.KVStorePrefixIterator(store, proposalSnapshotSubStore)
+	defer iterator.Close()
+
+	var proposalIDs []types.ProposalKey
+	for ; iterator.Valid(); iterator.Next() {
+		proposalIDs = append(proposalIDs, types.ProposalKey(iterator.Key()[1:]))
+	}
+	return proposalIDs
+}
+
+// DoesVotingPowerSnapshotExist - check if voter's power is recorded in proposal snapshot or not
+func (vs VoteStorage) DoesVotingPowerSnapshotExist(ctx sdk.Context, proposalID types.ProposalKey, voter types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(getVotingPowerSnapshotKey(proposalID, voter))
+}
+
+// GetVotingPowerSnapshot - get voter's power recorded in proposal snapshot from KVStore
+func (vs VoteStorage) GetVotingPowerSnapshot(ctx sdk.Context, proposalID types.ProposalKey, voter types.AccountKey) (*VotingPowerSnapshot, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	snapshotByte := store.Get(getVotingPowerSnapshotKey(proposalID, voter))
+	if snapshotByte == nil {
+		return nil, ErrSnapshotNotFound()
+	}
+	snapshot := new(VotingPowerSnapshot)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(snapshotByte, snapshot); err != nil {
+		return nil, ErrFailedToUnmarshalSnapshot(err)
+	}
+	return snapshot, nil
+}
+
+// SetVotingPowerSnapshot - set voter's power recorded in proposal snapshot to KVStore
+func (vs VoteStorage) SetVotingPowerSnapshot(ctx sdk.Context, proposalID types.ProposalKey, voter types.AccountKey, snapshot *VotingPowerSnapshot) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	snapshotByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*snapshot)
+	if err != nil {
+		return ErrFailedToMarshalSnapshot(err)
+	}
+	store.Set(getVotingPowerSnapshotKey(proposalID, voter), snapshotByte)
+	return nil
+}
+
+// DoesDelegationSnapshotExist - check if delegation amount is recorded in proposal snapshot or not
+func (vs VoteStorage) DoesDelegationSnapshotExist(ctx sdk.Context, proposalID types.ProposalKey, voter, delegator types.AccountKey) bool {
+	store := ctx.KVStore(vs.key)
+	return store.Has(getDelegationSnapshotKey(proposalID, delegator, voter))
+}
+
+// GetDelegationSnapshot - get delegation amount recorded in proposal snapshot from KVStore
+func (vs VoteStorage) GetDelegationSnapshot(ctx sdk.Context, proposalID types.ProposalKey, voter, delegator types.AccountKey) (*DelegationSnapshot, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	snapshotByte := store.Get(getDelegationSnapshotKey(proposalID, delegator, voter))
+	if snapshotByte == nil {
+		return nil, ErrSnapshotNotFound()
+	}
+	snapshot := new(DelegationSnapshot)
+	if err := vs.cdc.UnmarshalBinaryLengthPrefixed(snapshotByte, snapshot); err != nil {
+		return nil, ErrFailedToUnmarshalSnapshot(err)
+	}
+	return snapshot, nil
+}
+
+// SetDelegationSnapshot - set delegation amount recorded in proposal snapshot to KVStore
+func (vs VoteStorage) SetDelegationSnapshot(ctx sdk.Context, proposalID types.ProposalKey, voter, delegator types.AccountKey, snapshot *DelegationSnapshot) sdk.Error {
+	store := ctx.KVStore(vs.key)
+	snapshotByte, err := vs.cdc.MarshalBinaryLengthPrefixed(*snapshot)
+	if err != nil {
+		return ErrFailedToMarshalSnapshot(err)
+	}
+	store.Set(getDelegationSnapshotKey(proposalID, delegator, voter), snapshotByte)
+	return nil
+}
+
+// GetSnapshotDelegatees - get all voters whose delegation from delegator is recorded in proposal snapshot
+func (vs VoteStorage) GetSnapshotDelegatees(ctx sdk.Context, proposalID types.ProposalKey, delegator types.AccountKey) []types.AccountKey {
+	store := ctx.KVStore(vs.key)
+	prefix := getDelegationSnapshotDelegatorPrefix(proposalID, delegator)
+	iterator := store.Iterator(subspace(prefix))
+	defer iterator.Close()
+
+	var voters []types.AccountKey
+	for ; iterator.Valid(); iterator.Next() {
+		voters = append(voters, types.AccountKey(iterator.Key()[len(prefix):]))
+	}
+	return voters
+}
+
+// GetAllDelegatees - get all voters a delegator delegates to from KVStore
+func (vs VoteStorage) GetAllDelegatees(ctx sdk.Context, delegator types.AccountKey) ([]types.AccountKey, sdk.Error) {
+	store := ctx.KVStore(vs.key)
+	prefix := getDelegateePrefix(delegator)
+	iterator := store.Iterator(subspace(prefix))
+	defer iterator.Close()
+
+	var voters []types.AccountKey
+	for ; iterator.Valid(); iterator.Next() {
+		voters = append(voters, types.AccountKey(iterator.Key()[len(prefix):]))
+	}
+	return voters, nil
+}
+
 // GetAllDelegators - get all delegators of a voter from KVStore
 func (vs VoteStorage) GetAllDelegators(ctx sdk.Context, voterName types.AccountKey) ([]types.AccountKey, sdk.Error) {
 	store := ctx.KVStore(vs.key)
@@ -261,6 +521,117 @@ func (vs VoteStorage) Export(ctx sdk.Context) *VoterTables {
 		}
 	}()
 
+	// export table.Distributions
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, distributionSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			username := types.AccountKey(k[1:])
+			val, err := vs.GetDistribution(ctx, username)
+			if err != nil {
+				panic("failed to read distribution: " + err.Error())
+			}
+			row := DistributionRow{
+				Username:     username,
+				Distribution: *val,
+			}
+			tables.Distributions = append(tables.Distributions, row)
+		}
+	}()
+	// export table.RewardSnapshots
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, rewardSnapshotSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			meDelegator := string(k[1:])
+			strs := strings.Split(meDelegator, types.KeySeparator)
+			if len(strs) != 2 {
+				panic("failed to split out meDelegator: " + meDelegator)
+			}
+			voter, delegator := types.AccountKey(strs[0]), types.AccountKey(strs[1])
+			val, err := vs.GetRewardSnapshot(ctx, voter, delegator)
+			if err != nil {
+				panic("failed to read reward snapshot: " + err.Error())
+			}
+			row := RewardSnapshotRow{
+				Voter:     voter,
+				Delegator: delegator,
+				Snapshot:  *val,
+			}
+			tables.RewardSnapshots = append(tables.RewardSnapshots, row)
+		}
+	}()
+	// export table.ProposalSnapshots
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, proposalSnapshotSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			proposalID := types.ProposalKey(k[1:])
+			val, err := vs.GetProposalSnapshot(ctx, proposalID)
+			if err != nil {
+				panic("failed to read proposal snapshot: " + err.Error())
+			}
+			row := ProposalSnapshotRow{
+				ProposalID: proposalID,
+				Snapshot:   *val,
+			}
+			tables.ProposalSnapshots = append(tables.ProposalSnapshots, row)
+		}
+	}()
+	// export table.VotingPowerSnapshots
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, votingPowerSnapshotSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			idVoter := string(k[1:])
+			strs := strings.Split(idVoter, types.KeySeparator)
+			if len(strs) != 2 {
+				panic("failed to split out idVoter: " + idVoter)
+			}
+			proposalID, voter := types.ProposalKey(strs[0]), types.AccountKey(strs[1])
+			val, err := vs.GetVotingPowerSnapshot(ctx, proposalID, voter)
+			if err != nil {
+				panic("failed to read voting power snapshot: " + err.Error())
+			}
+			row := VotingPowerSnapshotRow{
+				ProposalID: proposalID,
+				Voter:      voter,
+				Snapshot:   *val,
+			}
+			tables.VotingPowerSnapshots = append(tables.VotingPowerSnapshots, row)
+		}
+	}()
+	// export table.DelegationSnapshots
+	func() {
+		itr := sdk.KVStorePrefixIterator(store, delegationSnapshotSubStore)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			k := itr.Key()
+			idDelegatorVoter := string(k[1:])
+			strs := strings.Split(idDelegatorVoter, types.KeySeparator)
+			if len(strs) != 3 {
+				panic("failed to split out idDelegatorVoter: " + idDelegatorVoter)
+			}
+			proposalID := types.ProposalKey(strs[0])
+			delegator, voter := types.AccountKey(strs[1]), types.AccountKey(strs[2])
+			val, err := vs.GetDelegationSnapshot(ctx, proposalID, voter, delegator)
+			if err != nil {
+				panic("failed to read delegation snapshot: " + err.Error())
+			}
+			row := DelegationSnapshotRow{
+				ProposalID: proposalID,
+				Delegator:  delegator,
+				Voter:      voter,
+				Snapshot:   *val,
+			}
+			tables.DelegationSnapshots = append(tables.DelegationSnapshots, row)
+		}
+	}()
+
 	list, err := vs.GetReferenceList(ctx)
 	if err != nil {
 		panic("failed to get Reference List: " + err.Error())
@@ -288,6 +659,31 @@ func (vs VoteStorage) Import(ctx sdk.Context, ir *VoterTablesIR) {
 		err := vs.SetDelegation(ctx, v.Voter, v.Delegator, &v.Delegation)
 		check(err)
 	}
+	// import table.Distributions
+	for _, v := range ir.Distributions {
+		err := vs.SetDistribution(ctx, v.Username, &v.Distribution)
+		check(err)
+	}
+	// import table.RewardSnapshots
+	for _, v := range ir.RewardSnapshots {
+		err := vs.SetRewardSnapshot(ctx, v.Voter, v.Delegator, &v.Snapshot)
+		check(err)
+	}
+	// import table.ProposalSnapshots
+	for _, v := range ir.ProposalSnapshots {
+		err := vs.SetProposalSnapshot(ctx, v.ProposalID, &v.Snapshot)
+		check(err)
+	}
+	// import table.VotingPowerSnapshots
+	for _, v := range ir.VotingPowerSnapshots {
+		err := vs.SetVotingPowerSnapshot(ctx, v.ProposalID, v.Voter, &v.Snapshot)
+		check(err)
+	}
+	// import table.DelegationSnapshots
+	for _, v := range ir.DelegationSnapshots {
+		err := vs.SetDelegationSnapshot(ctx, v.ProposalID, v.Voter, v.Delegator, &v.Snapshot)
+		check(err)
+	}
 	// import table.ReferenceList
 	err := vs.SetReferenceList(ctx, &ir.ReferenceList.List)
 	check(err)
@@ -339,6 +735,39 @@ func getDelegateeKey(me, delegatee types.AccountKey) []byte {
 	return append(getDelegateePrefix(me), delegatee...)
 }
 
+func getDistributionKey(me types.AccountKey) []byte {
+	return append(distributionSubStore, me...)
+}
+
+func getRewardSnapshotKey(me, myDelegator types.AccountKey) []byte {
+	return append(append(append(rewardSnapshotSubStore, me...), types.KeySeparator...), myDelegator...)
+}
+
+func getProposalSnapshotKey(proposalID types.ProposalKey) []byte {
+	return append(proposalSnapshotSubStore, proposalID...)
+}
+
+func getVotingPowerSnapshotPrefix(proposalID types.ProposalKey) []byte {
+	return append(append(votingPowerSnapshotSubStore, proposalID...), types.KeySeparator...)
+}
+
+func getVotingPowerSnapshotKey(proposalID types.ProposalKey, voter types.AccountKey) []byte {
+	return append(getVotingPowerSnapshotPrefix(proposalID), voter...)
+}
+
+func getDelegationSnapshotPrefix(proposalID types.ProposalKey) []byte {
+	return append(append(delegationSnapshotSubStore, proposalID...), types.KeySeparator...)
+}
+
+func getDelegationSnapshotDelegatorPrefix(proposalID types.ProposalKey, delegator types.AccountKey) []byte {
+	return append(append(getDelegationSnapshotPrefix(proposalID), delegator...), types.KeySeparator...)
+}
+
+// getDelegationSnapshotKey - "delegation snapshot substore" + "proposalID" + "delegator" + "voter"
+func getDelegationSnapshotKey(proposalID types.ProposalKey, delegator, voter types.AccountKey) []byte {
+	return append(getDelegationSnapshotDelegatorPrefix(proposalID, delegator), voter...)
+}
+
 func subspace(prefix []byte) (start, end []byte) {
 	end = make([]byte, len(prefix))
 	copy(end, prefix)
@@ -512,3 +512,38 @@ func TestAllDelegation(t *testing.T) {
 		}
 	}
 }
+
+func TestDistributionAndRewardSnapshot(t *testing.T) {
+	ctx, vs := setup(t)
+	user1, user2, user3 :=
+		types.AccountKey("user1"), types.AccountKey("user2"), types.AccountKey("user3")
+
+	assert.False(t, vs.DoesDistributionExist(ctx, user1))
+	distribution := &Distribution{
+		CommissionRate: sdk.NewDecWithPrec(1, 1),
+		RewardPerPower: sdk.NewDecWithPrec(25, 2),
+		RewardPool:     types.NewCoinFromInt64(100),
+	}
+	assert.Nil(t, vs.SetDistribution(ctx, user1, distribution))
+	assert.True(t, vs.DoesDistributionExist(ctx, user1))
+	distributionPtr, err := vs.GetDistribution(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, *distribution, *distributionPtr)
+
+	assert.False(t, vs.DoesRewardSnapshotExist(ctx, user1, user2))
+	snapshot := &RewardSnapshot{RewardPerPower: sdk.NewDecWithPrec(25, 2)}
+	assert.Nil(t, vs.SetRewardSnapshot(ctx, user1, user2, snapshot))
+	snapshotPtr, err := vs.GetRewardSnapshot(ctx, user1, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, *snapshot, *snapshotPtr)
+	assert.Nil(t, vs.DeleteRewardSnapshot(ctx, user1, user2))
+	_, err = vs.GetRewardSnapshot(ctx, user1, user2)
+	assert.Equal(t, types.CodeDelegationNotFound, err.Code())
+
+	// delegatees are indexed by delegation
+	assert.Nil(t, vs.SetDelegation(ctx, user1, user2, &Delegation{user2, types.NewCoinFromInt64(1)}))
+	assert.Nil(t, vs.SetDelegation(ctx, user3, user2, &Delegation{user2, types.NewCoinFromInt64(1)}))
+	delegatees, err := vs.GetAllDelegatees(ctx, user2)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.AccountKey{user1, user3}, delegatees)
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	types "github.com/lino-network/lino/types"
 )
 
@@ -12,13 +14,27 @@ type Voter struct {
 	DelegateToOthers  types.Coin       `json:"delegate_to_others"`
 	LastPowerChangeAt int64            `json:"last_power_change_at"`
 	Interest          types.Coin       `json:"interest"`
+	LastRedelegateAt  int64            `json:"last_redelegate_at"`
 }
 
-// Vote - a vote is created by a voter to a proposal
+// Vote - a vote is created by a voter to a proposal, result is true only if
+// the option is yes.
 type Vote struct {
 	Voter       types.AccountKey `json:"voter"`
 	VotingPower types.Coin       `json:"voting_power"`
 	Result      bool             `json:"result"`
+	Option      types.VoteOption `json:"option"`
+}
+
+// GetOption - option of the vote, votes cast before options were added only have result
+func (v Vote) GetOption() types.VoteOption {
+	if v.Option != types.VoteOptionUnspecified {
+		return v.Option
+	}
+	if v.Result {
+		return types.VoteOptionYes
+	}
+	return types.VoteOptionNo
 }
 
 // Delegation - normal user can delegate money to a voter to increase voter's voting power
@@ -31,3 +47,34 @@ type Delegation struct {
 type ReferenceList struct {
 	AllValidators []types.AccountKey `json:"all_validators"`
 }
+
+// Distribution - commission rate of a voter and reward reserved for its delegators.
+// RewardPerPower accumulates reward distributed per unit of delegated power,
+// RewardPool holds reward distributed but not yet settled to delegators.
+type Distribution struct {
+	CommissionRate sdk.Dec    `json:"commission_rate"`
+	RewardPerPower sdk.Dec    `json:"reward_per_power"`
+	RewardPool     types.Coin `json:"reward_pool"`
+}
+
+// RewardSnapshot - voter's RewardPerPower when the delegation was last settled
+type RewardSnapshot struct {
+	RewardPerPower sdk.Dec `json:"reward_per_power"`
+}
+
+// ProposalSnapshot - voting power of voters is fixed at the creation of an ongoing proposal
+type ProposalSnapshot struct {
+	CreatedHeight int64 `json:"created_height"`
+}
+
+// VotingPowerSnapshot - voting power of a voter at the creation of a proposal,
+// recorded before voter's power first changes after the creation.
+type VotingPowerSnapshot struct {
+	VotingPower types.Coin `json:"voting_power"`
+}
+
+// DelegationSnapshot - amount of a delegation at the creation of a proposal,
+// recorded before the delegation first changes after the creation.
+type DelegationSnapshot struct {
+	Amount types.Coin `json:"amount"`
+}
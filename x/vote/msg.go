@@ -14,6 +14,8 @@ var _ types.Msg = StakeOutMsg{}
 var _ types.Msg = DelegateMsg{}
 var _ types.Msg = DelegatorWithdrawMsg{}
 var _ types.Msg = ClaimInterestMsg{}
+var _ types.Msg = RedelegateMsg{}
+var _ types.Msg = SetCommissionMsg{}
 
 // StakeInMsg - voter deposit
 type StakeInMsg struct {
@@ -46,6 +48,21 @@ type ClaimInterestMsg struct {
 	Username types.AccountKey `json:"username"`
 }
 
+// RedelegateMsg - delegator moves delegation from one voter to another
+type RedelegateMsg struct {
+	Delegator types.AccountKey `json:"delegator"`
+	FromVoter types.AccountKey `json:"from_voter"`
+	ToVoter   types.AccountKey `json:"to_voter"`
+	Amount    types.LNO        `json:"amount"`
+}
+
+// SetCommissionMsg - voter sets the share of delegators' validator inflation it keeps,
+// the rest goes to delegators
+type SetCommissionMsg struct {
+	Username       types.AccountKey `json:"username"`
+	CommissionRate string           `json:"commission_rate"`
+}
+
 // NewStakeInMsg - return a StakeInMsg
 func NewStakeInMsg(username string, deposit types.LNO) StakeInMsg {
 	return StakeInMsg{
@@ -323,3 +340,137 @@ func (msg ClaimInterestMsg) GetSigners() []sdk.AccAddress {
 func (msg ClaimInterestMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+// NewRedelegateMsg - return a RedelegateMsg
+func NewRedelegateMsg(delegator, fromVoter, toVoter string, amount types.LNO) RedelegateMsg {
+	return RedelegateMsg{
+		Delegator: types.AccountKey(delegator),
+		FromVoter: types.AccountKey(fromVoter),
+		ToVoter:   types.AccountKey(toVoter),
+		Amount:    amount,
+	}
+}
+
+// Route - implements sdk.Msg
+func (msg RedelegateMsg) Route() string { return RouterKey }
+
+// Type - implements sdk.Msg
+func (msg RedelegateMsg) Type() string { return "RedelegateMsg" }
+
+// ValidateBasic - implements sdk.Msg
+func (msg RedelegateMsg) ValidateBasic() sdk.Error {
+	if len(msg.Delegator) < types.MinimumUsernameLength ||
+		len(msg.Delegator) > types.MaximumUsernameLength ||
+		len(msg.FromVoter) < types.MinimumUsernameLength ||
+		len(msg.FromVoter) > types.MaximumUsernameLength ||
+		len(msg.ToVoter) < types.MinimumUsernameLength ||
+		len(msg.ToVoter) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if msg.FromVoter == msg.ToVoter {
+		return ErrInvalidRedelegate()
+	}
+	_, err := types.LinoToCoin(msg.Amount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (msg RedelegateMsg) String() string {
+	return fmt.Sprintf("RedelegateMsg{Delegator:%v, FromVoter:%v, ToVoter:%v, Amount:%v}",
+		msg.Delegator, msg.FromVoter, msg.ToVoter, msg.Amount)
+}
+
+// GetPermission - implements types.Msg
+func (msg RedelegateMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg RedelegateMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg RedelegateMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Delegator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg RedelegateMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewSetCommissionMsg - return a SetCommissionMsg
+func NewSetCommissionMsg(username, commissionRate string) SetCommissionMsg {
+	return SetCommissionMsg{
+		Username:       types.AccountKey(username),
+		CommissionRate: commissionRate,
+	}
+}
+
+// Route - implements sdk.Msg
+func (msg SetCommissionMsg) Route() string { return RouterKey }
+
+// Type - implements sdk.Msg
+func (msg SetCommissionMsg) Type() string { return "SetCommissionMsg" }
+
+// ValidateBasic - implements sdk.Msg
+func (msg SetCommissionMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if _, err := msg.GetCommissionRate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetCommissionRate - parse commission rate, must be in [0, 1]
+func (msg SetCommissionMsg) GetCommissionRate() (sdk.Dec, sdk.Error) {
+	if len(msg.CommissionRate) > types.MaximumSdkRatLength {
+		return sdk.ZeroDec(), ErrInvalidCommissionRate()
+	}
+	rate, err := sdk.NewDecFromStr(msg.CommissionRate)
+	if err != nil {
+		return sdk.ZeroDec(), ErrInvalidCommissionRate()
+	}
+	if rate.LT(sdk.ZeroDec()) || rate.GT(sdk.OneDec()) {
+		return sdk.ZeroDec(), ErrInvalidCommissionRate()
+	}
+	return rate, nil
+}
+
+func (msg SetCommissionMsg) String() string {
+	return fmt.Sprintf("SetCommissionMsg{Username:%v, CommissionRate:%v}", msg.Username, msg.CommissionRate)
+}
+
+// GetPermission - implements types.Msg
+func (msg SetCommissionMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg SetCommissionMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg SetCommissionMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg SetCommissionMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
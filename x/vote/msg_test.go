@@ -177,6 +177,98 @@ func TestDelegatorWithdrawMsg(t *testing.T) {
 	}
 }
 
+func TestRedelegateMsg(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		redelegateMsg RedelegateMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			redelegateMsg: NewRedelegateMsg("user1", "user2", "user3", "1"),
+			expectedError: nil,
+		},
+		{
+			testName:      "invalid username",
+			redelegateMsg: NewRedelegateMsg("user1", "user2", "", "1"),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "redelegate to the same voter",
+			redelegateMsg: NewRedelegateMsg("user1", "user2", "user2", "1"),
+			expectedError: ErrInvalidRedelegate(),
+		},
+		{
+			testName:      "invalid redelegate amount",
+			redelegateMsg: NewRedelegateMsg("user1", "user2", "user3", "-1"),
+			expectedError: types.ErrInvalidCoins("LNO can't be less than lower bound"),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.redelegateMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, expect %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestSetCommissionMsg(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		msg           SetCommissionMsg
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "normal case",
+			msg:           NewSetCommissionMsg("user1", "0.1"),
+			expectedError: nil,
+		},
+		{
+			testName:      "zero commission",
+			msg:           NewSetCommissionMsg("user1", "0"),
+			expectedError: nil,
+		},
+		{
+			testName:      "full commission",
+			msg:           NewSetCommissionMsg("user1", "1"),
+			expectedError: nil,
+		},
+		{
+			testName:      "invalid username",
+			msg:           NewSetCommissionMsg("", "0.1"),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName:      "negative commission",
+			msg:           NewSetCommissionMsg("user1", "-0.1"),
+			expectedError: ErrInvalidCommissionRate(),
+		},
+		{
+			testName:      "commission larger than one",
+			msg:           NewSetCommissionMsg("user1", "1.01"),
+			expectedError: ErrInvalidCommissionRate(),
+		},
+		{
+			testName:      "illegal commission",
+			msg:           NewSetCommissionMsg("user1", "abc"),
+			expectedError: ErrInvalidCommissionRate(),
+		},
+		{
+			testName:      "commission too long",
+			msg:           NewSetCommissionMsg("user1", "0.1000000001"),
+			expectedError: ErrInvalidCommissionRate(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, expect %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestMsgPermission(t *testing.T) {
 	testCases := []struct {
 		testName           string
@@ -203,6 +295,16 @@ func TestMsgPermission(t *testing.T) {
 			msg:                NewDelegatorWithdrawMsg("delegator", "voter", types.LNO("1")),
 			expectedPermission: types.TransactionPermission,
 		},
+		{
+			testName:           "redelegate",
+			msg:                NewRedelegateMsg("delegator", "voter1", "voter2", types.LNO("1")),
+			expectedPermission: types.TransactionPermission,
+		},
+		{
+			testName:           "set commission",
+			msg:                NewSetCommissionMsg("voter", "0.1"),
+			expectedPermission: types.TransactionPermission,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -234,6 +336,14 @@ func TestGetSignBytes(t *testing.T) {
 			testName: "delegate withdraw",
 			msg:      NewDelegatorWithdrawMsg("delegator", "voter", types.LNO("1")),
 		},
+		{
+			testName: "redelegate",
+			msg:      NewRedelegateMsg("delegator", "voter1", "voter2", types.LNO("1")),
+		},
+		{
+			testName: "set commission",
+			msg:      NewSetCommissionMsg("voter", "0.1"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -267,6 +377,16 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewDelegatorWithdrawMsg("delegator", "voter", types.LNO("1")),
 			expectSigners: []types.AccountKey{"delegator"},
 		},
+		{
+			testName:      "redelegate",
+			msg:           NewRedelegateMsg("delegator", "voter1", "voter2", types.LNO("1")),
+			expectSigners: []types.AccountKey{"delegator"},
+		},
+		{
+			testName:      "set commission",
+			msg:           NewSetCommissionMsg("voter", "0.1"),
+			expectSigners: []types.AccountKey{"voter"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -22,6 +22,8 @@ const (
 	QueryVote          = "vote"
 	QueryReferenceList = "refList"
 	QueryDelegatee     = "delegatee"
+	QueryPendingReward = "pendingReward"
+	QuerySnapshotPower = "snapshotPower"
 )
 
 // creates a querier for vote REST endpoints
@@ -40,6 +42,10 @@ func NewQuerier(vm VoteManager) sdk.Querier {
 			return queryReferenceList(ctx, cdc, path[1:], req, vm)
 		case QueryDelegatee:
 			return queryDelegatee(ctx, cdc, path[1:], req, vm)
+		case QueryPendingReward:
+			return queryPendingReward(ctx, cdc, path[1:], req, vm)
+		case QuerySnapshotPower:
+			return querySnapshotPower(ctx, cdc, path[1:], req, vm)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown vote query endpoint")
 		}
@@ -117,3 +123,37 @@ func queryDelegatee(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.Re
 	}
 	return res, nil
 }
+
+func queryPendingReward(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, vm VoteManager) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 1); err != nil {
+		return nil, err
+	}
+	rewards, err := vm.GetPendingDelegatorRewards(ctx, types.AccountKey(path[0]))
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(rewards)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
+
+func querySnapshotPower(ctx sdk.Context, cdc *wire.Codec, path []string, req abci.RequestQuery, vm VoteManager) ([]byte, sdk.Error) {
+	if err := types.CheckPathContentAndMinLength(path, 2); err != nil {
+		return nil, err
+	}
+	proposalID, voter := types.ProposalKey(path[0]), types.AccountKey(path[1])
+	if _, err := vm.storage.GetProposalSnapshot(ctx, proposalID); err != nil {
+		return nil, err
+	}
+	power, err := vm.GetSnapshotVotingPower(ctx, proposalID, voter)
+	if err != nil {
+		return nil, err
+	}
+	res, marshalErr := cdc.MarshalJSON(power)
+	if marshalErr != nil {
+		return nil, ErrQueryFailed()
+	}
+	return res, nil
+}
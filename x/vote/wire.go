@@ -11,6 +11,8 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(DelegateMsg{}, "lino/delegate", nil)
 	cdc.RegisterConcrete(DelegatorWithdrawMsg{}, "lino/delegateWithdraw", nil)
 	cdc.RegisterConcrete(ClaimInterestMsg{}, "lino/claimInterest", nil)
+	cdc.RegisterConcrete(RedelegateMsg{}, "lino/redelegate", nil)
+	cdc.RegisterConcrete(SetCommissionMsg{}, "lino/setCommission", nil)
 }
 
 var msgCdc = wire.New()